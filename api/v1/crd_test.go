@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+)
+
+func readCRD(t *testing.T, path string) *apiextensionsv1.CustomResourceDefinition {
+	t.Helper()
+	g := NewWithT(t)
+	data, err := os.ReadFile(filepath.Join("..", "..", "config", "crd", path))
+	g.Expect(err).ToNot(HaveOccurred())
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(yaml.Unmarshal(data, crd)).To(Succeed())
+	return crd
+}
+
+// schemaOf returns the v1 schema of the field at the dot separated path
+func schemaOf(t *testing.T, crd *apiextensionsv1.CustomResourceDefinition, path string) apiextensionsv1.JSONSchemaProps {
+	t.Helper()
+	for _, version := range crd.Spec.Versions {
+		if version.Name != GroupVersion.Version {
+			continue
+		}
+		props := *version.Schema.OpenAPIV3Schema
+		for _, field := range strings.Split(path, ".") {
+			if field == "[]" {
+				props = *props.Items.Schema
+				continue
+			}
+			next, ok := props.Properties[field]
+			if !ok {
+				t.Fatalf("%s: no field %s", crd.Name, path)
+			}
+			props = next
+		}
+		return props
+	}
+	t.Fatalf("%s: no %s version", crd.Name, GroupVersion.Version)
+	return apiextensionsv1.JSONSchemaProps{}
+}
+
+func TestCRD_storageVersion(t *testing.T) {
+	// every stored kind is a conversion hub
+	for name := range map[string]conversion.Hub{
+		"securesigns":   &Securesign{},
+		"trillians":     &Trillian{},
+		"trilliantrees": &TrillianTree{},
+		"fulcios":       &Fulcio{},
+		"rekors":        &Rekor{},
+		"ctlogs":        &CTlog{},
+		"tufs":          &Tuf{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			crd := readCRD(t, filepath.Join("bases", "rhtas.redhat.com_"+name+".yaml"))
+			storage := map[string]bool{}
+			for _, version := range crd.Spec.Versions {
+				g.Expect(version.Served).To(BeTrue(), version.Name)
+				storage[version.Name] = version.Storage
+			}
+			g.Expect(storage).To(Equal(map[string]bool{"v1": true, "v1alpha1": false}))
+
+			// the objects stored as v1 are only readable as v1alpha1 through the conversion webhook
+			patch := readCRD(t, filepath.Join("patches", "webhook_in_"+name+".yaml"))
+			g.Expect(patch.Name).To(Equal(crd.Name))
+			g.Expect(patch.Spec.Conversion.Strategy).To(Equal(apiextensionsv1.WebhookConverter))
+			g.Expect(*patch.Spec.Conversion.Webhook.ClientConfig.Service.Path).To(Equal("/convert"))
+		})
+	}
+}
+
+func TestCRD_defaults(t *testing.T) {
+	for _, tc := range []struct {
+		crd, path, want string
+	}{
+		{"trillians", "spec.database.create", "true"},
+		{"trillians", "spec.database.pvc.retain", "true"},
+		{"rekors", "spec.pvc.retain", "true"},
+		{"rekors", "spec.rekorSearchUI.enabled", "true"},
+		{"rekors", "spec.backFillRedis.enabled", "true"},
+		{"tufs", "spec.port", "80"},
+	} {
+		t.Run(tc.crd+"/"+tc.path, func(t *testing.T) {
+			g := NewWithT(t)
+			props := schemaOf(t, readCRD(t, filepath.Join("bases", "rhtas.redhat.com_"+tc.crd+".yaml")), tc.path)
+			g.Expect(props.Default).ToNot(BeNil())
+			g.Expect(string(props.Default.Raw)).To(Equal(tc.want))
+		})
+	}
+
+	t.Run("rekors/spec.sharding.[].treeLength", func(t *testing.T) {
+		g := NewWithT(t)
+		crd := readCRD(t, filepath.Join("bases", "rhtas.redhat.com_rekors.yaml"))
+		g.Expect(schemaOf(t, crd, "spec.sharding.[]").Required).ToNot(ContainElement("treeLength"))
+	})
+}
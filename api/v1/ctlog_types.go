@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// CTlogSpec defines the desired state of CTlog component
+// +kubebuilder:validation:XValidation:rule=(!has(self.publicKeyRef) || has(self.privateKeyRef)),message=privateKeyRef cannot be empty
+// +kubebuilder:validation:XValidation:rule=(!has(self.privateKeyPasswordRef) || has(self.privateKeyRef)),message=privateKeyRef cannot be empty
+// +kubebuilder:validation:XValidation:rule=(!has(self.treeID) || !has(self.treeRef)),message=treeID and treeRef are mutually exclusive
+type CTlogSpec struct {
+	// The ID of a Trillian tree that stores the log data.
+	// If it is unset, the operator will create new Merkle tree in the Trillian backend
+	//+optional
+	TreeID *int64 `json:"treeID,omitempty"`
+
+	// Reference to a TrillianTree resource that provides the Trillian tree
+	//+optional
+	TreeRef *LocalObjectReference `json:"treeRef,omitempty"`
+
+	// The private key used for signing STHs etc.
+	//+optional
+	PrivateKeyRef *SecretKeySelector `json:"privateKeyRef,omitempty"`
+
+	// Password to decrypt private key
+	//+optional
+	PrivateKeyPasswordRef *SecretKeySelector `json:"privateKeyPasswordRef,omitempty"`
+
+	// The public key matching the private key (if both are present). It is
+	// used only by mirror logs for verifying the source log's signatures, but can
+	// be specified for regular logs as well for the convenience of test tools.
+	//+optional
+	PublicKeyRef *SecretKeySelector `json:"publicKeyRef,omitempty"`
+
+	// List of secrets containing root certificates that are acceptable to the log.
+	// The certs are served through get-roots endpoint. Optional in mirrors.
+	//+optional
+	RootCertificates []SecretKeySelector `json:"rootCertificates,omitempty"`
+
+	// Namespace of the root certificates, the namespace of the CTlog resource by default.
+	// Without root certificates the Fulcio CA secret is autodiscovered in this namespace.
+	//+kubebuilder:validation:MaxLength:=63
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	//+optional
+	RootCertificatesNamespace string `json:"rootCertificatesNamespace,omitempty"`
+
+	//Enable Service monitors for ctlog
+	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
+
+	// Trillian service configuration
+	//+kubebuilder:default:={port: 8091}
+	Trillian TrillianService `json:"trillian,omitempty"`
+
+	// Secret holding Certificate Transparency server config in text proto format
+	// If it is set then any setting of treeID, privateKeyRef, privateKeyPasswordRef,
+	// publicKeyRef, rootCertificates and trillian will be overridden.
+	//+optional
+	ServerConfigRef *LocalObjectReference `json:"serverConfigRef,omitempty"`
+
+	// Admission policy applied by the log to submitted certificate chains.
+	// It is ignored when serverConfigRef is set.
+	//+optional
+	Admission *CTlogAdmission `json:"admission,omitempty"`
+}
+
+// ExtKeyUsage is the name of an extended key usage accepted by the CT log.
+// The value "Any" disables the extended key usage check.
+// +kubebuilder:validation:Enum=Any;ServerAuth;ClientAuth;CodeSigning;EmailProtection;IPSECEndSystem;IPSECTunnel;IPSECUser;TimeStamping;OCSPSigning;MicrosoftServerGatedCrypto;NetscapeServerGatedCrypto
+type ExtKeyUsage string
+
+// CTlogAdmission defines which certificate chains are admitted to the log
+// +kubebuilder:validation:XValidation:rule=(!has(self.rejectExpired) || !self.rejectExpired || !has(self.rejectUnexpired) || !self.rejectUnexpired),message=rejectExpired and rejectUnexpired cannot be enabled at the same time
+// +kubebuilder:validation:XValidation:rule=(!has(self.notAfterStart) || !has(self.notAfterLimit) || timestamp(self.notAfterStart) <= timestamp(self.notAfterLimit)),message=notAfterStart must not be after notAfterLimit
+type CTlogAdmission struct {
+	// If true, the log rejects certificates that are expired, i.e. NotAfter < now.
+	//+optional
+	RejectExpired bool `json:"rejectExpired,omitempty"`
+
+	// If true, the log rejects certificates that are valid, i.e. NotAfter >= now.
+	// It cannot be combined with rejectExpired.
+	//+optional
+	RejectUnexpired bool `json:"rejectUnexpired,omitempty"`
+
+	// Extended key usages the log accepts. A certificate is admitted if it
+	// has at least one of them. Defaults to CodeSigning.
+	//+kubebuilder:validation:MinItems:=1
+	//+optional
+	ExtKeyUsages []ExtKeyUsage `json:"extKeyUsages,omitempty"`
+
+	// If set, the log rejects certificates with NotAfter before this time.
+	//+optional
+	NotAfterStart *metav1.Time `json:"notAfterStart,omitempty"`
+
+	// If set, the log rejects certificates with NotAfter equal to or after this time.
+	//+optional
+	NotAfterLimit *metav1.Time `json:"notAfterLimit,omitempty"`
+
+	// If true, the log accepts only CA certificates.
+	//+optional
+	AcceptOnlyCA bool `json:"acceptOnlyCA,omitempty"`
+
+	// List of certificate extension OIDs. Certificates containing any of them are rejected.
+	//+optional
+	RejectExtensions []string `json:"rejectExtensions,omitempty"`
+}
+
+// CTlogStatus defines the observed state of CTlog component
+type CTlogStatus struct {
+	ServerConfigRef       *LocalObjectReference `json:"serverConfigRef,omitempty"`
+	PrivateKeyRef         *SecretKeySelector    `json:"privateKeyRef,omitempty"`
+	PrivateKeyPasswordRef *SecretKeySelector    `json:"privateKeyPasswordRef,omitempty"`
+	PublicKeyRef          *SecretKeySelector    `json:"publicKeyRef,omitempty"`
+	RootCertificates      []SecretKeySelector   `json:"rootCertificates,omitempty"`
+	// Admission policy used to generate the current server config
+	Admission *CTlogAdmission `json:"admission,omitempty"`
+	// The ID of a Trillian tree that stores the log data.
+	TreeID *int64 `json:"treeID,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="The component status"
+
+// CTlog is the Schema for the ctlogs API
+type CTlog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CTlogSpec   `json:"spec,omitempty"`
+	Status CTlogStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CTlogList contains a list of CTlog
+type CTlogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CTlog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CTlog{}, &CTlogList{})
+}
+
+func (i *CTlog) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *CTlog) SetCondition(newCondition metav1.Condition) {
+	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
+}
+
+func (i *CTlog) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *CTlog) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
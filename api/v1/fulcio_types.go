@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// FulcioSpec defines the desired state of Fulcio
+type FulcioSpec struct {
+	// Define whether you want to export service or not
+	ExternalAccess ExternalAccess `json:"externalAccess,omitempty"`
+	// Ctlog service configuration
+	//+optional
+	//+kubebuilder:default:={port: 80, prefix: trusted-artifact-signer}
+	Ctlog CtlogService `json:"ctlog,omitempty"`
+	// Fulcio Configuration
+	//+required
+	Config FulcioConfig `json:"config"`
+	// Certificate configuration
+	Certificate FulcioCert `json:"certificate"`
+	//Enable Service monitors for fulcio
+	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
+	// ConfigMap with additional bundle of trusted CA
+	//+optional
+	TrustedCA *LocalObjectReference `json:"trustedCA,omitempty"`
+}
+
+// FulcioCert defines fields for system-generated certificate
+// +kubebuilder:validation:XValidation:rule=(has(self.caRef) || self.organizationName != ""),message=organizationName cannot be empty
+// +kubebuilder:validation:XValidation:rule=(!has(self.caRef) || has(self.privateKeyRef)),message=privateKeyRef cannot be empty
+type FulcioCert struct {
+	// Reference to CA private key
+	//+optional
+	PrivateKeyRef *SecretKeySelector `json:"privateKeyRef,omitempty"`
+	// Reference to password to encrypt CA private key
+	//+optional
+	PrivateKeyPasswordRef *SecretKeySelector `json:"privateKeyPasswordRef,omitempty"`
+
+	// Reference to CA certificate
+	//+optional
+	CARef *SecretKeySelector `json:"caRef,omitempty"`
+
+	//+optional
+	// CommonName specifies the common name for the Fulcio certificate.
+	// If not provided, the common name will default to the host name.
+	CommonName string `json:"commonName,omitempty"`
+	//+optional
+	OrganizationName string `json:"organizationName,omitempty"`
+	//+optional
+	OrganizationEmail string `json:"organizationEmail,omitempty"`
+}
+
+// FulcioConfig configuration of OIDC issuers
+// +kubebuilder:validation:XValidation:rule=(has(self.oidcIssuers) && (size(self.oidcIssuers) > 0)) || (has(self.metaIssuers) && (size(self.metaIssuers) > 0)),message=At least one of oidcIssuers or metaIssuers must be defined
+type FulcioConfig struct {
+	// OIDC Configuration
+	// +optional
+	OIDCIssuers []OIDCIssuer `json:"oidcIssuers,omitempty"`
+
+	// A meta issuer has a templated URL of the form:
+	//   https://oidc.eks.*.amazonaws.com/id/*
+	// Where * can match a single hostname or URI path parts
+	// (in particular, no '.' or '/' are permitted, among
+	// other special characters)  Some examples we want to match:
+	// * https://oidc.eks.us-west-2.amazonaws.com/id/B02C93B6A2D30341AD01E1B6D48164CB
+	// * https://container.googleapis.com/v1/projects/mattmoor-credit/locations/us-west1-b/clusters/tenant-cluster
+	// +optional
+	MetaIssuers []OIDCIssuer `json:"metaIssuers,omitempty"`
+}
+
+type OIDCIssuer struct {
+	// The URL the tokens of the issuer are verified against, the issuer by default
+	//+optional
+	IssuerURL string `json:"issuerURL,omitempty"`
+	// The expected issuer of an OIDC token
+	//+required
+	Issuer string `json:"issuer"`
+	//+required
+	ClientID string `json:"clientID"`
+	// Used to determine the subject of the certificate and if additional
+	// certificate values are needed
+	//+required
+	Type string `json:"type"`
+	// Optional, if the issuer is in a different claim in the OIDC token
+	IssuerClaim string `json:"issuerClaim,omitempty"`
+	// The domain that must be present in the subject for 'uri' issuer types
+	// Also used to create an email for 'username' issuer types
+	SubjectDomain string `json:"subjectDomain,omitempty"`
+	// SPIFFETrustDomain specifies the trust domain that 'spiffe' issuer types
+	// issue ID tokens for. Tokens with a different trust domain will be
+	// rejected.
+	SPIFFETrustDomain string `json:"spiffeTrustDomain,omitempty"`
+	// Optional, the challenge claim expected for the issuer
+	// Set if using a custom issuer
+	ChallengeClaim string `json:"challengeClaim,omitempty"`
+}
+
+// FulcioStatus defines the observed state of Fulcio
+type FulcioStatus struct {
+	ServerConfigRef *LocalObjectReference `json:"serverConfigRef,omitempty"`
+	Certificate     *FulcioCert           `json:"certificate,omitempty"`
+	Url             string                `json:"url,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="The component status"
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,description="The component url"
+
+// Fulcio is the Schema for the fulcios API
+type Fulcio struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FulcioSpec   `json:"spec,omitempty"`
+	Status FulcioStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FulcioList contains a list of Fulcio
+type FulcioList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Fulcio `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Fulcio{}, &FulcioList{})
+}
+
+func (i *Fulcio) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *Fulcio) SetCondition(newCondition metav1.Condition) {
+	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
+}
+
+func (i *Fulcio) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Fulcio) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// SecuresignSpec defines the desired state of Securesign
+// +kubebuilder:validation:XValidation:rule="!self.components.trillian.external || !(self.components.rekor.enabled && !self.components.rekor.external) || (has(self.rekor) && has(self.rekor.trillian) && has(self.rekor.trillian.address))",message="rekor.trillian.address must be set when Trillian is external"
+// +kubebuilder:validation:XValidation:rule="!self.components.trillian.external || !(self.components.ctlog.enabled && !self.components.ctlog.external) || (has(self.ctlog) && has(self.ctlog.trillian) && has(self.ctlog.trillian.address))",message="ctlog.trillian.address must be set when Trillian is external"
+// +kubebuilder:validation:XValidation:rule="!self.components.ctlog.external || !(self.components.fulcio.enabled && !self.components.fulcio.external) || (has(self.fulcio) && has(self.fulcio.ctlog) && has(self.fulcio.ctlog.address))",message="fulcio.ctlog.address must be set when CTlog is external"
+// +kubebuilder:validation:XValidation:rule="self.components.trillian.enabled || (!(self.components.rekor.enabled && !self.components.rekor.external) && !(self.components.ctlog.enabled && !self.components.ctlog.external))",message="Rekor and CTlog deployed by Securesign require Trillian"
+// +kubebuilder:validation:XValidation:rule="self.components.ctlog.enabled || !(self.components.fulcio.enabled && !self.components.fulcio.external)",message="Fulcio deployed by Securesign requires CTlog"
+type SecuresignSpec struct {
+	Rekor    RekorSpec    `json:"rekor,omitempty"`
+	Fulcio   FulcioSpec   `json:"fulcio,omitempty"`
+	Trillian TrillianSpec `json:"trillian,omitempty"`
+	//+kubebuilder:default:={keys:{{name: rekor.pub},{name: ctfe.pub},{name: fulcio_v1.crt.pem}}}
+	Tuf   TufSpec   `json:"tuf,omitempty"`
+	Ctlog CTlogSpec `json:"ctlog,omitempty"`
+	// Components deployed by the Securesign resource. All of them are deployed by default.
+	//+kubebuilder:default:={}
+	//+optional
+	Components SecuresignComponents `json:"components,omitempty"`
+}
+
+// SecuresignStatus defines the observed state of Securesign
+type SecuresignStatus struct {
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions     []metav1.Condition       `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	RekorStatus    SecuresignRekorStatus    `json:"rekor,omitempty"`
+	FulcioStatus   SecuresignFulcioStatus   `json:"fulcio,omitempty"`
+	TufStatus      SecuresignTufStatus      `json:"tuf,omitempty"`
+	CTlogStatus    SecuresignCTlogStatus    `json:"ctlog,omitempty"`
+	TrillianStatus SecuresignTrillianStatus `json:"trillian,omitempty"`
+}
+
+// SecuresignComponents selects how the Securesign resource handles each of its components
+type SecuresignComponents struct {
+	//+kubebuilder:default:={}
+	//+optional
+	Trillian SecuresignComponent `json:"trillian,omitempty"`
+	//+kubebuilder:default:={}
+	//+optional
+	Fulcio SecuresignComponent `json:"fulcio,omitempty"`
+	//+kubebuilder:default:={}
+	//+optional
+	Rekor SecuresignComponent `json:"rekor,omitempty"`
+	//+kubebuilder:default:={}
+	//+optional
+	Ctlog SecuresignComponent `json:"ctlog,omitempty"`
+	//+kubebuilder:default:={}
+	//+optional
+	Tuf SecuresignComponent `json:"tuf,omitempty"`
+}
+
+// SecuresignComponent selects whether the component is deployed by the Securesign resource
+// +kubebuilder:validation:XValidation:rule="!self.external || self.enabled",message="external component must be enabled"
+type SecuresignComponent struct {
+	// Use the component. A disabled component is neither deployed nor used by the other components.
+	//+kubebuilder:default:=true
+	//+optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// The component is running outside of the Securesign resource and is not deployed by it.
+	// The components using it are configured with its address in their own spec.
+	//+kubebuilder:default:=false
+	//+optional
+	External bool `json:"external,omitempty"`
+	// Namespace the component resource is created in, the namespace of the Securesign resource by default.
+	// A component in another namespace is not owned by the Securesign resource, it is deleted by its finalizer.
+	// When the namespace changes, the component is recreated in the new namespace, its data is not moved.
+	//+kubebuilder:validation:MaxLength:=63
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	//+optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// IsEnabled returns true when the component is used by the Securesign resource
+func (c SecuresignComponent) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// IsManaged returns true when the component is deployed by the Securesign resource
+func (c SecuresignComponent) IsManaged() bool {
+	return c.IsEnabled() && !c.External
+}
+
+type SecuresignRekorStatus struct {
+	Url string `json:"url,omitempty"`
+	// URL of the Rekor Search UI
+	//+optional
+	RekorSearchUIUrl string `json:"rekorSearchUIUrl,omitempty"`
+	// The ID of the Trillian tree of the active shard
+	//+optional
+	TreeID *int64 `json:"treeID,omitempty"`
+	// Inactive shards of the log
+	//+optional
+	Sharding []RekorLogRange `json:"sharding,omitempty"`
+}
+
+type SecuresignFulcioStatus struct {
+	Url string `json:"url,omitempty"`
+	// Reference to the Fulcio CA certificate
+	//+optional
+	CARef *SecretKeySelector `json:"caRef,omitempty"`
+	// Expiration of the Fulcio CA certificate
+	//+optional
+	CAExpiration *metav1.Time `json:"caExpiration,omitempty"`
+}
+
+type SecuresignTufStatus struct {
+	Url string `json:"url,omitempty"`
+	// Keys published by the TUF repository
+	//+optional
+	Keys []TufKey `json:"keys,omitempty"`
+}
+
+type SecuresignCTlogStatus struct {
+	// The ID of the Trillian tree that stores the log data
+	//+optional
+	TreeID *int64 `json:"treeID,omitempty"`
+	// Reference to the secret with the CTlog public key
+	//+optional
+	PublicKeyRef *SecretKeySelector `json:"publicKeyRef,omitempty"`
+}
+
+type SecuresignTrillianStatus struct {
+	// Reference to the secret with the database connection
+	//+optional
+	DatabaseSecretRef *LocalObjectReference `json:"databaseSecretRef,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="The Deployment status"
+//+kubebuilder:printcolumn:name="Rekor URL",type=string,JSONPath=`.status.rekor.url`,description="The rekor url"
+//+kubebuilder:printcolumn:name="Fulcio URL",type=string,JSONPath=`.status.fulcio.url`,description="The fulcio url"
+//+kubebuilder:printcolumn:name="Tuf URL",type=string,JSONPath=`.status.tuf.url`,description="The tuf url"
+
+// Securesign is the Schema for the securesigns API
+type Securesign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecuresignSpec   `json:"spec,omitempty"`
+	Status SecuresignStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SecuresignList contains a list of Securesign
+type SecuresignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Securesign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Securesign{}, &SecuresignList{})
+}
+
+func (i *Securesign) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *Securesign) SetCondition(newCondition metav1.Condition) {
+	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
+}
+
+func (i *Securesign) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Securesign) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
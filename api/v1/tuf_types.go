@@ -0,0 +1,285 @@
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// TufSpec defines the desired state of Tuf
+// +kubebuilder:validation:XValidation:rule="has(self.repository) || !has(self.keys) || self.keys.all(k, !has(k.__namespace__))",message=keys from other namespaces require the repository generated by the operator
+type TufSpec struct {
+	// Define whether you want to export service or not
+	ExternalAccess ExternalAccess `json:"externalAccess,omitempty"`
+	// Port of the TUF server
+	//+kubebuilder:default:=80
+	//+kubebuilder:validation:Minimum:=1
+	//+kubebuilder:validation:Maximum:=65535
+	//+optional
+	Port int32 `json:"port,omitempty"`
+	// List of TUF targets which will be added to TUF root
+	//+kubebuilder:default:={{name: rekor.pub},{name: ctfe.pub},{name: fulcio_v1.crt.pem}}
+	//+kubebuilder:validation:MinItems:=1
+	Keys []TufKey `json:"keys,omitempty"`
+	// Configuration of the TUF repository generated and signed by the operator.
+	// The repository is stored in a ConfigMap, every replica serves the same content.
+	//+kubebuilder:default:={}
+	//+optional
+	Repository *TufRepository `json:"repository,omitempty"`
+	// Number of TUF server replicas. A PodDisruptionBudget keeps one replica available when more than one is requested.
+	//+kubebuilder:default:=2
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// External TUF repository the operator mirrors and serves instead of the repository it generates.
+	// The keys and the repository configuration are ignored.
+	//+optional
+	Mirror *TufMirror `json:"mirror,omitempty"`
+}
+
+// TufMirror configures the external TUF repository mirrored by the operator
+type TufMirror struct {
+	// URL of the mirrored TUF repository
+	//+kubebuilder:validation:Pattern:="^https?://"
+	//+required
+	URL string `json:"url"`
+	// Reference to the initial root metadata of the mirrored repository. The updates of the repository are verified
+	// starting from it.
+	//+required
+	Root SecretKeySelector `json:"root"`
+	// Period the mirrored repository is checked for updates at
+	//+kubebuilder:default:="1h"
+	//+kubebuilder:validation:XValidation:rule=(duration(self) >= duration('1m')),message=mirror interval must be at least 1m
+	//+optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// TufRepository configures the TUF repository generated and signed by the operator
+type TufRepository struct {
+	// Reference to the secret with the private keys signing the TUF metadata.
+	// The secret must contain the PEM encoded `root`, `targets`, `snapshot` and `timestamp` keys.
+	// If it is unset, the operator generates the keys.
+	//+optional
+	SigningKeys *LocalObjectReference `json:"signingKeys,omitempty"`
+	// Validity periods of the metadata of the top-level roles.
+	// The operator re-signs the snapshot and timestamp metadata when half of the period elapsed.
+	//+kubebuilder:default:={}
+	//+optional
+	Expiration TufExpiration `json:"expiration,omitempty"`
+	// Root role signed with offline keys.
+	// If it is unset, the root metadata is signed with the `root` key of the signing keys secret.
+	//+optional
+	Root *TufRoot `json:"root,omitempty"`
+	// Sigstore client configuration published as the `trusted_root.json` and `signing_config.json` targets.
+	// The Securesign resource fills the unset service URLs and the Rekor shards.
+	//+optional
+	TrustedRoot *TufTrustedRoot `json:"trustedRoot,omitempty"`
+}
+
+// TufTrustedRoot configures the Sigstore trusted root and signing config built from the TUF targets
+type TufTrustedRoot struct {
+	// URL of the Fulcio certificate authority
+	//+optional
+	FulcioURL string `json:"fulcioURL,omitempty"`
+	// URL of the Rekor transparency log
+	//+optional
+	RekorURL string `json:"rekorURL,omitempty"`
+	// URL of the OIDC provider issuing the identity tokens
+	//+optional
+	OIDCURL string `json:"oidcURL,omitempty"`
+	// Inactive Rekor log shards, their public keys verify the existing log entries
+	//+optional
+	RekorSharding []RekorLogRange `json:"rekorSharding,omitempty"`
+}
+
+// TufRoot configures the keys of the root role. A new root version is published once the threshold of the keys
+// of both the new and the previous root signed it.
+// +kubebuilder:validation:XValidation:rule=(!has(self.threshold) || self.threshold <= size(self.keys)),message=threshold must not exceed the number of root keys
+type TufRoot struct {
+	// References to the PEM encoded public keys of the root role
+	//+kubebuilder:validation:MinItems:=1
+	Keys []SecretKeySelector `json:"keys"`
+	// Number of root key signatures required to publish a new root version
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	Threshold int `json:"threshold,omitempty"`
+}
+
+type TufExpiration struct {
+	// Validity period of the root metadata
+	//+kubebuilder:default:="8760h"
+	//+kubebuilder:validation:XValidation:rule=(duration(self) > duration('0s')),message=root expiration must be positive
+	//+optional
+	Root metav1.Duration `json:"root,omitempty"`
+	// Validity period of the targets metadata
+	//+kubebuilder:default:="8760h"
+	//+kubebuilder:validation:XValidation:rule=(duration(self) > duration('0s')),message=targets expiration must be positive
+	//+optional
+	Targets metav1.Duration `json:"targets,omitempty"`
+	// Validity period of the snapshot metadata
+	//+kubebuilder:default:="168h"
+	//+kubebuilder:validation:XValidation:rule=(duration(self) >= duration('1h')),message=snapshot expiration must be at least 1h
+	//+optional
+	Snapshot metav1.Duration `json:"snapshot,omitempty"`
+	// Validity period of the timestamp metadata
+	//+kubebuilder:default:="24h"
+	//+kubebuilder:validation:XValidation:rule=(duration(self) >= duration('1h')),message=timestamp expiration must be at least 1h
+	//+optional
+	Timestamp metav1.Duration `json:"timestamp,omitempty"`
+}
+
+type TufKey struct {
+	// File name which will be used as TUF target.
+	//+required
+	//+kubebuilder:validation:Pattern:="^[-._a-zA-Z0-9]+$"
+	Name string `json:"name"`
+	// Reference to secret object
+	// If it is unset, the operator will try to autoconfigure secret reference, by searching secrets in namespace which
+	// contain `rhtas.redhat.com/$name` label.
+	//+optional
+	SecretRef *SecretKeySelector `json:"secretRef,omitempty"`
+	// Namespace of the secret, the namespace of the TUF resource by default.
+	// Secrets from other namespaces require the repository generated by the operator.
+	//+optional
+	Namespace string `json:"namespace,omitempty"`
+	// Label selector of the autoconfigured secrets, the `rhtas.redhat.com/$name` label by default.
+	// Every matching secret is published as a separate target named after the secret, e.g. `rekor-<secret>.pub`.
+	// The value of the `rhtas.redhat.com/$name` label selects the key of the secret, `$name` if the label is missing.
+	//+optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Usage of the key by Sigstore clients published in the target metadata.
+	// It is derived from the name of the well-known targets by default.
+	//+kubebuilder:validation:Enum:=Fulcio;Rekor;CTFE;TSA
+	//+optional
+	Usage string `json:"usage,omitempty"`
+}
+
+// TufStatus defines the observed state of Tuf
+type TufStatus struct {
+	Keys []TufKey `json:"keys,omitempty"`
+	Url  string   `json:"url,omitempty"`
+	// Status of the TUF repository generated by the operator
+	//+optional
+	Repository *TufRepositoryStatus `json:"repository,omitempty"`
+	// Status of the mirrored TUF repository
+	//+optional
+	Mirror *TufMirrorStatus `json:"mirror,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+type TufRepositoryStatus struct {
+	// Reference to the secret with the private keys signing the TUF metadata
+	//+optional
+	SigningKeys *LocalObjectReference `json:"signingKeys,omitempty"`
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Roles []TufRoleStatus `json:"roles,omitempty"`
+	// Root version waiting for the signatures of the root keys
+	//+optional
+	PendingRoot *TufPendingRootStatus `json:"pendingRoot,omitempty"`
+}
+
+// TufPendingRootStatus describes the root version waiting for signatures
+type TufPendingRootStatus struct {
+	// Version of the pending root
+	Version int64 `json:"version"`
+	// IDs of the root keys of the pending root
+	KeyIDs []string `json:"keyIDs"`
+	// Number of signatures required from the root keys of the pending root
+	Threshold int `json:"threshold"`
+	// IDs of the root keys of the published root
+	//+optional
+	PreviousKeyIDs []string `json:"previousKeyIDs,omitempty"`
+	// Number of signatures required from the root keys of the published root
+	//+optional
+	PreviousThreshold int `json:"previousThreshold,omitempty"`
+	// IDs of the keys which signed the pending root
+	//+optional
+	SignedKeyIDs []string `json:"signedKeyIDs,omitempty"`
+}
+
+// TufMirrorStatus describes the last update of the mirrored TUF repository
+type TufMirrorStatus struct {
+	// URL of the mirrored TUF repository
+	URL string `json:"url"`
+	// Version of the last verified timestamp metadata
+	//+optional
+	Version int64 `json:"version,omitempty"`
+	// Version of the last verified root metadata
+	//+optional
+	RootVersion int64 `json:"rootVersion,omitempty"`
+	// Time of the last verified update
+	//+optional
+	LastVerifiedTime *metav1.Time `json:"lastVerifiedTime,omitempty"`
+	// Time of the last update attempt
+	LastSyncTime metav1.Time `json:"lastSyncTime"`
+}
+
+// TufRoleStatus describes the published metadata of a TUF role
+type TufRoleStatus struct {
+	// Name of the role
+	Name string `json:"name"`
+	// Version of the published metadata
+	Version int64 `json:"version"`
+	// Expiry of the published metadata
+	Expires metav1.Time `json:"expires"`
+	// IDs of the keys signing the published metadata
+	//+optional
+	KeyIDs []string `json:"keyIDs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="The component status"
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`,description="The component url"
+
+// Tuf is the Schema for the tufs API
+type Tuf struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TufSpec   `json:"spec,omitempty"`
+	Status TufStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TufList contains a list of Tuf
+type TufList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tuf `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tuf{}, &TufList{})
+}
+
+func (i *Tuf) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *Tuf) SetCondition(newCondition metav1.Condition) {
+	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
+}
+
+func (i *Tuf) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Tuf) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
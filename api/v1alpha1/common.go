@@ -32,6 +32,11 @@ type TrillianService struct {
 	//+kubebuilder:default:=8091
 	//+optional
 	Port *int32 `json:"port,omitempty"`
+	// Secret holding the CA certificate used to verify the Trillian Log Server TLS certificate.
+	// If it is not set, the CA of the Trillian instance the address points to in the same namespace
+	// (or of the instance serving this resource when the address is not set) is used when it serves gRPC over TLS.
+	//+optional
+	CACertRef *SecretKeySelector `json:"caCertRef,omitempty"`
 }
 
 // CtlogService configuration to connect Ctlog server
@@ -53,6 +58,26 @@ type CtlogService struct {
 	Prefix string `json:"prefix,omitempty"`
 }
 
+// TLS (Transport Layer Security) configuration for enabling service encryption
+// +kubebuilder:validation:XValidation:rule=(has(self.certRef) == has(self.privateKeyRef)),message=certRef and privateKeyRef must be set together
+// +kubebuilder:validation:XValidation:rule=(!has(self.caCertRef) || has(self.certRef)),message=certRef cannot be empty
+type TLS struct {
+	// If true, the service is served over TLS. When certRef and privateKeyRef are not set,
+	// the Operator generates the certificate and rotates it before it expires.
+	//+kubebuilder:default:=false
+	Enabled bool `json:"enabled"`
+	// Reference to the TLS certificate
+	//+optional
+	CertRef *SecretKeySelector `json:"certRef,omitempty"`
+	// Reference to the private key of the TLS certificate
+	//+optional
+	PrivateKeyRef *SecretKeySelector `json:"privateKeyRef,omitempty"`
+	// Reference to the CA certificate that issued the TLS certificate.
+	// If it is not set, the TLS certificate itself is trusted by the clients.
+	//+optional
+	CACertRef *SecretKeySelector `json:"caCertRef,omitempty"`
+}
+
 // LocalObjectReference contains enough information to let you locate the
 // referenced object inside the same namespace.
 // +structType=atomic
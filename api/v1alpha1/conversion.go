@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+)
+
+// The types of v1alpha1 match the types of v1 field by field, the versions differ in the schema only.
+// The conversion from and to the Hub version (v1) is lossless in both directions.
+
+// convertPointer converts the value referenced by the pointer, nil is kept
+func convertPointer[T, U any](in *T, convert func(T) U) *U {
+	if in == nil {
+		return nil
+	}
+	out := convert(*in)
+	return &out
+}
+
+// convertSlice converts every item of the slice, nil is kept
+func convertSlice[T, U any](in []T, convert func(T) U) []U {
+	if in == nil {
+		return nil
+	}
+	out := make([]U, len(in))
+	for i := range in {
+		out[i] = convert(in[i])
+	}
+	return out
+}
+
+func convertExternalAccessToV1(in ExternalAccess) v1.ExternalAccess {
+	return v1.ExternalAccess(in)
+}
+
+func convertExternalAccessFromV1(in v1.ExternalAccess) ExternalAccess {
+	return ExternalAccess(in)
+}
+
+func convertMonitoringConfigToV1(in MonitoringConfig) v1.MonitoringConfig {
+	return v1.MonitoringConfig(in)
+}
+
+func convertMonitoringConfigFromV1(in v1.MonitoringConfig) MonitoringConfig {
+	return MonitoringConfig(in)
+}
+
+func convertTrillianServiceToV1(in TrillianService) v1.TrillianService {
+	return v1.TrillianService{
+		Address:   in.Address,
+		Port:      in.Port,
+		CACertRef: convertPointer(in.CACertRef, convertSecretKeySelectorToV1),
+	}
+}
+
+func convertTrillianServiceFromV1(in v1.TrillianService) TrillianService {
+	return TrillianService{
+		Address:   in.Address,
+		Port:      in.Port,
+		CACertRef: convertPointer(in.CACertRef, convertSecretKeySelectorFromV1),
+	}
+}
+
+func convertCtlogServiceToV1(in CtlogService) v1.CtlogService {
+	return v1.CtlogService(in)
+}
+
+func convertCtlogServiceFromV1(in v1.CtlogService) CtlogService {
+	return CtlogService(in)
+}
+
+func convertTLSToV1(in TLS) v1.TLS {
+	return v1.TLS{
+		Enabled:       in.Enabled,
+		CertRef:       convertPointer(in.CertRef, convertSecretKeySelectorToV1),
+		PrivateKeyRef: convertPointer(in.PrivateKeyRef, convertSecretKeySelectorToV1),
+		CACertRef:     convertPointer(in.CACertRef, convertSecretKeySelectorToV1),
+	}
+}
+
+func convertTLSFromV1(in v1.TLS) TLS {
+	return TLS{
+		Enabled:       in.Enabled,
+		CertRef:       convertPointer(in.CertRef, convertSecretKeySelectorFromV1),
+		PrivateKeyRef: convertPointer(in.PrivateKeyRef, convertSecretKeySelectorFromV1),
+		CACertRef:     convertPointer(in.CACertRef, convertSecretKeySelectorFromV1),
+	}
+}
+
+func convertLocalObjectReferenceToV1(in LocalObjectReference) v1.LocalObjectReference {
+	return v1.LocalObjectReference(in)
+}
+
+func convertLocalObjectReferenceFromV1(in v1.LocalObjectReference) LocalObjectReference {
+	return LocalObjectReference(in)
+}
+
+func convertSecretKeySelectorToV1(in SecretKeySelector) v1.SecretKeySelector {
+	return v1.SecretKeySelector{
+		LocalObjectReference: convertLocalObjectReferenceToV1(in.LocalObjectReference),
+		Key:                  in.Key,
+	}
+}
+
+func convertSecretKeySelectorFromV1(in v1.SecretKeySelector) SecretKeySelector {
+	return SecretKeySelector{
+		LocalObjectReference: convertLocalObjectReferenceFromV1(in.LocalObjectReference),
+		Key:                  in.Key,
+	}
+}
+
+func convertPvcToV1(in Pvc) v1.Pvc {
+	return v1.Pvc(in)
+}
+
+func convertPvcFromV1(in v1.Pvc) Pvc {
+	return Pvc(in)
+}
@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	"flag"
+	"math/rand"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "github.com/securesign/operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// conversionSeed reproduces a failed round trip, e.g. go test ./api/v1alpha1 -run TestConversion -conversion-seed=<seed>
+var conversionSeed = flag.Int64("conversion-seed", 0, "seed of the conversion fuzzer, random when 0")
+
+func TestConversion_roundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(AddToScheme(scheme)).To(Succeed())
+	NewWithT(t).Expect(v1.AddToScheme(scheme)).To(Succeed())
+	seed := *conversionSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t.Logf("conversion fuzzer seed %d", seed)
+	f := fuzzer.FuzzerFor(metafuzzer.Funcs, rand.NewSource(seed), serializer.NewCodecFactory(scheme))
+
+	for _, tc := range []struct {
+		name  string
+		spoke func() conversion.Convertible
+		hub   func() conversion.Hub
+	}{
+		{"Securesign", func() conversion.Convertible { return &Securesign{} }, func() conversion.Hub { return &v1.Securesign{} }},
+		{"Trillian", func() conversion.Convertible { return &Trillian{} }, func() conversion.Hub { return &v1.Trillian{} }},
+		{"TrillianTree", func() conversion.Convertible { return &TrillianTree{} }, func() conversion.Hub { return &v1.TrillianTree{} }},
+		{"Fulcio", func() conversion.Convertible { return &Fulcio{} }, func() conversion.Hub { return &v1.Fulcio{} }},
+		{"Rekor", func() conversion.Convertible { return &Rekor{} }, func() conversion.Hub { return &v1.Rekor{} }},
+		{"CTlog", func() conversion.Convertible { return &CTlog{} }, func() conversion.Hub { return &v1.CTlog{} }},
+		{"Tuf", func() conversion.Convertible { return &Tuf{} }, func() conversion.Hub { return &v1.Tuf{} }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			for i := 0; i < 100; i++ {
+				spoke, hub, restored := tc.spoke(), tc.hub(), tc.spoke()
+				f.Fuzz(spoke)
+				g.Expect(spoke.ConvertTo(hub)).To(Succeed())
+				g.Expect(restored.ConvertFrom(hub)).To(Succeed())
+				g.Expect(restored).To(Equal(spoke), "v1alpha1 -> v1 -> v1alpha1")
+
+				hub, spoke, restoredHub := tc.hub(), tc.spoke(), tc.hub()
+				f.Fuzz(hub)
+				g.Expect(spoke.ConvertFrom(hub)).To(Succeed())
+				g.Expect(spoke.ConvertTo(restoredHub)).To(Succeed())
+				g.Expect(restoredHub).To(Equal(hub), "v1 -> v1alpha1 -> v1")
+			}
+		})
+	}
+}
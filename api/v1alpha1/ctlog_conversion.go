@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertCTlogSpecToV1(in CTlogSpec) v1.CTlogSpec {
+	return v1.CTlogSpec{
+		TreeID:                    in.TreeID,
+		TreeRef:                   convertPointer(in.TreeRef, convertLocalObjectReferenceToV1),
+		PrivateKeyRef:             convertPointer(in.PrivateKeyRef, convertSecretKeySelectorToV1),
+		PrivateKeyPasswordRef:     convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorToV1),
+		PublicKeyRef:              convertPointer(in.PublicKeyRef, convertSecretKeySelectorToV1),
+		RootCertificates:          convertSlice(in.RootCertificates, convertSecretKeySelectorToV1),
+		RootCertificatesNamespace: in.RootCertificatesNamespace,
+		Monitoring:                convertMonitoringConfigToV1(in.Monitoring),
+		Trillian:                  convertTrillianServiceToV1(in.Trillian),
+		ServerConfigRef:           convertPointer(in.ServerConfigRef, convertLocalObjectReferenceToV1),
+		Admission:                 convertPointer(in.Admission, convertCTlogAdmissionToV1),
+	}
+}
+
+func convertCTlogSpecFromV1(in v1.CTlogSpec) CTlogSpec {
+	return CTlogSpec{
+		TreeID:                    in.TreeID,
+		TreeRef:                   convertPointer(in.TreeRef, convertLocalObjectReferenceFromV1),
+		PrivateKeyRef:             convertPointer(in.PrivateKeyRef, convertSecretKeySelectorFromV1),
+		PrivateKeyPasswordRef:     convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorFromV1),
+		PublicKeyRef:              convertPointer(in.PublicKeyRef, convertSecretKeySelectorFromV1),
+		RootCertificates:          convertSlice(in.RootCertificates, convertSecretKeySelectorFromV1),
+		RootCertificatesNamespace: in.RootCertificatesNamespace,
+		Monitoring:                convertMonitoringConfigFromV1(in.Monitoring),
+		Trillian:                  convertTrillianServiceFromV1(in.Trillian),
+		ServerConfigRef:           convertPointer(in.ServerConfigRef, convertLocalObjectReferenceFromV1),
+		Admission:                 convertPointer(in.Admission, convertCTlogAdmissionFromV1),
+	}
+}
+
+func convertExtKeyUsageToV1(in ExtKeyUsage) v1.ExtKeyUsage {
+	return v1.ExtKeyUsage(in)
+}
+
+func convertExtKeyUsageFromV1(in v1.ExtKeyUsage) ExtKeyUsage {
+	return ExtKeyUsage(in)
+}
+
+func convertCTlogAdmissionToV1(in CTlogAdmission) v1.CTlogAdmission {
+	return v1.CTlogAdmission{
+		RejectExpired:    in.RejectExpired,
+		RejectUnexpired:  in.RejectUnexpired,
+		ExtKeyUsages:     convertSlice(in.ExtKeyUsages, convertExtKeyUsageToV1),
+		NotAfterStart:    in.NotAfterStart,
+		NotAfterLimit:    in.NotAfterLimit,
+		AcceptOnlyCA:     in.AcceptOnlyCA,
+		RejectExtensions: in.RejectExtensions,
+	}
+}
+
+func convertCTlogAdmissionFromV1(in v1.CTlogAdmission) CTlogAdmission {
+	return CTlogAdmission{
+		RejectExpired:    in.RejectExpired,
+		RejectUnexpired:  in.RejectUnexpired,
+		ExtKeyUsages:     convertSlice(in.ExtKeyUsages, convertExtKeyUsageFromV1),
+		NotAfterStart:    in.NotAfterStart,
+		NotAfterLimit:    in.NotAfterLimit,
+		AcceptOnlyCA:     in.AcceptOnlyCA,
+		RejectExtensions: in.RejectExtensions,
+	}
+}
+
+func convertCTlogStatusToV1(in CTlogStatus) v1.CTlogStatus {
+	return v1.CTlogStatus{
+		ServerConfigRef:       convertPointer(in.ServerConfigRef, convertLocalObjectReferenceToV1),
+		PrivateKeyRef:         convertPointer(in.PrivateKeyRef, convertSecretKeySelectorToV1),
+		PrivateKeyPasswordRef: convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorToV1),
+		PublicKeyRef:          convertPointer(in.PublicKeyRef, convertSecretKeySelectorToV1),
+		RootCertificates:      convertSlice(in.RootCertificates, convertSecretKeySelectorToV1),
+		Admission:             convertPointer(in.Admission, convertCTlogAdmissionToV1),
+		TreeID:                in.TreeID,
+		ObservedGeneration:    in.ObservedGeneration,
+		Conditions:            in.Conditions,
+	}
+}
+
+func convertCTlogStatusFromV1(in v1.CTlogStatus) CTlogStatus {
+	return CTlogStatus{
+		ServerConfigRef:       convertPointer(in.ServerConfigRef, convertLocalObjectReferenceFromV1),
+		PrivateKeyRef:         convertPointer(in.PrivateKeyRef, convertSecretKeySelectorFromV1),
+		PrivateKeyPasswordRef: convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorFromV1),
+		PublicKeyRef:          convertPointer(in.PublicKeyRef, convertSecretKeySelectorFromV1),
+		RootCertificates:      convertSlice(in.RootCertificates, convertSecretKeySelectorFromV1),
+		Admission:             convertPointer(in.Admission, convertCTlogAdmissionFromV1),
+		TreeID:                in.TreeID,
+		ObservedGeneration:    in.ObservedGeneration,
+		Conditions:            in.Conditions,
+	}
+}
+
+// ConvertTo converts this CTlog to the Hub version (v1).
+func (src *CTlog) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.CTlog)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertCTlogSpecToV1(src.Spec)
+	dst.Status = convertCTlogStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *CTlog) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.CTlog)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertCTlogSpecFromV1(src.Spec)
+	dst.Status = convertCTlogStatusFromV1(src.Status)
+	return nil
+}
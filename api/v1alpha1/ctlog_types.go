@@ -11,12 +11,17 @@ import (
 // CTlogSpec defines the desired state of CTlog component
 // +kubebuilder:validation:XValidation:rule=(!has(self.publicKeyRef) || has(self.privateKeyRef)),message=privateKeyRef cannot be empty
 // +kubebuilder:validation:XValidation:rule=(!has(self.privateKeyPasswordRef) || has(self.privateKeyRef)),message=privateKeyRef cannot be empty
+// +kubebuilder:validation:XValidation:rule=(!has(self.treeID) || !has(self.treeRef)),message=treeID and treeRef are mutually exclusive
 type CTlogSpec struct {
 	// The ID of a Trillian tree that stores the log data.
 	// If it is unset, the operator will create new Merkle tree in the Trillian backend
 	//+optional
 	TreeID *int64 `json:"treeID,omitempty"`
 
+	// Reference to a TrillianTree resource that provides the Trillian tree
+	//+optional
+	TreeRef *LocalObjectReference `json:"treeRef,omitempty"`
+
 	// The private key used for signing STHs etc.
 	//+optional
 	PrivateKeyRef *SecretKeySelector `json:"privateKeyRef,omitempty"`
@@ -36,6 +41,13 @@ type CTlogSpec struct {
 	//+optional
 	RootCertificates []SecretKeySelector `json:"rootCertificates,omitempty"`
 
+	// Namespace of the root certificates, the namespace of the CTlog resource by default.
+	// Without root certificates the Fulcio CA secret is autodiscovered in this namespace.
+	//+kubebuilder:validation:MaxLength:=63
+	//+kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	//+optional
+	RootCertificatesNamespace string `json:"rootCertificatesNamespace,omitempty"`
+
 	//Enable Service monitors for ctlog
 	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
 
@@ -48,6 +60,52 @@ type CTlogSpec struct {
 	// publicKeyRef, rootCertificates and trillian will be overridden.
 	//+optional
 	ServerConfigRef *LocalObjectReference `json:"serverConfigRef,omitempty"`
+
+	// Admission policy applied by the log to submitted certificate chains.
+	// It is ignored when serverConfigRef is set.
+	//+optional
+	Admission *CTlogAdmission `json:"admission,omitempty"`
+}
+
+// ExtKeyUsage is the name of an extended key usage accepted by the CT log.
+// The value "Any" disables the extended key usage check.
+// +kubebuilder:validation:Enum=Any;ServerAuth;ClientAuth;CodeSigning;EmailProtection;IPSECEndSystem;IPSECTunnel;IPSECUser;TimeStamping;OCSPSigning;MicrosoftServerGatedCrypto;NetscapeServerGatedCrypto
+type ExtKeyUsage string
+
+// CTlogAdmission defines which certificate chains are admitted to the log
+// +kubebuilder:validation:XValidation:rule=(!has(self.rejectExpired) || !self.rejectExpired || !has(self.rejectUnexpired) || !self.rejectUnexpired),message=rejectExpired and rejectUnexpired cannot be enabled at the same time
+// +kubebuilder:validation:XValidation:rule=(!has(self.notAfterStart) || !has(self.notAfterLimit) || timestamp(self.notAfterStart) <= timestamp(self.notAfterLimit)),message=notAfterStart must not be after notAfterLimit
+type CTlogAdmission struct {
+	// If true, the log rejects certificates that are expired, i.e. NotAfter < now.
+	//+optional
+	RejectExpired bool `json:"rejectExpired,omitempty"`
+
+	// If true, the log rejects certificates that are valid, i.e. NotAfter >= now.
+	// It cannot be combined with rejectExpired.
+	//+optional
+	RejectUnexpired bool `json:"rejectUnexpired,omitempty"`
+
+	// Extended key usages the log accepts. A certificate is admitted if it
+	// has at least one of them. Defaults to CodeSigning.
+	//+kubebuilder:validation:MinItems:=1
+	//+optional
+	ExtKeyUsages []ExtKeyUsage `json:"extKeyUsages,omitempty"`
+
+	// If set, the log rejects certificates with NotAfter before this time.
+	//+optional
+	NotAfterStart *metav1.Time `json:"notAfterStart,omitempty"`
+
+	// If set, the log rejects certificates with NotAfter equal to or after this time.
+	//+optional
+	NotAfterLimit *metav1.Time `json:"notAfterLimit,omitempty"`
+
+	// If true, the log accepts only CA certificates.
+	//+optional
+	AcceptOnlyCA bool `json:"acceptOnlyCA,omitempty"`
+
+	// List of certificate extension OIDs. Certificates containing any of them are rejected.
+	//+optional
+	RejectExtensions []string `json:"rejectExtensions,omitempty"`
 }
 
 // CTlogStatus defines the observed state of CTlog component
@@ -57,8 +115,13 @@ type CTlogStatus struct {
 	PrivateKeyPasswordRef *SecretKeySelector    `json:"privateKeyPasswordRef,omitempty"`
 	PublicKeyRef          *SecretKeySelector    `json:"publicKeyRef,omitempty"`
 	RootCertificates      []SecretKeySelector   `json:"rootCertificates,omitempty"`
+	// Admission policy used to generate the current server config
+	Admission *CTlogAdmission `json:"admission,omitempty"`
 	// The ID of a Trillian tree that stores the log data.
 	TreeID *int64 `json:"treeID,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
@@ -100,3 +163,11 @@ func (i *CTlog) GetConditions() []metav1.Condition {
 func (i *CTlog) SetCondition(newCondition metav1.Condition) {
 	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
 }
+
+func (i *CTlog) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *CTlog) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"golang.org/x/net/context"
@@ -67,6 +69,39 @@ var _ = Describe("CTlog", func() {
 				Expect(k8sClient.Create(context.Background(), invalidObject)).
 					To(MatchError(ContainSubstring("privateKeyRef cannot be empty")))
 			})
+
+			It("admission reject all certificates", func() {
+				invalidObject := generateCTlogObject("admission-reject-all-invalid")
+				invalidObject.Spec.Admission = &CTlogAdmission{
+					RejectExpired:   true,
+					RejectUnexpired: true,
+				}
+
+				Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+				Expect(k8sClient.Create(context.Background(), invalidObject)).
+					To(MatchError(ContainSubstring("rejectExpired and rejectUnexpired cannot be enabled at the same time")))
+			})
+
+			It("admission notAfter window", func() {
+				invalidObject := generateCTlogObject("admission-not-after-invalid")
+				invalidObject.Spec.Admission = &CTlogAdmission{
+					NotAfterStart: &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+					NotAfterLimit: &metav1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}
+
+				Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+				Expect(k8sClient.Create(context.Background(), invalidObject)).
+					To(MatchError(ContainSubstring("notAfterStart must not be after notAfterLimit")))
+			})
+
+			It("admission extended key usage", func() {
+				invalidObject := generateCTlogObject("admission-eku-invalid")
+				invalidObject.Spec.Admission = &CTlogAdmission{
+					ExtKeyUsages: []ExtKeyUsage{"Unknown"},
+				}
+
+				Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+			})
 		})
 
 		Context("Default settings", func() {
@@ -0,0 +1,50 @@
+package v1alpha1
+
+// The defaults the CRD schema can't express. They are applied by the defaulting webhooks and by the Securesign
+// controller to the component resources it creates.
+
+// defaultExtKeyUsage is the extended key usage accepted by the CT log when the admission policy lists none
+const defaultExtKeyUsage ExtKeyUsage = "CodeSigning"
+
+// targetUsages maps the well-known TUF targets to the usage Sigstore clients look for
+var targetUsages = map[string]string{
+	"fulcio_v1.crt.pem": "Fulcio",
+	"rekor.pub":         "Rekor",
+	"ctfe.pub":          "CTFE",
+	"tsa.certchain.pem": "TSA",
+}
+
+// TargetUsage returns the Sigstore usage of the well-known TUF target
+func TargetUsage(name string) string {
+	return targetUsages[name]
+}
+
+// DefaultCTlogSpec sets the extended key usages of an admission policy listing none
+func DefaultCTlogSpec(spec *CTlogSpec) {
+	if spec.Admission != nil && len(spec.Admission.ExtKeyUsages) == 0 {
+		spec.Admission.ExtKeyUsages = []ExtKeyUsage{defaultExtKeyUsage}
+	}
+}
+
+// DefaultRekorSpec sets the signer KMS, an empty KMS is served by the secret signer
+func DefaultRekorSpec(spec *RekorSpec) {
+	if spec.Signer.KMS == "" {
+		spec.Signer.KMS = "secret"
+	}
+}
+
+// DefaultTrillianSpec enables TLS when a certificate is provided
+func DefaultTrillianSpec(spec *TrillianSpec) {
+	if spec.TLS.CertRef != nil {
+		spec.TLS.Enabled = true
+	}
+}
+
+// DefaultTufSpec sets the usage of the well-known targets
+func DefaultTufSpec(spec *TufSpec) {
+	for i := range spec.Keys {
+		if key := &spec.Keys[i]; key.Usage == "" {
+			key.Usage = TargetUsage(key.Name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertFulcioSpecToV1(in FulcioSpec) v1.FulcioSpec {
+	return v1.FulcioSpec{
+		ExternalAccess: convertExternalAccessToV1(in.ExternalAccess),
+		Ctlog:          convertCtlogServiceToV1(in.Ctlog),
+		Config:         convertFulcioConfigToV1(in.Config),
+		Certificate:    convertFulcioCertToV1(in.Certificate),
+		Monitoring:     convertMonitoringConfigToV1(in.Monitoring),
+		TrustedCA:      convertPointer(in.TrustedCA, convertLocalObjectReferenceToV1),
+	}
+}
+
+func convertFulcioSpecFromV1(in v1.FulcioSpec) FulcioSpec {
+	return FulcioSpec{
+		ExternalAccess: convertExternalAccessFromV1(in.ExternalAccess),
+		Ctlog:          convertCtlogServiceFromV1(in.Ctlog),
+		Config:         convertFulcioConfigFromV1(in.Config),
+		Certificate:    convertFulcioCertFromV1(in.Certificate),
+		Monitoring:     convertMonitoringConfigFromV1(in.Monitoring),
+		TrustedCA:      convertPointer(in.TrustedCA, convertLocalObjectReferenceFromV1),
+	}
+}
+
+func convertFulcioCertToV1(in FulcioCert) v1.FulcioCert {
+	return v1.FulcioCert{
+		PrivateKeyRef:         convertPointer(in.PrivateKeyRef, convertSecretKeySelectorToV1),
+		PrivateKeyPasswordRef: convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorToV1),
+		CARef:                 convertPointer(in.CARef, convertSecretKeySelectorToV1),
+		CommonName:            in.CommonName,
+		OrganizationName:      in.OrganizationName,
+		OrganizationEmail:     in.OrganizationEmail,
+	}
+}
+
+func convertFulcioCertFromV1(in v1.FulcioCert) FulcioCert {
+	return FulcioCert{
+		PrivateKeyRef:         convertPointer(in.PrivateKeyRef, convertSecretKeySelectorFromV1),
+		PrivateKeyPasswordRef: convertPointer(in.PrivateKeyPasswordRef, convertSecretKeySelectorFromV1),
+		CARef:                 convertPointer(in.CARef, convertSecretKeySelectorFromV1),
+		CommonName:            in.CommonName,
+		OrganizationName:      in.OrganizationName,
+		OrganizationEmail:     in.OrganizationEmail,
+	}
+}
+
+func convertFulcioConfigToV1(in FulcioConfig) v1.FulcioConfig {
+	return v1.FulcioConfig{
+		OIDCIssuers: convertSlice(in.OIDCIssuers, convertOIDCIssuerToV1),
+		MetaIssuers: convertSlice(in.MetaIssuers, convertOIDCIssuerToV1),
+	}
+}
+
+func convertFulcioConfigFromV1(in v1.FulcioConfig) FulcioConfig {
+	return FulcioConfig{
+		OIDCIssuers: convertSlice(in.OIDCIssuers, convertOIDCIssuerFromV1),
+		MetaIssuers: convertSlice(in.MetaIssuers, convertOIDCIssuerFromV1),
+	}
+}
+
+func convertOIDCIssuerToV1(in OIDCIssuer) v1.OIDCIssuer {
+	return v1.OIDCIssuer(in)
+}
+
+func convertOIDCIssuerFromV1(in v1.OIDCIssuer) OIDCIssuer {
+	return OIDCIssuer(in)
+}
+
+func convertFulcioStatusToV1(in FulcioStatus) v1.FulcioStatus {
+	return v1.FulcioStatus{
+		ServerConfigRef:    convertPointer(in.ServerConfigRef, convertLocalObjectReferenceToV1),
+		Certificate:        convertPointer(in.Certificate, convertFulcioCertToV1),
+		Url:                in.Url,
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+func convertFulcioStatusFromV1(in v1.FulcioStatus) FulcioStatus {
+	return FulcioStatus{
+		ServerConfigRef:    convertPointer(in.ServerConfigRef, convertLocalObjectReferenceFromV1),
+		Certificate:        convertPointer(in.Certificate, convertFulcioCertFromV1),
+		Url:                in.Url,
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+// ConvertTo converts this Fulcio to the Hub version (v1).
+func (src *Fulcio) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Fulcio)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertFulcioSpecToV1(src.Spec)
+	dst.Status = convertFulcioStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *Fulcio) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Fulcio)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertFulcioSpecFromV1(src.Spec)
+	dst.Status = convertFulcioStatusFromV1(src.Status)
+	return nil
+}
@@ -102,6 +102,9 @@ type FulcioStatus struct {
 	ServerConfigRef *LocalObjectReference `json:"serverConfigRef,omitempty"`
 	Certificate     *FulcioCert           `json:"certificate,omitempty"`
 	Url             string                `json:"url,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
@@ -144,3 +147,11 @@ func (i *Fulcio) GetConditions() []metav1.Condition {
 func (i *Fulcio) SetCondition(newCondition metav1.Condition) {
 	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
 }
+
+func (i *Fulcio) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Fulcio) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
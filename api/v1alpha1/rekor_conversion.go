@@ -0,0 +1,122 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertRekorSpecToV1(in RekorSpec) v1.RekorSpec {
+	return v1.RekorSpec{
+		TreeID:         in.TreeID,
+		TreeRef:        convertPointer(in.TreeRef, convertLocalObjectReferenceToV1),
+		Trillian:       convertTrillianServiceToV1(in.Trillian),
+		ExternalAccess: convertExternalAccessToV1(in.ExternalAccess),
+		Monitoring:     convertMonitoringConfigToV1(in.Monitoring),
+		RekorSearchUI:  convertRekorSearchUIToV1(in.RekorSearchUI),
+		Signer:         convertRekorSignerToV1(in.Signer),
+		Pvc:            convertPvcToV1(in.Pvc),
+		BackFillRedis:  convertBackFillRedisToV1(in.BackFillRedis),
+		Sharding:       convertSlice(in.Sharding, convertRekorLogRangeToV1),
+	}
+}
+
+func convertRekorSpecFromV1(in v1.RekorSpec) RekorSpec {
+	return RekorSpec{
+		TreeID:         in.TreeID,
+		TreeRef:        convertPointer(in.TreeRef, convertLocalObjectReferenceFromV1),
+		Trillian:       convertTrillianServiceFromV1(in.Trillian),
+		ExternalAccess: convertExternalAccessFromV1(in.ExternalAccess),
+		Monitoring:     convertMonitoringConfigFromV1(in.Monitoring),
+		RekorSearchUI:  convertRekorSearchUIFromV1(in.RekorSearchUI),
+		Signer:         convertRekorSignerFromV1(in.Signer),
+		Pvc:            convertPvcFromV1(in.Pvc),
+		BackFillRedis:  convertBackFillRedisFromV1(in.BackFillRedis),
+		Sharding:       convertSlice(in.Sharding, convertRekorLogRangeFromV1),
+	}
+}
+
+func convertRekorSignerToV1(in RekorSigner) v1.RekorSigner {
+	return v1.RekorSigner{
+		KMS:         in.KMS,
+		PasswordRef: convertPointer(in.PasswordRef, convertSecretKeySelectorToV1),
+		KeyRef:      convertPointer(in.KeyRef, convertSecretKeySelectorToV1),
+	}
+}
+
+func convertRekorSignerFromV1(in v1.RekorSigner) RekorSigner {
+	return RekorSigner{
+		KMS:         in.KMS,
+		PasswordRef: convertPointer(in.PasswordRef, convertSecretKeySelectorFromV1),
+		KeyRef:      convertPointer(in.KeyRef, convertSecretKeySelectorFromV1),
+	}
+}
+
+func convertRekorSearchUIToV1(in RekorSearchUI) v1.RekorSearchUI {
+	return v1.RekorSearchUI(in)
+}
+
+func convertRekorSearchUIFromV1(in v1.RekorSearchUI) RekorSearchUI {
+	return RekorSearchUI(in)
+}
+
+func convertBackFillRedisToV1(in BackFillRedis) v1.BackFillRedis {
+	return v1.BackFillRedis(in)
+}
+
+func convertBackFillRedisFromV1(in v1.BackFillRedis) BackFillRedis {
+	return BackFillRedis(in)
+}
+
+func convertRekorLogRangeToV1(in RekorLogRange) v1.RekorLogRange {
+	return v1.RekorLogRange(in)
+}
+
+func convertRekorLogRangeFromV1(in v1.RekorLogRange) RekorLogRange {
+	return RekorLogRange(in)
+}
+
+func convertRekorStatusToV1(in RekorStatus) v1.RekorStatus {
+	return v1.RekorStatus{
+		PublicKeyRef:       convertPointer(in.PublicKeyRef, convertSecretKeySelectorToV1),
+		ServerConfigRef:    convertPointer(in.ServerConfigRef, convertLocalObjectReferenceToV1),
+		Signer:             convertRekorSignerToV1(in.Signer),
+		PvcName:            in.PvcName,
+		Url:                in.Url,
+		RekorSearchUIUrl:   in.RekorSearchUIUrl,
+		TreeID:             in.TreeID,
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+func convertRekorStatusFromV1(in v1.RekorStatus) RekorStatus {
+	return RekorStatus{
+		PublicKeyRef:       convertPointer(in.PublicKeyRef, convertSecretKeySelectorFromV1),
+		ServerConfigRef:    convertPointer(in.ServerConfigRef, convertLocalObjectReferenceFromV1),
+		Signer:             convertRekorSignerFromV1(in.Signer),
+		PvcName:            in.PvcName,
+		Url:                in.Url,
+		RekorSearchUIUrl:   in.RekorSearchUIUrl,
+		TreeID:             in.TreeID,
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+// ConvertTo converts this Rekor to the Hub version (v1).
+func (src *Rekor) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Rekor)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertRekorSpecToV1(src.Spec)
+	dst.Status = convertRekorStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *Rekor) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Rekor)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertRekorSpecFromV1(src.Spec)
+	dst.Status = convertRekorStatusFromV1(src.Status)
+	return nil
+}
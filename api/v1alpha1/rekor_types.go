@@ -9,11 +9,15 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // RekorSpec defines the desired state of Rekor
+// +kubebuilder:validation:XValidation:rule=(!has(self.treeID) || !has(self.treeRef)),message=treeID and treeRef are mutually exclusive
 type RekorSpec struct {
 	// ID of Merkle tree in Trillian backend
 	// If it is unset, the operator will create new Merkle tree in the Trillian backend
 	//+optional
 	TreeID *int64 `json:"treeID,omitempty"`
+	// Reference to a TrillianTree resource that provides the Merkle tree
+	//+optional
+	TreeRef *LocalObjectReference `json:"treeRef,omitempty"`
 	// Trillian service configuration
 	//+kubebuilder:default:={port: 8091}
 	Trillian TrillianService `json:"trillian,omitempty"`
@@ -103,6 +107,9 @@ type RekorStatus struct {
 	RekorSearchUIUrl string                `json:"rekorSearchUIUrl,omitempty"`
 	// The ID of a Trillian tree that stores the log data.
 	TreeID *int64 `json:"treeID,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
@@ -145,3 +152,11 @@ func (i *Rekor) GetConditions() []metav1.Condition {
 func (i *Rekor) SetCondition(newCondition metav1.Condition) {
 	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
 }
+
+func (i *Rekor) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Rekor) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
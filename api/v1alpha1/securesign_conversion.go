@@ -0,0 +1,172 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertSecuresignSpecToV1(in SecuresignSpec) v1.SecuresignSpec {
+	return v1.SecuresignSpec{
+		Rekor:      convertRekorSpecToV1(in.Rekor),
+		Fulcio:     convertFulcioSpecToV1(in.Fulcio),
+		Trillian:   convertTrillianSpecToV1(in.Trillian),
+		Tuf:        convertTufSpecToV1(in.Tuf),
+		Ctlog:      convertCTlogSpecToV1(in.Ctlog),
+		Components: convertSecuresignComponentsToV1(in.Components),
+	}
+}
+
+func convertSecuresignSpecFromV1(in v1.SecuresignSpec) SecuresignSpec {
+	return SecuresignSpec{
+		Rekor:      convertRekorSpecFromV1(in.Rekor),
+		Fulcio:     convertFulcioSpecFromV1(in.Fulcio),
+		Trillian:   convertTrillianSpecFromV1(in.Trillian),
+		Tuf:        convertTufSpecFromV1(in.Tuf),
+		Ctlog:      convertCTlogSpecFromV1(in.Ctlog),
+		Components: convertSecuresignComponentsFromV1(in.Components),
+	}
+}
+
+func convertSecuresignStatusToV1(in SecuresignStatus) v1.SecuresignStatus {
+	return v1.SecuresignStatus{
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+		RekorStatus:        convertSecuresignRekorStatusToV1(in.RekorStatus),
+		FulcioStatus:       convertSecuresignFulcioStatusToV1(in.FulcioStatus),
+		TufStatus:          convertSecuresignTufStatusToV1(in.TufStatus),
+		CTlogStatus:        convertSecuresignCTlogStatusToV1(in.CTlogStatus),
+		TrillianStatus:     convertSecuresignTrillianStatusToV1(in.TrillianStatus),
+	}
+}
+
+func convertSecuresignStatusFromV1(in v1.SecuresignStatus) SecuresignStatus {
+	return SecuresignStatus{
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+		RekorStatus:        convertSecuresignRekorStatusFromV1(in.RekorStatus),
+		FulcioStatus:       convertSecuresignFulcioStatusFromV1(in.FulcioStatus),
+		TufStatus:          convertSecuresignTufStatusFromV1(in.TufStatus),
+		CTlogStatus:        convertSecuresignCTlogStatusFromV1(in.CTlogStatus),
+		TrillianStatus:     convertSecuresignTrillianStatusFromV1(in.TrillianStatus),
+	}
+}
+
+func convertSecuresignComponentsToV1(in SecuresignComponents) v1.SecuresignComponents {
+	return v1.SecuresignComponents{
+		Trillian: convertSecuresignComponentToV1(in.Trillian),
+		Fulcio:   convertSecuresignComponentToV1(in.Fulcio),
+		Rekor:    convertSecuresignComponentToV1(in.Rekor),
+		Ctlog:    convertSecuresignComponentToV1(in.Ctlog),
+		Tuf:      convertSecuresignComponentToV1(in.Tuf),
+	}
+}
+
+func convertSecuresignComponentsFromV1(in v1.SecuresignComponents) SecuresignComponents {
+	return SecuresignComponents{
+		Trillian: convertSecuresignComponentFromV1(in.Trillian),
+		Fulcio:   convertSecuresignComponentFromV1(in.Fulcio),
+		Rekor:    convertSecuresignComponentFromV1(in.Rekor),
+		Ctlog:    convertSecuresignComponentFromV1(in.Ctlog),
+		Tuf:      convertSecuresignComponentFromV1(in.Tuf),
+	}
+}
+
+func convertSecuresignComponentToV1(in SecuresignComponent) v1.SecuresignComponent {
+	return v1.SecuresignComponent(in)
+}
+
+func convertSecuresignComponentFromV1(in v1.SecuresignComponent) SecuresignComponent {
+	return SecuresignComponent(in)
+}
+
+func convertSecuresignRekorStatusToV1(in SecuresignRekorStatus) v1.SecuresignRekorStatus {
+	return v1.SecuresignRekorStatus{
+		Url:              in.Url,
+		RekorSearchUIUrl: in.RekorSearchUIUrl,
+		TreeID:           in.TreeID,
+		Sharding:         convertSlice(in.Sharding, convertRekorLogRangeToV1),
+	}
+}
+
+func convertSecuresignRekorStatusFromV1(in v1.SecuresignRekorStatus) SecuresignRekorStatus {
+	return SecuresignRekorStatus{
+		Url:              in.Url,
+		RekorSearchUIUrl: in.RekorSearchUIUrl,
+		TreeID:           in.TreeID,
+		Sharding:         convertSlice(in.Sharding, convertRekorLogRangeFromV1),
+	}
+}
+
+func convertSecuresignFulcioStatusToV1(in SecuresignFulcioStatus) v1.SecuresignFulcioStatus {
+	return v1.SecuresignFulcioStatus{
+		Url:          in.Url,
+		CARef:        convertPointer(in.CARef, convertSecretKeySelectorToV1),
+		CAExpiration: in.CAExpiration,
+	}
+}
+
+func convertSecuresignFulcioStatusFromV1(in v1.SecuresignFulcioStatus) SecuresignFulcioStatus {
+	return SecuresignFulcioStatus{
+		Url:          in.Url,
+		CARef:        convertPointer(in.CARef, convertSecretKeySelectorFromV1),
+		CAExpiration: in.CAExpiration,
+	}
+}
+
+func convertSecuresignTufStatusToV1(in SecuresignTufStatus) v1.SecuresignTufStatus {
+	return v1.SecuresignTufStatus{
+		Url:  in.Url,
+		Keys: convertSlice(in.Keys, convertTufKeyToV1),
+	}
+}
+
+func convertSecuresignTufStatusFromV1(in v1.SecuresignTufStatus) SecuresignTufStatus {
+	return SecuresignTufStatus{
+		Url:  in.Url,
+		Keys: convertSlice(in.Keys, convertTufKeyFromV1),
+	}
+}
+
+func convertSecuresignCTlogStatusToV1(in SecuresignCTlogStatus) v1.SecuresignCTlogStatus {
+	return v1.SecuresignCTlogStatus{
+		TreeID:       in.TreeID,
+		PublicKeyRef: convertPointer(in.PublicKeyRef, convertSecretKeySelectorToV1),
+	}
+}
+
+func convertSecuresignCTlogStatusFromV1(in v1.SecuresignCTlogStatus) SecuresignCTlogStatus {
+	return SecuresignCTlogStatus{
+		TreeID:       in.TreeID,
+		PublicKeyRef: convertPointer(in.PublicKeyRef, convertSecretKeySelectorFromV1),
+	}
+}
+
+func convertSecuresignTrillianStatusToV1(in SecuresignTrillianStatus) v1.SecuresignTrillianStatus {
+	return v1.SecuresignTrillianStatus{
+		DatabaseSecretRef: convertPointer(in.DatabaseSecretRef, convertLocalObjectReferenceToV1),
+	}
+}
+
+func convertSecuresignTrillianStatusFromV1(in v1.SecuresignTrillianStatus) SecuresignTrillianStatus {
+	return SecuresignTrillianStatus{
+		DatabaseSecretRef: convertPointer(in.DatabaseSecretRef, convertLocalObjectReferenceFromV1),
+	}
+}
+
+// ConvertTo converts this Securesign to the Hub version (v1).
+func (src *Securesign) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Securesign)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertSecuresignSpecToV1(src.Spec)
+	dst.Status = convertSecuresignStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *Securesign) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Securesign)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertSecuresignSpecFromV1(src.Spec)
+	dst.Status = convertSecuresignStatusFromV1(src.Status)
+	return nil
+}
@@ -0,0 +1,236 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertTrillianSpecToV1(in TrillianSpec) v1.TrillianSpec {
+	return v1.TrillianSpec{
+		Db:         convertTrillianDBToV1(in.Db),
+		Monitoring: convertMonitoringConfigToV1(in.Monitoring),
+		TLS:        convertTLSToV1(in.TLS),
+		LogServer:  convertTrillianLogServerToV1(in.LogServer),
+		LogSigner:  convertTrillianLogSignerToV1(in.LogSigner),
+		Quota:      convertTrillianQuotaToV1(in.Quota),
+	}
+}
+
+func convertTrillianSpecFromV1(in v1.TrillianSpec) TrillianSpec {
+	return TrillianSpec{
+		Db:         convertTrillianDBFromV1(in.Db),
+		Monitoring: convertMonitoringConfigFromV1(in.Monitoring),
+		TLS:        convertTLSFromV1(in.TLS),
+		LogServer:  convertTrillianLogServerFromV1(in.LogServer),
+		LogSigner:  convertTrillianLogSignerFromV1(in.LogSigner),
+		Quota:      convertTrillianQuotaFromV1(in.Quota),
+	}
+}
+
+func convertTrillianLogServerToV1(in TrillianLogServer) v1.TrillianLogServer {
+	return v1.TrillianLogServer(in)
+}
+
+func convertTrillianLogServerFromV1(in v1.TrillianLogServer) TrillianLogServer {
+	return TrillianLogServer(in)
+}
+
+func convertTrillianLogSignerToV1(in TrillianLogSigner) v1.TrillianLogSigner {
+	return v1.TrillianLogSigner{
+		Replicas:           in.Replicas,
+		Election:           convertTrillianElectionToV1(in.Election),
+		BatchSize:          in.BatchSize,
+		SequencerInterval:  in.SequencerInterval,
+		NumSequencers:      in.NumSequencers,
+		MasterHoldInterval: in.MasterHoldInterval,
+	}
+}
+
+func convertTrillianLogSignerFromV1(in v1.TrillianLogSigner) TrillianLogSigner {
+	return TrillianLogSigner{
+		Replicas:           in.Replicas,
+		Election:           convertTrillianElectionFromV1(in.Election),
+		BatchSize:          in.BatchSize,
+		SequencerInterval:  in.SequencerInterval,
+		NumSequencers:      in.NumSequencers,
+		MasterHoldInterval: in.MasterHoldInterval,
+	}
+}
+
+func convertTrillianElectionToV1(in TrillianElection) v1.TrillianElection {
+	return v1.TrillianElection(in)
+}
+
+func convertTrillianElectionFromV1(in v1.TrillianElection) TrillianElection {
+	return TrillianElection(in)
+}
+
+func convertTrillianQuotaToV1(in TrillianQuota) v1.TrillianQuota {
+	return v1.TrillianQuota{
+		System:             v1.TrillianQuotaSystem(in.System),
+		MaxUnsequencedRows: in.MaxUnsequencedRows,
+		TreeWrite:          convertPointer(in.TreeWrite, convertTrillianTreeQuotaToV1),
+		DryRun:             in.DryRun,
+	}
+}
+
+func convertTrillianQuotaFromV1(in v1.TrillianQuota) TrillianQuota {
+	return TrillianQuota{
+		System:             TrillianQuotaSystem(in.System),
+		MaxUnsequencedRows: in.MaxUnsequencedRows,
+		TreeWrite:          convertPointer(in.TreeWrite, convertTrillianTreeQuotaFromV1),
+		DryRun:             in.DryRun,
+	}
+}
+
+func convertTrillianTreeQuotaToV1(in TrillianTreeQuota) v1.TrillianTreeQuota {
+	return v1.TrillianTreeQuota(in)
+}
+
+func convertTrillianTreeQuotaFromV1(in v1.TrillianTreeQuota) TrillianTreeQuota {
+	return TrillianTreeQuota(in)
+}
+
+func convertTrillianSignerMasterToV1(in TrillianSignerMaster) v1.TrillianSignerMaster {
+	return v1.TrillianSignerMaster(in)
+}
+
+func convertTrillianSignerMasterFromV1(in v1.TrillianSignerMaster) TrillianSignerMaster {
+	return TrillianSignerMaster(in)
+}
+
+func convertTrillianDBToV1(in TrillianDB) v1.TrillianDB {
+	return v1.TrillianDB{
+		Create:            in.Create,
+		Engine:            v1.DatabaseEngine(in.Engine),
+		DatabaseSecretRef: convertPointer(in.DatabaseSecretRef, convertLocalObjectReferenceToV1),
+		Pvc:               convertPvcToV1(in.Pvc),
+		Backup:            convertPointer(in.Backup, convertTrillianDBBackupToV1),
+	}
+}
+
+func convertTrillianDBFromV1(in v1.TrillianDB) TrillianDB {
+	return TrillianDB{
+		Create:            in.Create,
+		Engine:            DatabaseEngine(in.Engine),
+		DatabaseSecretRef: convertPointer(in.DatabaseSecretRef, convertLocalObjectReferenceFromV1),
+		Pvc:               convertPvcFromV1(in.Pvc),
+		Backup:            convertPointer(in.Backup, convertTrillianDBBackupFromV1),
+	}
+}
+
+func convertTrillianDBBackupToV1(in TrillianDBBackup) v1.TrillianDBBackup {
+	return v1.TrillianDBBackup{
+		Schedule:  in.Schedule,
+		Retention: in.Retention,
+		Pvc:       convertPointer(in.Pvc, convertPvcToV1),
+		S3:        convertPointer(in.S3, convertTrillianDBBackupS3ToV1),
+	}
+}
+
+func convertTrillianDBBackupFromV1(in v1.TrillianDBBackup) TrillianDBBackup {
+	return TrillianDBBackup{
+		Schedule:  in.Schedule,
+		Retention: in.Retention,
+		Pvc:       convertPointer(in.Pvc, convertPvcFromV1),
+		S3:        convertPointer(in.S3, convertTrillianDBBackupS3FromV1),
+	}
+}
+
+func convertTrillianDBBackupS3ToV1(in TrillianDBBackupS3) v1.TrillianDBBackupS3 {
+	return v1.TrillianDBBackupS3{
+		Endpoint:             in.Endpoint,
+		Bucket:               in.Bucket,
+		Prefix:               in.Prefix,
+		Region:               in.Region,
+		CredentialsSecretRef: convertLocalObjectReferenceToV1(in.CredentialsSecretRef),
+	}
+}
+
+func convertTrillianDBBackupS3FromV1(in v1.TrillianDBBackupS3) TrillianDBBackupS3 {
+	return TrillianDBBackupS3{
+		Endpoint:             in.Endpoint,
+		Bucket:               in.Bucket,
+		Prefix:               in.Prefix,
+		Region:               in.Region,
+		CredentialsSecretRef: convertLocalObjectReferenceFromV1(in.CredentialsSecretRef),
+	}
+}
+
+func convertTrillianDBBackupStatusToV1(in TrillianDBBackupStatus) v1.TrillianDBBackupStatus {
+	return v1.TrillianDBBackupStatus(in)
+}
+
+func convertTrillianDBBackupStatusFromV1(in v1.TrillianDBBackupStatus) TrillianDBBackupStatus {
+	return TrillianDBBackupStatus(in)
+}
+
+func convertTrillianDBUpgradeToV1(in TrillianDBUpgrade) v1.TrillianDBUpgrade {
+	return v1.TrillianDBUpgrade{
+		FromImage:          in.FromImage,
+		Image:              in.Image,
+		SchemaVersion:      in.SchemaVersion,
+		Phase:              v1.TrillianDBUpgradePhase(in.Phase),
+		Backup:             in.Backup,
+		Message:            in.Message,
+		LastTransitionTime: in.LastTransitionTime,
+	}
+}
+
+func convertTrillianDBUpgradeFromV1(in v1.TrillianDBUpgrade) TrillianDBUpgrade {
+	return TrillianDBUpgrade{
+		FromImage:          in.FromImage,
+		Image:              in.Image,
+		SchemaVersion:      in.SchemaVersion,
+		Phase:              TrillianDBUpgradePhase(in.Phase),
+		Backup:             in.Backup,
+		Message:            in.Message,
+		LastTransitionTime: in.LastTransitionTime,
+	}
+}
+
+func convertTrillianStatusToV1(in TrillianStatus) v1.TrillianStatus {
+	return v1.TrillianStatus{
+		Db:                 convertTrillianDBToV1(in.Db),
+		TLS:                convertTLSToV1(in.TLS),
+		ElectedSigners:     convertSlice(in.ElectedSigners, convertTrillianSignerMasterToV1),
+		DatabaseImage:      in.DatabaseImage,
+		SchemaVersion:      in.SchemaVersion,
+		DatabaseUpgrade:    convertPointer(in.DatabaseUpgrade, convertTrillianDBUpgradeToV1),
+		Backup:             convertPointer(in.Backup, convertTrillianDBBackupStatusToV1),
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+func convertTrillianStatusFromV1(in v1.TrillianStatus) TrillianStatus {
+	return TrillianStatus{
+		Db:                 convertTrillianDBFromV1(in.Db),
+		TLS:                convertTLSFromV1(in.TLS),
+		ElectedSigners:     convertSlice(in.ElectedSigners, convertTrillianSignerMasterFromV1),
+		DatabaseImage:      in.DatabaseImage,
+		SchemaVersion:      in.SchemaVersion,
+		DatabaseUpgrade:    convertPointer(in.DatabaseUpgrade, convertTrillianDBUpgradeFromV1),
+		Backup:             convertPointer(in.Backup, convertTrillianDBBackupStatusFromV1),
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+// ConvertTo converts this Trillian to the Hub version (v1).
+func (src *Trillian) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Trillian)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTrillianSpecToV1(src.Spec)
+	dst.Status = convertTrillianStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *Trillian) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Trillian)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTrillianSpecFromV1(src.Spec)
+	dst.Status = convertTrillianStatusFromV1(src.Status)
+	return nil
+}
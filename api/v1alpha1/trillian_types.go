@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/api/meta"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,33 +26,275 @@ import (
 type TrillianSpec struct {
 	// Define your database connection
 	//+kubebuilder:validation:XValidation:rule=((!self.create && self.databaseSecretRef != null) || self.create),message=databaseSecretRef cannot be empty
+	//+kubebuilder:validation:XValidation:rule=(!has(self.backup) || self.create),message=backup is supported only for the database created by the operator
 	//+kubebuilder:default:={create: true, pvc: {size: "5Gi", retain: true}}
 	Db TrillianDB `json:"database,omitempty"`
 	// Enable Monitoring for Logsigner and Logserver
 	Monitoring MonitoringConfig `json:"monitoring,omitempty"`
+	// Serve gRPC of Logsigner and Logserver over TLS
+	//+optional
+	TLS TLS `json:"tls,omitempty"`
+	// Define Logserver deployment
+	//+optional
+	LogServer TrillianLogServer `json:"logServer,omitempty"`
+	// Define Logsigner deployment
+	//+optional
+	LogSigner TrillianLogSigner `json:"logSigner,omitempty"`
+	// Define write quotas enforced by Logserver and Logsigner
+	//+kubebuilder:validation:XValidation:rule=(!has(self.maxUnsequencedRows) || self.system == 'database'),message=maxUnsequencedRows requires the database quota system
+	//+kubebuilder:validation:XValidation:rule=(!has(self.treeWrite) || self.system == 'etcd'),message=treeWrite requires the etcd quota system
+	//+optional
+	Quota TrillianQuota `json:"quota,omitempty"`
+}
+
+type TrillianLogServer struct {
+	// Number of Logserver replicas. A PodDisruptionBudget keeps one replica available when more than one is requested.
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+type TrillianLogSigner struct {
+	// Number of Logsigner replicas. Master election is enabled when more than one replica is requested.
+	//+kubebuilder:default:=1
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Master election of Logsigner replicas
+	//+optional
+	Election TrillianElection `json:"election,omitempty"`
+	// Max number of leaves to process per batch
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// Time between each sequencing pass through all trees
+	//+kubebuilder:validation:XValidation:rule=(duration(self) > duration('0s')),message=sequencerInterval must be positive
+	//+optional
+	SequencerInterval *metav1.Duration `json:"sequencerInterval,omitempty"`
+	// Number of sequencer workers to run in parallel
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	NumSequencers *int32 `json:"numSequencers,omitempty"`
+	// Minimum interval the elected master holds the mastership of a tree. Only effective with master election.
+	//+kubebuilder:validation:XValidation:rule=(duration(self) > duration('0s')),message=masterHoldInterval must be positive
+	//+optional
+	MasterHoldInterval *metav1.Duration `json:"masterHoldInterval,omitempty"`
+}
+
+type TrillianElection struct {
+	// Endpoints of an existing etcd cluster used for the master election and the etcd quota system, for example http://etcd.example.svc:2379.
+	// The operator deploys etcd when no endpoint is set and election or the etcd quota system is enabled.
+	// Setting endpoints enables the election for a single replica as well.
+	//+optional
+	EtcdServers []string `json:"etcdServers,omitempty"`
 }
 
+// TrillianQuotaSystem selects how Trillian limits writes
+// +kubebuilder:validation:Enum=database;etcd;noop
+type TrillianQuotaSystem string
+
+const (
+	// TrillianQuotaDatabase limits the number of unsequenced leaves stored in the database
+	TrillianQuotaDatabase TrillianQuotaSystem = "database"
+	// TrillianQuotaEtcd keeps token buckets in etcd and supports per-tree quotas
+	TrillianQuotaEtcd TrillianQuotaSystem = "etcd"
+	// TrillianQuotaNoop does not limit writes
+	TrillianQuotaNoop TrillianQuotaSystem = "noop"
+)
+
+type TrillianQuota struct {
+	// Quota system used by Logserver and Logsigner
+	//+kubebuilder:default:=database
+	//+optional
+	System TrillianQuotaSystem `json:"system,omitempty"`
+	// Max number of unsequenced leaves in the database before writes are rate limited. Only effective for the database quota system.
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	MaxUnsequencedRows *int32 `json:"maxUnsequencedRows,omitempty"`
+	// Write quota applied to every tree. Only effective for the etcd quota system.
+	//+optional
+	TreeWrite *TrillianTreeQuota `json:"treeWrite,omitempty"`
+	// Log requests exceeding the quota instead of rejecting them
+	//+optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// TrillianTreeQuota is a token bucket limiting writes of leaves to a tree.
+// Tokens are replenished as leaves get sequenced unless a time based replenishment is set.
+// +kubebuilder:validation:XValidation:rule=(has(self.tokensToReplenish) == has(self.replenishInterval)),message=tokensToReplenish and replenishInterval must be set together
+type TrillianTreeQuota struct {
+	// Max number of tokens of the bucket, each leaf written consumes one token
+	//+kubebuilder:validation:Minimum:=1
+	MaxTokens int64 `json:"maxTokens"`
+	// Number of tokens replenished every replenishInterval
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	TokensToReplenish *int64 `json:"tokensToReplenish,omitempty"`
+	// Interval tokensToReplenish get replenished at
+	//+kubebuilder:validation:XValidation:rule=(duration(self) >= duration('1s')),message=replenishInterval must be at least one second
+	//+optional
+	ReplenishInterval *metav1.Duration `json:"replenishInterval,omitempty"`
+}
+
+// TrillianSignerMaster is the Logsigner pod elected as master for a tree
+type TrillianSignerMaster struct {
+	TreeID int64  `json:"treeID"`
+	Pod    string `json:"pod"`
+}
+
+// DatabaseEngine selects the storage backend of Trillian
+// +kubebuilder:validation:Enum=mysql;postgresql
+type DatabaseEngine string
+
+const (
+	DatabaseEngineMySQL      DatabaseEngine = "mysql"
+	DatabaseEnginePostgreSQL DatabaseEngine = "postgresql"
+)
+
 type TrillianDB struct {
 	// Create Database if a database is not created one must be defined using the DatabaseSecret field
 	//+kubebuilder:default:=true
 	//+kubebuilder:validation:XValidation:rule=(self == oldSelf),message=Field is immutable
 	Create *bool `json:"create"`
-	// Secret with values to be used to connect to an existing DB or to be used with the creation of a new DB
-	// mysql-host: The host of the MySQL server
-	// mysql-port: The port of the MySQL server
-	// mysql-user: The user to connect to the MySQL server
-	// mysql-password: The password to connect to the MySQL server
-	// mysql-database: The database to connect to
+	// Database engine used as Trillian storage
+	//+kubebuilder:default:=mysql
+	//+kubebuilder:validation:XValidation:rule=(self == oldSelf),message=Field is immutable
+	//+optional
+	Engine DatabaseEngine `json:"engine,omitempty"`
+	// Secret with values to be used to connect to an existing DB or to be used with the creation of a new DB.
+	// Keys are prefixed with the engine name, mysql- or postgresql-:
+	// <engine>-host: The host of the database server
+	// <engine>-port: The port of the database server
+	// <engine>-user: The user to connect to the database server
+	// <engine>-password: The password to connect to the database server
+	// <engine>-database: The database to connect to
+	// Optional entries securing the connection with TLS:
+	// <engine>-tls-mode: One of disabled, preferred, skip-verify or verify-full. Defaults to verify-full when <engine>-tls-ca is set, otherwise disabled
+	// <engine>-tls-ca: The PEM encoded CA bundle used to verify the database server certificate
+	// <engine>-tls-server-cert: The PEM encoded certificate served by the managed database server
+	// <engine>-tls-server-key: The PEM encoded private key of <engine>-tls-server-cert
+	// Client certificate authentication is not supported, the secret must not contain <engine>-tls-cert or <engine>-tls-key
 	//+optional
 	DatabaseSecretRef *LocalObjectReference `json:"databaseSecretRef,omitempty"`
 	// PVC configuration
 	//+kubebuilder:default:={size: "5Gi", retain: true}
 	Pvc Pvc `json:"pvc,omitempty"`
+	// Scheduled logical backups of the database created by the operator
+	//+optional
+	Backup *TrillianDBBackup `json:"backup,omitempty"`
+}
+
+// TrillianDBBackup schedules consistent dumps of the managed database to a PVC or an S3-compatible bucket.
+// +kubebuilder:validation:XValidation:rule=(has(self.pvc) != has(self.s3)),message=exactly one of pvc or s3 must be set
+type TrillianDBBackup struct {
+	// Schedule of the backup CronJob
+	//+kubebuilder:default:="0 0 * * *"
+	//+kubebuilder:validation:Pattern:="^(@(?i)(yearly|annually|monthly|weekly|daily|hourly)|((\\*(\\/[1-9][0-9]*)?|[0-9,-]+)+\\s){4}(\\*(\\/[1-9][0-9]*)?|[0-9,-]+)+)$"
+	Schedule string `json:"schedule,omitempty"`
+	// Number of backups kept, older backups are removed
+	//+kubebuilder:default:=7
+	//+kubebuilder:validation:Minimum:=1
+	Retention int32 `json:"retention,omitempty"`
+	// PVC the backups are stored to. The PVC is created when it does not exist.
+	//+optional
+	Pvc *Pvc `json:"pvc,omitempty"`
+	// S3-compatible bucket the backups are uploaded to
+	//+optional
+	S3 *TrillianDBBackupS3 `json:"s3,omitempty"`
+}
+
+type TrillianDBBackupS3 struct {
+	// Endpoint of the S3-compatible service, for example https://s3.us-east-1.amazonaws.com
+	//+kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// Bucket the backups are uploaded to
+	//+kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+	// Prefix of the backup objects in the bucket
+	//+optional
+	Prefix string `json:"prefix,omitempty"`
+	// Region of the bucket
+	//+kubebuilder:default:=us-east-1
+	//+optional
+	Region string `json:"region,omitempty"`
+	// Secret with the credentials of the bucket:
+	// access-key-id: The access key ID
+	// secret-access-key: The secret access key
+	CredentialsSecretRef LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// TrillianDBBackupStatus is the result of the last successful backup
+type TrillianDBBackupStatus struct {
+	// Time the last successful backup finished at
+	//+optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	// Name of the last successful backup
+	//+optional
+	LastBackup string `json:"lastBackup,omitempty"`
+	// Size of the last successful backup
+	//+optional
+	LastSize *k8sresource.Quantity `json:"lastSize,omitempty"`
+}
+
+// TrillianDBUpgradePhase is the step of the managed database upgrade
+// +kubebuilder:validation:Enum=Backup;Migrate;Rollout;Completed;Failed
+type TrillianDBUpgradePhase string
+
+const (
+	// TrillianDBUpgradeBackup takes the pre-upgrade dump of the database
+	TrillianDBUpgradeBackup TrillianDBUpgradePhase = "Backup"
+	// TrillianDBUpgradeMigrate applies the schema migrations
+	TrillianDBUpgradeMigrate TrillianDBUpgradePhase = "Migrate"
+	// TrillianDBUpgradeRollout rolls out the new database image
+	TrillianDBUpgradeRollout TrillianDBUpgradePhase = "Rollout"
+	// TrillianDBUpgradeCompleted is set once the new database image is running
+	TrillianDBUpgradeCompleted TrillianDBUpgradePhase = "Completed"
+	// TrillianDBUpgradeFailed is set when a step failed, the upgrade is retried later
+	TrillianDBUpgradeFailed TrillianDBUpgradePhase = "Failed"
+)
+
+// TrillianDBUpgrade is the progress of the managed database upgrade
+type TrillianDBUpgrade struct {
+	// Image of the database before the upgrade
+	//+optional
+	FromImage string `json:"fromImage,omitempty"`
+	// Image the database is upgraded to
+	Image string `json:"image"`
+	// Schema version the database is migrated to
+	SchemaVersion int32 `json:"schemaVersion"`
+	Phase         TrillianDBUpgradePhase `json:"phase"`
+	// Name of the pre-upgrade backup
+	//+optional
+	Backup string `json:"backup,omitempty"`
+	//+optional
+	Message            string      `json:"message,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 }
 
 // TrillianStatus defines the observed state of Trillian
 type TrillianStatus struct {
-	Db TrillianDB `json:"database,omitempty"`
+	Db  TrillianDB `json:"database,omitempty"`
+	TLS TLS        `json:"tls,omitempty"`
+	// Logsigner pods currently elected as master
+	//+listType=atomic
+	//+optional
+	ElectedSigners []TrillianSignerMaster `json:"electedSigners,omitempty"`
+	// Image of the managed database
+	//+optional
+	DatabaseImage string `json:"databaseImage,omitempty"`
+	// Schema version of the managed database
+	//+optional
+	SchemaVersion int32 `json:"schemaVersion,omitempty"`
+	// Progress of the last managed database upgrade
+	//+optional
+	DatabaseUpgrade *TrillianDBUpgrade `json:"databaseUpgrade,omitempty"`
+	// Result of the scheduled database backups
+	//+optional
+	Backup *TrillianDBBackupStatus `json:"backup,omitempty"`
+	// The generation of the spec the resource was completely reconciled at
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
@@ -93,3 +336,11 @@ func (i *Trillian) GetConditions() []metav1.Condition {
 func (i *Trillian) SetCondition(newCondition metav1.Condition) {
 	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
 }
+
+func (i *Trillian) GetObservedGeneration() int64 {
+	return i.Status.ObservedGeneration
+}
+
+func (i *Trillian) SetObservedGeneration(generation int64) {
+	i.Status.ObservedGeneration = generation
+}
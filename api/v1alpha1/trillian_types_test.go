@@ -65,6 +65,19 @@ var _ = Describe("Trillian", func() {
 					To(MatchError(ContainSubstring("Field is immutable")))
 			})
 
+			It("immutable database engine", func() {
+				validObject := generateTrillianObject("immutable-engine")
+				Expect(k8sClient.Create(context.Background(), validObject)).To(Succeed())
+
+				invalidObject := &Trillian{}
+				Expect(k8sClient.Get(context.Background(), getKey(validObject), invalidObject)).To(Succeed())
+				invalidObject.Spec.Db.Engine = DatabaseEnginePostgreSQL
+
+				Expect(apierrors.IsInvalid(k8sClient.Update(context.Background(), invalidObject))).To(BeTrue())
+				Expect(k8sClient.Update(context.Background(), invalidObject)).
+					To(MatchError(ContainSubstring("Field is immutable")))
+			})
+
 			It("immutable pvc retain", func() {
 				validObject := generateTrillianObject("immutable-retain")
 				Expect(k8sClient.Create(context.Background(), validObject)).To(Succeed())
@@ -100,6 +113,26 @@ var _ = Describe("Trillian", func() {
 				})
 			})
 
+			When("database backup", func() {
+				It("requires database created by the operator", func() {
+					invalidObject := generateTrillianObject("backup-create")
+					invalidObject.Spec.Db.Create = ptr.To(false)
+					invalidObject.Spec.Db.DatabaseSecretRef = &LocalObjectReference{Name: "secret"}
+					invalidObject.Spec.Db.Backup = &TrillianDBBackup{Pvc: &Pvc{Retain: ptr.To(true)}}
+					Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+					Expect(k8sClient.Create(context.Background(), invalidObject)).
+						To(MatchError(ContainSubstring("backup is supported only for the database created by the operator")))
+				})
+
+				It("requires exactly one target", func() {
+					invalidObject := generateTrillianObject("backup-target")
+					invalidObject.Spec.Db.Backup = &TrillianDBBackup{}
+					Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+					Expect(k8sClient.Create(context.Background(), invalidObject)).
+						To(MatchError(ContainSubstring("exactly one of pvc or s3 must be set")))
+				})
+			})
+
 			It("checking pvc name", func() {
 				invalidObject := generateTrillianObject("trillian3")
 				invalidObject.Spec.Db.Pvc.Name = "-invalid-name!"
@@ -147,6 +180,7 @@ var _ = Describe("Trillian", func() {
 						Spec: TrillianSpec{
 							Db: TrillianDB{
 								Create: ptr.To(true),
+								Engine: DatabaseEngineMySQL,
 								Pvc: Pvc{
 									Retain:       ptr.To(true),
 									Name:         "storage",
@@ -209,6 +243,7 @@ func generateTrillianObject(name string) *Trillian {
 		Spec: TrillianSpec{
 			Db: TrillianDB{
 				Create: ptr.To(true),
+				Engine: DatabaseEngineMySQL,
 				Pvc: Pvc{
 					Retain: ptr.To(true),
 					Size:   &storage,
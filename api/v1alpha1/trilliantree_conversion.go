@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertTrillianTreeSpecToV1(in TrillianTreeSpec) v1.TrillianTreeSpec {
+	return v1.TrillianTreeSpec{
+		Trillian:        convertTrillianServiceToV1(in.Trillian),
+		TreeID:          in.TreeID,
+		DisplayName:     in.DisplayName,
+		TreeType:        v1.TreeType(in.TreeType),
+		State:           v1.TreeState(in.State),
+		MaxRootDuration: in.MaxRootDuration,
+		DeletionPolicy:  v1.TrillianTreeDeletionPolicy(in.DeletionPolicy),
+	}
+}
+
+func convertTrillianTreeSpecFromV1(in v1.TrillianTreeSpec) TrillianTreeSpec {
+	return TrillianTreeSpec{
+		Trillian:        convertTrillianServiceFromV1(in.Trillian),
+		TreeID:          in.TreeID,
+		DisplayName:     in.DisplayName,
+		TreeType:        TreeType(in.TreeType),
+		State:           TreeState(in.State),
+		MaxRootDuration: in.MaxRootDuration,
+		DeletionPolicy:  TrillianTreeDeletionPolicy(in.DeletionPolicy),
+	}
+}
+
+func convertTrillianTreeStatusToV1(in TrillianTreeStatus) v1.TrillianTreeStatus {
+	return v1.TrillianTreeStatus{
+		TreeID:          in.TreeID,
+		DisplayName:     in.DisplayName,
+		TreeType:        v1.TreeType(in.TreeType),
+		State:           v1.TreeState(in.State),
+		MaxRootDuration: in.MaxRootDuration,
+		Size:            in.Size,
+		Conditions:      in.Conditions,
+	}
+}
+
+func convertTrillianTreeStatusFromV1(in v1.TrillianTreeStatus) TrillianTreeStatus {
+	return TrillianTreeStatus{
+		TreeID:          in.TreeID,
+		DisplayName:     in.DisplayName,
+		TreeType:        TreeType(in.TreeType),
+		State:           TreeState(in.State),
+		MaxRootDuration: in.MaxRootDuration,
+		Size:            in.Size,
+		Conditions:      in.Conditions,
+	}
+}
+
+// ConvertTo converts this TrillianTree to the Hub version (v1).
+func (src *TrillianTree) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.TrillianTree)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTrillianTreeSpecToV1(src.Spec)
+	dst.Status = convertTrillianTreeStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *TrillianTree) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.TrillianTree)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTrillianTreeSpecFromV1(src.Spec)
+	dst.Status = convertTrillianTreeStatusFromV1(src.Status)
+	return nil
+}
@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TreeState is the state of a Trillian tree
+// +kubebuilder:validation:Enum=Active;Frozen;Draining
+type TreeState string
+
+const (
+	// TreeStateActive tree accepts new entries
+	TreeStateActive TreeState = "Active"
+	// TreeStateFrozen tree is read-only, no new entries are accepted or integrated
+	TreeStateFrozen TreeState = "Frozen"
+	// TreeStateDraining tree does not accept new entries but keeps integrating the queued ones
+	TreeStateDraining TreeState = "Draining"
+)
+
+// TreeType is the type of a Trillian tree
+// +kubebuilder:validation:Enum=Log;PreorderedLog
+type TreeType string
+
+const (
+	TreeTypeLog           TreeType = "Log"
+	TreeTypePreorderedLog TreeType = "PreorderedLog"
+)
+
+// TrillianTreeDeletionPolicy defines what happens with the Trillian tree when the resource is deleted
+// +kubebuilder:validation:Enum=Retain;Delete
+type TrillianTreeDeletionPolicy string
+
+const (
+	// TrillianTreeRetain keeps the tree in the Trillian backend
+	TrillianTreeRetain TrillianTreeDeletionPolicy = "Retain"
+	// TrillianTreeDelete soft-deletes the tree in the Trillian backend
+	TrillianTreeDelete TrillianTreeDeletionPolicy = "Delete"
+)
+
+// TrillianTreeSpec defines the desired state of TrillianTree
+type TrillianTreeSpec struct {
+	// Trillian service configuration
+	//+kubebuilder:default:={port: 8091}
+	Trillian TrillianService `json:"trillian,omitempty"`
+
+	// The ID of an existing Trillian tree to adopt.
+	// If it is unset, the operator will create new Merkle tree in the Trillian backend
+	//+kubebuilder:validation:XValidation:rule=(self == oldSelf),message=Field is immutable
+	//+optional
+	TreeID *int64 `json:"treeID,omitempty"`
+
+	// Display name of the tree. Defaults to the name of the resource.
+	//+kubebuilder:validation:MaxLength:=20
+	//+optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Type of the tree
+	//+kubebuilder:default:=Log
+	//+kubebuilder:validation:XValidation:rule=(self == oldSelf),message=Field is immutable
+	TreeType TreeType `json:"treeType,omitempty"`
+
+	// State of the tree
+	//+kubebuilder:default:=Active
+	State TreeState `json:"state,omitempty"`
+
+	// Interval after which a new signed root is produced even if there have been
+	// no submissions. Zero disables the periodic signing.
+	//+kubebuilder:default:="1h"
+	MaxRootDuration *metav1.Duration `json:"maxRootDuration,omitempty"`
+
+	// What happens with the tree in the Trillian backend when the resource is deleted. The tree is kept when the
+	// in-cluster Trillian serving it is removed, or when the rhtas.redhat.com/skip-tree-deletion annotation is "true".
+	//+kubebuilder:default:=Retain
+	DeletionPolicy TrillianTreeDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// TrillianTreeStatus defines the observed state of TrillianTree
+type TrillianTreeStatus struct {
+	// The ID of the managed Trillian tree
+	TreeID *int64 `json:"treeID,omitempty"`
+	// Display name of the tree
+	DisplayName string `json:"displayName,omitempty"`
+	// Type of the tree
+	TreeType TreeType `json:"treeType,omitempty"`
+	// State of the tree
+	State TreeState `json:"state,omitempty"`
+	// Interval after which a new signed root is produced
+	MaxRootDuration *metav1.Duration `json:"maxRootDuration,omitempty"`
+	// Number of entries integrated into the tree
+	Size *uint64 `json:"size,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="The component status"
+//+kubebuilder:printcolumn:name="Tree ID",type=integer,JSONPath=`.status.treeID`,description="The Trillian tree ID"
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`,description="The Trillian tree state"
+//+kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.status.size`,description="The number of entries in the tree"
+
+// TrillianTree is the Schema for the trilliantrees API
+type TrillianTree struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TrillianTreeSpec   `json:"spec,omitempty"`
+	Status TrillianTreeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TrillianTreeList contains a list of TrillianTree
+type TrillianTreeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TrillianTree `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TrillianTree{}, &TrillianTreeList{})
+}
+
+func (i *TrillianTree) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *TrillianTree) SetCondition(newCondition metav1.Condition) {
+	meta.SetStatusCondition(&i.Status.Conditions, newCondition)
+}
@@ -0,0 +1,106 @@
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("TrillianTree", func() {
+
+	Context("TrillianTreeSpec", func() {
+		It("can be created", func() {
+			created := generateTrillianTreeObject("tree-create")
+			Expect(k8sClient.Create(context.Background(), created)).To(Succeed())
+
+			fetched := &TrillianTree{}
+			Expect(k8sClient.Get(context.Background(), getKey(created), fetched)).To(Succeed())
+			Expect(fetched).To(Equal(created))
+		})
+
+		It("can be updated", func() {
+			created := generateTrillianTreeObject("tree-update")
+			Expect(k8sClient.Create(context.Background(), created)).To(Succeed())
+
+			fetched := &TrillianTree{}
+			Expect(k8sClient.Get(context.Background(), getKey(created), fetched)).To(Succeed())
+			fetched.Spec.State = TreeStateFrozen
+			fetched.Spec.DisplayName = "frozen-tree"
+			Expect(k8sClient.Update(context.Background(), fetched)).To(Succeed())
+		})
+
+		Context("is validated", func() {
+			It("immutable tree type", func() {
+				validObject := generateTrillianTreeObject("immutable-type")
+				Expect(k8sClient.Create(context.Background(), validObject)).To(Succeed())
+
+				invalidObject := &TrillianTree{}
+				Expect(k8sClient.Get(context.Background(), getKey(validObject), invalidObject)).To(Succeed())
+				invalidObject.Spec.TreeType = TreeTypePreorderedLog
+
+				Expect(apierrors.IsInvalid(k8sClient.Update(context.Background(), invalidObject))).To(BeTrue())
+				Expect(k8sClient.Update(context.Background(), invalidObject)).
+					To(MatchError(ContainSubstring("Field is immutable")))
+			})
+
+			It("immutable tree ID", func() {
+				validObject := generateTrillianTreeObject("immutable-id")
+				validObject.Spec.TreeID = ptr.To(int64(1))
+				Expect(k8sClient.Create(context.Background(), validObject)).To(Succeed())
+
+				invalidObject := &TrillianTree{}
+				Expect(k8sClient.Get(context.Background(), getKey(validObject), invalidObject)).To(Succeed())
+				invalidObject.Spec.TreeID = ptr.To(int64(2))
+
+				Expect(apierrors.IsInvalid(k8sClient.Update(context.Background(), invalidObject))).To(BeTrue())
+				Expect(k8sClient.Update(context.Background(), invalidObject)).
+					To(MatchError(ContainSubstring("Field is immutable")))
+			})
+
+			It("state", func() {
+				invalidObject := generateTrillianTreeObject("invalid-state")
+				invalidObject.Spec.State = "Deleted"
+				Expect(apierrors.IsInvalid(k8sClient.Create(context.Background(), invalidObject))).To(BeTrue())
+			})
+		})
+
+		Context("Default settings", func() {
+			It("creates CR with defaults", func() {
+				instance := TrillianTree{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tree-defaults",
+						Namespace: "default",
+					},
+				}
+
+				Expect(k8sClient.Create(context.Background(), &instance)).To(Succeed())
+				fetched := &TrillianTree{}
+				Expect(k8sClient.Get(context.Background(), getKey(&instance), fetched)).To(Succeed())
+				Expect(fetched.Spec).To(Equal(generateTrillianTreeObject("foo").Spec))
+			})
+		})
+	})
+})
+
+func generateTrillianTreeObject(name string) *TrillianTree {
+	return &TrillianTree{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: TrillianTreeSpec{
+			Trillian: TrillianService{
+				Port: ptr.To(int32(8091)),
+			},
+			TreeType:        TreeTypeLog,
+			State:           TreeStateActive,
+			MaxRootDuration: &metav1.Duration{Duration: time.Hour},
+			DeletionPolicy:  TrillianTreeRetain,
+		},
+	}
+}
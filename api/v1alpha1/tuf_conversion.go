@@ -0,0 +1,202 @@
+package v1alpha1
+
+import (
+	v1 "github.com/securesign/operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+func convertTufSpecToV1(in TufSpec) v1.TufSpec {
+	return v1.TufSpec{
+		ExternalAccess: convertExternalAccessToV1(in.ExternalAccess),
+		Port:           in.Port,
+		Keys:           convertSlice(in.Keys, convertTufKeyToV1),
+		Repository:     convertPointer(in.Repository, convertTufRepositoryToV1),
+		Replicas:       in.Replicas,
+		Mirror:         convertPointer(in.Mirror, convertTufMirrorToV1),
+	}
+}
+
+func convertTufSpecFromV1(in v1.TufSpec) TufSpec {
+	return TufSpec{
+		ExternalAccess: convertExternalAccessFromV1(in.ExternalAccess),
+		Port:           in.Port,
+		Keys:           convertSlice(in.Keys, convertTufKeyFromV1),
+		Repository:     convertPointer(in.Repository, convertTufRepositoryFromV1),
+		Replicas:       in.Replicas,
+		Mirror:         convertPointer(in.Mirror, convertTufMirrorFromV1),
+	}
+}
+
+func convertTufMirrorToV1(in TufMirror) v1.TufMirror {
+	return v1.TufMirror{
+		URL:      in.URL,
+		Root:     convertSecretKeySelectorToV1(in.Root),
+		Interval: in.Interval,
+	}
+}
+
+func convertTufMirrorFromV1(in v1.TufMirror) TufMirror {
+	return TufMirror{
+		URL:      in.URL,
+		Root:     convertSecretKeySelectorFromV1(in.Root),
+		Interval: in.Interval,
+	}
+}
+
+func convertTufRepositoryToV1(in TufRepository) v1.TufRepository {
+	return v1.TufRepository{
+		SigningKeys: convertPointer(in.SigningKeys, convertLocalObjectReferenceToV1),
+		Expiration:  convertTufExpirationToV1(in.Expiration),
+		Root:        convertPointer(in.Root, convertTufRootToV1),
+		TrustedRoot: convertPointer(in.TrustedRoot, convertTufTrustedRootToV1),
+	}
+}
+
+func convertTufRepositoryFromV1(in v1.TufRepository) TufRepository {
+	return TufRepository{
+		SigningKeys: convertPointer(in.SigningKeys, convertLocalObjectReferenceFromV1),
+		Expiration:  convertTufExpirationFromV1(in.Expiration),
+		Root:        convertPointer(in.Root, convertTufRootFromV1),
+		TrustedRoot: convertPointer(in.TrustedRoot, convertTufTrustedRootFromV1),
+	}
+}
+
+func convertTufTrustedRootToV1(in TufTrustedRoot) v1.TufTrustedRoot {
+	return v1.TufTrustedRoot{
+		FulcioURL:     in.FulcioURL,
+		RekorURL:      in.RekorURL,
+		OIDCURL:       in.OIDCURL,
+		RekorSharding: convertSlice(in.RekorSharding, convertRekorLogRangeToV1),
+	}
+}
+
+func convertTufTrustedRootFromV1(in v1.TufTrustedRoot) TufTrustedRoot {
+	return TufTrustedRoot{
+		FulcioURL:     in.FulcioURL,
+		RekorURL:      in.RekorURL,
+		OIDCURL:       in.OIDCURL,
+		RekorSharding: convertSlice(in.RekorSharding, convertRekorLogRangeFromV1),
+	}
+}
+
+func convertTufRootToV1(in TufRoot) v1.TufRoot {
+	return v1.TufRoot{
+		Keys:      convertSlice(in.Keys, convertSecretKeySelectorToV1),
+		Threshold: in.Threshold,
+	}
+}
+
+func convertTufRootFromV1(in v1.TufRoot) TufRoot {
+	return TufRoot{
+		Keys:      convertSlice(in.Keys, convertSecretKeySelectorFromV1),
+		Threshold: in.Threshold,
+	}
+}
+
+func convertTufExpirationToV1(in TufExpiration) v1.TufExpiration {
+	return v1.TufExpiration(in)
+}
+
+func convertTufExpirationFromV1(in v1.TufExpiration) TufExpiration {
+	return TufExpiration(in)
+}
+
+func convertTufKeyToV1(in TufKey) v1.TufKey {
+	return v1.TufKey{
+		Name:      in.Name,
+		SecretRef: convertPointer(in.SecretRef, convertSecretKeySelectorToV1),
+		Namespace: in.Namespace,
+		Selector:  in.Selector,
+		Usage:     in.Usage,
+	}
+}
+
+func convertTufKeyFromV1(in v1.TufKey) TufKey {
+	return TufKey{
+		Name:      in.Name,
+		SecretRef: convertPointer(in.SecretRef, convertSecretKeySelectorFromV1),
+		Namespace: in.Namespace,
+		Selector:  in.Selector,
+		Usage:     in.Usage,
+	}
+}
+
+func convertTufStatusToV1(in TufStatus) v1.TufStatus {
+	return v1.TufStatus{
+		Keys:               convertSlice(in.Keys, convertTufKeyToV1),
+		Url:                in.Url,
+		Repository:         convertPointer(in.Repository, convertTufRepositoryStatusToV1),
+		Mirror:             convertPointer(in.Mirror, convertTufMirrorStatusToV1),
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+func convertTufStatusFromV1(in v1.TufStatus) TufStatus {
+	return TufStatus{
+		Keys:               convertSlice(in.Keys, convertTufKeyFromV1),
+		Url:                in.Url,
+		Repository:         convertPointer(in.Repository, convertTufRepositoryStatusFromV1),
+		Mirror:             convertPointer(in.Mirror, convertTufMirrorStatusFromV1),
+		ObservedGeneration: in.ObservedGeneration,
+		Conditions:         in.Conditions,
+	}
+}
+
+func convertTufRepositoryStatusToV1(in TufRepositoryStatus) v1.TufRepositoryStatus {
+	return v1.TufRepositoryStatus{
+		SigningKeys: convertPointer(in.SigningKeys, convertLocalObjectReferenceToV1),
+		Roles:       convertSlice(in.Roles, convertTufRoleStatusToV1),
+		PendingRoot: convertPointer(in.PendingRoot, convertTufPendingRootStatusToV1),
+	}
+}
+
+func convertTufRepositoryStatusFromV1(in v1.TufRepositoryStatus) TufRepositoryStatus {
+	return TufRepositoryStatus{
+		SigningKeys: convertPointer(in.SigningKeys, convertLocalObjectReferenceFromV1),
+		Roles:       convertSlice(in.Roles, convertTufRoleStatusFromV1),
+		PendingRoot: convertPointer(in.PendingRoot, convertTufPendingRootStatusFromV1),
+	}
+}
+
+func convertTufPendingRootStatusToV1(in TufPendingRootStatus) v1.TufPendingRootStatus {
+	return v1.TufPendingRootStatus(in)
+}
+
+func convertTufPendingRootStatusFromV1(in v1.TufPendingRootStatus) TufPendingRootStatus {
+	return TufPendingRootStatus(in)
+}
+
+func convertTufMirrorStatusToV1(in TufMirrorStatus) v1.TufMirrorStatus {
+	return v1.TufMirrorStatus(in)
+}
+
+func convertTufMirrorStatusFromV1(in v1.TufMirrorStatus) TufMirrorStatus {
+	return TufMirrorStatus(in)
+}
+
+func convertTufRoleStatusToV1(in TufRoleStatus) v1.TufRoleStatus {
+	return v1.TufRoleStatus(in)
+}
+
+func convertTufRoleStatusFromV1(in v1.TufRoleStatus) TufRoleStatus {
+	return TufRoleStatus(in)
+}
+
+// ConvertTo converts this Tuf to the Hub version (v1).
+func (src *Tuf) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Tuf)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTufSpecToV1(src.Spec)
+	dst.Status = convertTufStatusToV1(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1) to this version.
+func (dst *Tuf) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Tuf)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = convertTufSpecFromV1(src.Spec)
+	dst.Status = convertTufStatusFromV1(src.Status)
+	return nil
+}
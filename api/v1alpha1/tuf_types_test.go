@@ -1,12 +1,15 @@
 package v1alpha1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"golang.org/x/net/context"
 	_ "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("TUF", func() {
@@ -147,6 +150,16 @@ var _ = Describe("TUF", func() {
 									},
 								},
 							},
+							Repository: &TufRepository{
+								SigningKeys: &LocalObjectReference{Name: "signing-keys"},
+								Expiration: TufExpiration{
+									Root:      metav1.Duration{Duration: 2 * 8760 * time.Hour},
+									Targets:   metav1.Duration{Duration: 8760 * time.Hour},
+									Snapshot:  metav1.Duration{Duration: 48 * time.Hour},
+									Timestamp: metav1.Duration{Duration: 2 * time.Hour},
+								},
+							},
+							Replicas: ptr.To(int32(3)),
 						},
 					}
 
@@ -207,6 +220,15 @@ func generateTufObject(name string) *Tuf {
 					Name: "fulcio_v1.crt.pem",
 				},
 			},
+			Repository: &TufRepository{
+				Expiration: TufExpiration{
+					Root:      metav1.Duration{Duration: 8760 * time.Hour},
+					Targets:   metav1.Duration{Duration: 8760 * time.Hour},
+					Snapshot:  metav1.Duration{Duration: 168 * time.Hour},
+					Timestamp: metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+			Replicas: ptr.To(int32(2)),
 		},
 	}
 }
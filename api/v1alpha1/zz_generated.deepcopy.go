@@ -72,6 +72,39 @@ func (in *CTlog) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CTlogAdmission) DeepCopyInto(out *CTlogAdmission) {
+	*out = *in
+	if in.ExtKeyUsages != nil {
+		in, out := &in.ExtKeyUsages, &out.ExtKeyUsages
+		*out = make([]ExtKeyUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotAfterStart != nil {
+		in, out := &in.NotAfterStart, &out.NotAfterStart
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfterLimit != nil {
+		in, out := &in.NotAfterLimit, &out.NotAfterLimit
+		*out = (*in).DeepCopy()
+	}
+	if in.RejectExtensions != nil {
+		in, out := &in.RejectExtensions, &out.RejectExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CTlogAdmission.
+func (in *CTlogAdmission) DeepCopy() *CTlogAdmission {
+	if in == nil {
+		return nil
+	}
+	out := new(CTlogAdmission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CTlogList) DeepCopyInto(out *CTlogList) {
 	*out = *in
@@ -112,6 +145,11 @@ func (in *CTlogSpec) DeepCopyInto(out *CTlogSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.TreeRef != nil {
+		in, out := &in.TreeRef, &out.TreeRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	if in.PrivateKeyRef != nil {
 		in, out := &in.PrivateKeyRef, &out.PrivateKeyRef
 		*out = new(SecretKeySelector)
@@ -139,6 +177,11 @@ func (in *CTlogSpec) DeepCopyInto(out *CTlogSpec) {
 		*out = new(LocalObjectReference)
 		**out = **in
 	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(CTlogAdmission)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CTlogSpec.
@@ -179,6 +222,11 @@ func (in *CTlogStatus) DeepCopyInto(out *CTlogStatus) {
 		*out = make([]SecretKeySelector, len(*in))
 		copy(*out, *in)
 	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(CTlogAdmission)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TreeID != nil {
 		in, out := &in.TreeID, &out.TreeID
 		*out = new(int64)
@@ -606,6 +654,11 @@ func (in *RekorSpec) DeepCopyInto(out *RekorSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.TreeRef != nil {
+		in, out := &in.TreeRef, &out.TreeRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
 	in.Trillian.DeepCopyInto(&out.Trillian)
 	out.ExternalAccess = in.ExternalAccess
 	out.Monitoring = in.Monitoring
@@ -711,9 +764,83 @@ func (in *Securesign) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuresignCTlogStatus) DeepCopyInto(out *SecuresignCTlogStatus) {
+	*out = *in
+	if in.TreeID != nil {
+		in, out := &in.TreeID, &out.TreeID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PublicKeyRef != nil {
+		in, out := &in.PublicKeyRef, &out.PublicKeyRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignCTlogStatus.
+func (in *SecuresignCTlogStatus) DeepCopy() *SecuresignCTlogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuresignCTlogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuresignComponent) DeepCopyInto(out *SecuresignComponent) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignComponent.
+func (in *SecuresignComponent) DeepCopy() *SecuresignComponent {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuresignComponent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuresignComponents) DeepCopyInto(out *SecuresignComponents) {
+	*out = *in
+	in.Trillian.DeepCopyInto(&out.Trillian)
+	in.Fulcio.DeepCopyInto(&out.Fulcio)
+	in.Rekor.DeepCopyInto(&out.Rekor)
+	in.Ctlog.DeepCopyInto(&out.Ctlog)
+	in.Tuf.DeepCopyInto(&out.Tuf)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignComponents.
+func (in *SecuresignComponents) DeepCopy() *SecuresignComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuresignComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuresignFulcioStatus) DeepCopyInto(out *SecuresignFulcioStatus) {
 	*out = *in
+	if in.CARef != nil {
+		in, out := &in.CARef, &out.CARef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.CAExpiration != nil {
+		in, out := &in.CAExpiration, &out.CAExpiration
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignFulcioStatus.
@@ -761,6 +888,16 @@ func (in *SecuresignList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuresignRekorStatus) DeepCopyInto(out *SecuresignRekorStatus) {
 	*out = *in
+	if in.TreeID != nil {
+		in, out := &in.TreeID, &out.TreeID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Sharding != nil {
+		in, out := &in.Sharding, &out.Sharding
+		*out = make([]RekorLogRange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignRekorStatus.
@@ -781,6 +918,7 @@ func (in *SecuresignSpec) DeepCopyInto(out *SecuresignSpec) {
 	in.Trillian.DeepCopyInto(&out.Trillian)
 	in.Tuf.DeepCopyInto(&out.Tuf)
 	in.Ctlog.DeepCopyInto(&out.Ctlog)
+	in.Components.DeepCopyInto(&out.Components)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignSpec.
@@ -803,9 +941,11 @@ func (in *SecuresignStatus) DeepCopyInto(out *SecuresignStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.RekorStatus = in.RekorStatus
-	out.FulcioStatus = in.FulcioStatus
-	out.TufStatus = in.TufStatus
+	in.RekorStatus.DeepCopyInto(&out.RekorStatus)
+	in.FulcioStatus.DeepCopyInto(&out.FulcioStatus)
+	in.TufStatus.DeepCopyInto(&out.TufStatus)
+	in.CTlogStatus.DeepCopyInto(&out.CTlogStatus)
+	in.TrillianStatus.DeepCopyInto(&out.TrillianStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignStatus.
@@ -818,9 +958,36 @@ func (in *SecuresignStatus) DeepCopy() *SecuresignStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecuresignTrillianStatus) DeepCopyInto(out *SecuresignTrillianStatus) {
+	*out = *in
+	if in.DatabaseSecretRef != nil {
+		in, out := &in.DatabaseSecretRef, &out.DatabaseSecretRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignTrillianStatus.
+func (in *SecuresignTrillianStatus) DeepCopy() *SecuresignTrillianStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecuresignTrillianStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecuresignTufStatus) DeepCopyInto(out *SecuresignTufStatus) {
 	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]TufKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecuresignTufStatus.
@@ -833,6 +1000,36 @@ func (in *SecuresignTufStatus) DeepCopy() *SecuresignTufStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+	if in.CertRef != nil {
+		in, out := &in.CertRef, &out.CertRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.PrivateKeyRef != nil {
+		in, out := &in.PrivateKeyRef, &out.PrivateKeyRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.CACertRef != nil {
+		in, out := &in.CACertRef, &out.CACertRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Trillian) DeepCopyInto(out *Trillian) {
 	*out = *in
@@ -874,6 +1071,11 @@ func (in *TrillianDB) DeepCopyInto(out *TrillianDB) {
 		**out = **in
 	}
 	in.Pvc.DeepCopyInto(&out.Pvc)
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(TrillianDBBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianDB.
@@ -887,170 +1089,132 @@ func (in *TrillianDB) DeepCopy() *TrillianDB {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TrillianList) DeepCopyInto(out *TrillianList) {
+func (in *TrillianDBBackup) DeepCopyInto(out *TrillianDBBackup) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Trillian, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianList.
-func (in *TrillianList) DeepCopy() *TrillianList {
-	if in == nil {
-		return nil
-	}
-	out := new(TrillianList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TrillianList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.Pvc != nil {
+		in, out := &in.Pvc, &out.Pvc
+		*out = new(Pvc)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TrillianService) DeepCopyInto(out *TrillianService) {
-	*out = *in
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(int32)
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(TrillianDBBackupS3)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianService.
-func (in *TrillianService) DeepCopy() *TrillianService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianDBBackup.
+func (in *TrillianDBBackup) DeepCopy() *TrillianDBBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(TrillianService)
+	out := new(TrillianDBBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TrillianSpec) DeepCopyInto(out *TrillianSpec) {
+func (in *TrillianDBBackupS3) DeepCopyInto(out *TrillianDBBackupS3) {
 	*out = *in
-	in.Db.DeepCopyInto(&out.Db)
-	out.Monitoring = in.Monitoring
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianSpec.
-func (in *TrillianSpec) DeepCopy() *TrillianSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianDBBackupS3.
+func (in *TrillianDBBackupS3) DeepCopy() *TrillianDBBackupS3 {
 	if in == nil {
 		return nil
 	}
-	out := new(TrillianSpec)
+	out := new(TrillianDBBackupS3)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TrillianStatus) DeepCopyInto(out *TrillianStatus) {
+func (in *TrillianDBBackupStatus) DeepCopyInto(out *TrillianDBBackupStatus) {
 	*out = *in
-	in.Db.DeepCopyInto(&out.Db)
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSize != nil {
+		in, out := &in.LastSize, &out.LastSize
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianStatus.
-func (in *TrillianStatus) DeepCopy() *TrillianStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianDBBackupStatus.
+func (in *TrillianDBBackupStatus) DeepCopy() *TrillianDBBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TrillianStatus)
+	out := new(TrillianDBBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Tuf) DeepCopyInto(out *Tuf) {
+func (in *TrillianDBUpgrade) DeepCopyInto(out *TrillianDBUpgrade) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tuf.
-func (in *Tuf) DeepCopy() *Tuf {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianDBUpgrade.
+func (in *TrillianDBUpgrade) DeepCopy() *TrillianDBUpgrade {
 	if in == nil {
 		return nil
 	}
-	out := new(Tuf)
+	out := new(TrillianDBUpgrade)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Tuf) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TufKey) DeepCopyInto(out *TufKey) {
+func (in *TrillianElection) DeepCopyInto(out *TrillianElection) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretKeySelector)
-		**out = **in
+	if in.EtcdServers != nil {
+		in, out := &in.EtcdServers, &out.EtcdServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufKey.
-func (in *TufKey) DeepCopy() *TufKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianElection.
+func (in *TrillianElection) DeepCopy() *TrillianElection {
 	if in == nil {
 		return nil
 	}
-	out := new(TufKey)
+	out := new(TrillianElection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TufList) DeepCopyInto(out *TufList) {
+func (in *TrillianList) DeepCopyInto(out *TrillianList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Tuf, len(*in))
+		*out = make([]Trillian, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufList.
-func (in *TufList) DeepCopy() *TufList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianList.
+func (in *TrillianList) DeepCopy() *TrillianList {
 	if in == nil {
 		return nil
 	}
-	out := new(TufList)
+	out := new(TrillianList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TufList) DeepCopyObject() runtime.Object {
+func (in *TrillianList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1058,38 +1222,670 @@ func (in *TufList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TufSpec) DeepCopyInto(out *TufSpec) {
+func (in *TrillianLogServer) DeepCopyInto(out *TrillianLogServer) {
 	*out = *in
-	out.ExternalAccess = in.ExternalAccess
-	if in.Keys != nil {
-		in, out := &in.Keys, &out.Keys
-		*out = make([]TufKey, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufSpec.
-func (in *TufSpec) DeepCopy() *TufSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianLogServer.
+func (in *TrillianLogServer) DeepCopy() *TrillianLogServer {
 	if in == nil {
 		return nil
 	}
-	out := new(TufSpec)
+	out := new(TrillianLogServer)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TufStatus) DeepCopyInto(out *TufStatus) {
+func (in *TrillianLogSigner) DeepCopyInto(out *TrillianLogSigner) {
 	*out = *in
-	if in.Keys != nil {
-		in, out := &in.Keys, &out.Keys
-		*out = make([]TufKey, len(*in))
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Election.DeepCopyInto(&out.Election)
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SequencerInterval != nil {
+		in, out := &in.SequencerInterval, &out.SequencerInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NumSequencers != nil {
+		in, out := &in.NumSequencers, &out.NumSequencers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MasterHoldInterval != nil {
+		in, out := &in.MasterHoldInterval, &out.MasterHoldInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianLogSigner.
+func (in *TrillianLogSigner) DeepCopy() *TrillianLogSigner {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianLogSigner)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianQuota) DeepCopyInto(out *TrillianQuota) {
+	*out = *in
+	if in.MaxUnsequencedRows != nil {
+		in, out := &in.MaxUnsequencedRows, &out.MaxUnsequencedRows
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TreeWrite != nil {
+		in, out := &in.TreeWrite, &out.TreeWrite
+		*out = new(TrillianTreeQuota)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianQuota.
+func (in *TrillianQuota) DeepCopy() *TrillianQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianService) DeepCopyInto(out *TrillianService) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CACertRef != nil {
+		in, out := &in.CACertRef, &out.CACertRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianService.
+func (in *TrillianService) DeepCopy() *TrillianService {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianSignerMaster) DeepCopyInto(out *TrillianSignerMaster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianSignerMaster.
+func (in *TrillianSignerMaster) DeepCopy() *TrillianSignerMaster {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianSignerMaster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianSpec) DeepCopyInto(out *TrillianSpec) {
+	*out = *in
+	in.Db.DeepCopyInto(&out.Db)
+	out.Monitoring = in.Monitoring
+	in.TLS.DeepCopyInto(&out.TLS)
+	in.LogServer.DeepCopyInto(&out.LogServer)
+	in.LogSigner.DeepCopyInto(&out.LogSigner)
+	in.Quota.DeepCopyInto(&out.Quota)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianSpec.
+func (in *TrillianSpec) DeepCopy() *TrillianSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianStatus) DeepCopyInto(out *TrillianStatus) {
+	*out = *in
+	in.Db.DeepCopyInto(&out.Db)
+	in.TLS.DeepCopyInto(&out.TLS)
+	if in.ElectedSigners != nil {
+		in, out := &in.ElectedSigners, &out.ElectedSigners
+		*out = make([]TrillianSignerMaster, len(*in))
+		copy(*out, *in)
+	}
+	if in.DatabaseUpgrade != nil {
+		in, out := &in.DatabaseUpgrade, &out.DatabaseUpgrade
+		*out = new(TrillianDBUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(TrillianDBBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianStatus.
+func (in *TrillianStatus) DeepCopy() *TrillianStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianTree) DeepCopyInto(out *TrillianTree) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianTree.
+func (in *TrillianTree) DeepCopy() *TrillianTree {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrillianTree) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianTreeList) DeepCopyInto(out *TrillianTreeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrillianTree, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianTreeList.
+func (in *TrillianTreeList) DeepCopy() *TrillianTreeList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianTreeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrillianTreeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianTreeQuota) DeepCopyInto(out *TrillianTreeQuota) {
+	*out = *in
+	if in.TokensToReplenish != nil {
+		in, out := &in.TokensToReplenish, &out.TokensToReplenish
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ReplenishInterval != nil {
+		in, out := &in.ReplenishInterval, &out.ReplenishInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianTreeQuota.
+func (in *TrillianTreeQuota) DeepCopy() *TrillianTreeQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianTreeQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianTreeSpec) DeepCopyInto(out *TrillianTreeSpec) {
+	*out = *in
+	in.Trillian.DeepCopyInto(&out.Trillian)
+	if in.TreeID != nil {
+		in, out := &in.TreeID, &out.TreeID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRootDuration != nil {
+		in, out := &in.MaxRootDuration, &out.MaxRootDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianTreeSpec.
+func (in *TrillianTreeSpec) DeepCopy() *TrillianTreeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianTreeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrillianTreeStatus) DeepCopyInto(out *TrillianTreeStatus) {
+	*out = *in
+	if in.TreeID != nil {
+		in, out := &in.TreeID, &out.TreeID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRootDuration != nil {
+		in, out := &in.MaxRootDuration, &out.MaxRootDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrillianTreeStatus.
+func (in *TrillianTreeStatus) DeepCopy() *TrillianTreeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrillianTreeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tuf) DeepCopyInto(out *Tuf) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tuf.
+func (in *Tuf) DeepCopy() *Tuf {
+	if in == nil {
+		return nil
+	}
+	out := new(Tuf)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Tuf) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufExpiration) DeepCopyInto(out *TufExpiration) {
+	*out = *in
+	out.Root = in.Root
+	out.Targets = in.Targets
+	out.Snapshot = in.Snapshot
+	out.Timestamp = in.Timestamp
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufExpiration.
+func (in *TufExpiration) DeepCopy() *TufExpiration {
+	if in == nil {
+		return nil
+	}
+	out := new(TufExpiration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufKey) DeepCopyInto(out *TufKey) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufKey.
+func (in *TufKey) DeepCopy() *TufKey {
+	if in == nil {
+		return nil
+	}
+	out := new(TufKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufList) DeepCopyInto(out *TufList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Tuf, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufList.
+func (in *TufList) DeepCopy() *TufList {
+	if in == nil {
+		return nil
+	}
+	out := new(TufList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TufList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufMirror) DeepCopyInto(out *TufMirror) {
+	*out = *in
+	out.Root = in.Root
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufMirror.
+func (in *TufMirror) DeepCopy() *TufMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(TufMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufMirrorStatus) DeepCopyInto(out *TufMirrorStatus) {
+	*out = *in
+	if in.LastVerifiedTime != nil {
+		in, out := &in.LastVerifiedTime, &out.LastVerifiedTime
+		*out = (*in).DeepCopy()
+	}
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufMirrorStatus.
+func (in *TufMirrorStatus) DeepCopy() *TufMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TufMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufPendingRootStatus) DeepCopyInto(out *TufPendingRootStatus) {
+	*out = *in
+	if in.KeyIDs != nil {
+		in, out := &in.KeyIDs, &out.KeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousKeyIDs != nil {
+		in, out := &in.PreviousKeyIDs, &out.PreviousKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignedKeyIDs != nil {
+		in, out := &in.SignedKeyIDs, &out.SignedKeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufPendingRootStatus.
+func (in *TufPendingRootStatus) DeepCopy() *TufPendingRootStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TufPendingRootStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufRepository) DeepCopyInto(out *TufRepository) {
+	*out = *in
+	if in.SigningKeys != nil {
+		in, out := &in.SigningKeys, &out.SigningKeys
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	out.Expiration = in.Expiration
+	if in.Root != nil {
+		in, out := &in.Root, &out.Root
+		*out = new(TufRoot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedRoot != nil {
+		in, out := &in.TrustedRoot, &out.TrustedRoot
+		*out = new(TufTrustedRoot)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufRepository.
+func (in *TufRepository) DeepCopy() *TufRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(TufRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufRepositoryStatus) DeepCopyInto(out *TufRepositoryStatus) {
+	*out = *in
+	if in.SigningKeys != nil {
+		in, out := &in.SigningKeys, &out.SigningKeys
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]TufRoleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingRoot != nil {
+		in, out := &in.PendingRoot, &out.PendingRoot
+		*out = new(TufPendingRootStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufRepositoryStatus.
+func (in *TufRepositoryStatus) DeepCopy() *TufRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TufRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufRoleStatus) DeepCopyInto(out *TufRoleStatus) {
+	*out = *in
+	in.Expires.DeepCopyInto(&out.Expires)
+	if in.KeyIDs != nil {
+		in, out := &in.KeyIDs, &out.KeyIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufRoleStatus.
+func (in *TufRoleStatus) DeepCopy() *TufRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TufRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufRoot) DeepCopyInto(out *TufRoot) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufRoot.
+func (in *TufRoot) DeepCopy() *TufRoot {
+	if in == nil {
+		return nil
+	}
+	out := new(TufRoot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufSpec) DeepCopyInto(out *TufSpec) {
+	*out = *in
+	out.ExternalAccess = in.ExternalAccess
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]TufKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(TufRepository)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(TufMirror)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufSpec.
+func (in *TufSpec) DeepCopy() *TufSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TufSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufStatus) DeepCopyInto(out *TufStatus) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]TufKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(TufRepositoryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(TufMirrorStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1108,3 +1904,23 @@ func (in *TufStatus) DeepCopy() *TufStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TufTrustedRoot) DeepCopyInto(out *TufTrustedRoot) {
+	*out = *in
+	if in.RekorSharding != nil {
+		in, out := &in.RekorSharding, &out.RekorSharding
+		*out = make([]RekorLogRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TufTrustedRoot.
+func (in *TufTrustedRoot) DeepCopy() *TufTrustedRoot {
+	if in == nil {
+		return nil
+	}
+	out := new(TufTrustedRoot)
+	in.DeepCopyInto(out)
+	return out
+}
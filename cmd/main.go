@@ -17,8 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
 
 	"net/http"
 	"os"
@@ -36,7 +38,9 @@ import (
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/securesign/operator/internal/clidownload"
+	"github.com/securesign/operator/internal/controller/common"
 	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
@@ -52,13 +56,16 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	rhtasv1 "github.com/securesign/operator/api/v1"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/ctlog"
 	"github.com/securesign/operator/internal/controller/fulcio"
 	"github.com/securesign/operator/internal/controller/rekor"
 	"github.com/securesign/operator/internal/controller/securesign"
 	"github.com/securesign/operator/internal/controller/trillian"
+	"github.com/securesign/operator/internal/controller/trilliantree"
 	"github.com/securesign/operator/internal/controller/tuf"
+	webhookv1alpha1 "github.com/securesign/operator/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -74,6 +81,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	utilruntime.Must(rhtasv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(rhtasv1.AddToScheme(scheme))
 	utilruntime.Must(routev1.AddToScheme(scheme))
 	utilruntime.Must(v1.AddToScheme(scheme))
 	utilruntime.Must(consolev1.AddToScheme(scheme))
@@ -91,6 +99,15 @@ func main() {
 		enableHTTP2          bool
 	)
 
+	// the manager binary creates Trillian trees from the create tree job
+	if len(os.Args) > 1 && os.Args[1] == common.CreateTreeCommand {
+		if err := common.RunCreateTree(ctrl.SetupSignalHandler(), os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	flag.StringVar(&pprofAddr, "pprof-address", "", "The address to expose the pprof server. Default is empty string which disables the pprof server.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -102,10 +119,14 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.Int64Var(&constants.CreateTreeDeadline, "create-tree-deadline", constants.CreateTreeDeadline, "The time allowance (in seconds) for the create tree job to run before failing.")
+	utils.BoolFlagOrEnv(&constants.CreateTreeInJob, "create-tree-in-job", "CREATE_TREE_IN_JOB", false, "Enable to create Trillian trees of the Rekor and CTlog resources from a Job running in the namespace of the resource instead of the operator.")
 	utils.BoolFlagOrEnv(&constants.Openshift, "openshift", "OPENSHIFT", false, "Enable to ensures the operator applies OpenShift specific configurations.")
 	utils.StringFlagOrEnv(&constants.TrillianLogSignerImage, "trillian-log-signer-image", "TRILLIAN_LOG_SIGNER_IMAGE", constants.TrillianLogSignerImage, "The image used for trillian log signer.")
 	utils.StringFlagOrEnv(&constants.TrillianServerImage, "trillian-log-server-image", "TRILLIAN_LOG_SERVER_IMAGE", constants.TrillianServerImage, "The image used for trillian log server.")
 	utils.StringFlagOrEnv(&constants.TrillianDbImage, "trillian-db-image", "TRILLIAN_DB_IMAGE", constants.TrillianDbImage, "The image used for trillian's database.")
+	utils.StringFlagOrEnv(&constants.TrillianPostgresqlImage, "trillian-postgresql-image", "TRILLIAN_POSTGRESQL_IMAGE", constants.TrillianPostgresqlImage, "The image used for trillian's PostgreSQL database.")
+	utils.StringFlagOrEnv(&constants.TrillianBackupS3Image, "trillian-backup-s3-image", "TRILLIAN_BACKUP_S3_IMAGE", constants.TrillianBackupS3Image, "The image used to transfer trillian's database backups to S3.")
+	utils.StringFlagOrEnv(&constants.TrillianEtcdImage, "trillian-etcd-image", "TRILLIAN_ETCD_IMAGE", constants.TrillianEtcdImage, "The image used for the etcd of the trillian log signer election.")
 	utils.StringFlagOrEnv(&constants.TrillianNetcatImage, "trillian-netcat-image", "TRILLIAN_NETCAT_IMAGE", constants.TrillianNetcatImage, "The image used for trillian netcat.")
 	utils.StringFlagOrEnv(&constants.FulcioServerImage, "fulcio-server-image", "FULCIO_SERVER_IMAGE", constants.FulcioServerImage, "The image used for the fulcio server.")
 	utils.StringFlagOrEnv(&constants.RekorRedisImage, "rekor-redis-image", "REKOR_REDIS_IMAGE", constants.RekorRedisImage, "The image used for redis.")
@@ -113,11 +134,13 @@ func main() {
 	utils.StringFlagOrEnv(&constants.RekorSearchUiImage, "rekor-search-ui-image", "REKOR_SEARCH_UI_IMAGE", constants.RekorSearchUiImage, "The image used for rekor search ui.")
 	utils.StringFlagOrEnv(&constants.BackfillRedisImage, "backfill-redis-image", "BACKFILL_REDIS_IMAGE", constants.BackfillRedisImage, "The image used for backfill redis.")
 	utils.StringFlagOrEnv(&constants.TufImage, "tuf-image", "TUF_IMAGE", constants.TufImage, "The image used for TUF.")
+	utils.StringFlagOrEnv(&constants.TufRepositoryImage, "tuf-repository-image", "TUF_REPOSITORY_IMAGE", constants.TufRepositoryImage, "The image serving the TUF repository generated by the operator.")
 	utils.StringFlagOrEnv(&constants.CTLogImage, "ctlog-image", "CTLOG_IMAGE", constants.CTLogImage, "The image used for ctlog.")
 	utils.StringFlagOrEnv(&constants.ClientServerImage, "client-server-image", "CLIENT_SERVER_IMAGE", constants.ClientServerImage, "The image used to serve our cli binary's.")
 	utils.StringFlagOrEnv(&constants.ClientServerImage_cg, "client-server-cg-image", "CLIENT_SERVER_CG_IMAGE", constants.ClientServerImage_cg, "The image used to serve cosign and gitsign.")
 	utils.StringFlagOrEnv(&constants.ClientServerImage_re, "client-server-re-image", "CLIENT_SERVER_RE_IMAGE", constants.ClientServerImage_re, "The image used to serve rekor-cli and the ec binary.")
 	utils.StringFlagOrEnv(&constants.SegmentBackupImage, "segment-backup-job-image", "SEGMENT_BACKUP_JOB_IMAGE", constants.SegmentBackupImage, "The image used for the segment backup job")
+	utils.StringFlagOrEnv(&constants.CreateTreeImage, "create-tree-image", "CREATE_TREE_IMAGE", constants.CreateTreeImage, "The image used for the create tree job. Defaults to the image of the running operator.")
 	flag.StringVar(&clidownload.CliHostName, "cli-server-hostname", "", "The hostname for the cli server")
 
 	klog.InitFlags(flag.CommandLine)
@@ -190,9 +213,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the create tree job runs the create-tree command of the operator binary, use the same image
+	if constants.CreateTreeImage == "" {
+		image, err := kubernetes.OperatorImage(context.Background(), mgr.GetAPIReader())
+		switch {
+		case err == nil:
+			constants.CreateTreeImage = image
+		case constants.CreateTreeInJob:
+			setupLog.Error(err, "unable to resolve the create tree job image, set it with --create-tree-image")
+			os.Exit(1)
+		default:
+			setupLog.Info("unable to resolve the create tree job image", "reason", err.Error())
+		}
+	}
+
 	if err = (&securesign.SecuresignReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("securesign-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Securesign")
 		os.Exit(1)
@@ -213,6 +251,14 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Trillian")
 		os.Exit(1)
 	}
+	if err = (&trilliantree.TrillianTreeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("trilliantree-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "TrillianTree")
+		os.Exit(1)
+	}
 	if err = (&rekor.RekorReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -237,6 +283,26 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "CTlog")
 		os.Exit(1)
 	}
+	// the webhooks require the serving certificate, they are disabled to run the manager locally.
+	// The conversion webhook between v1alpha1 and v1 is served together with them.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		for _, w := range []struct {
+			kind  string
+			setup func(ctrl.Manager) error
+		}{
+			{"Securesign", webhookv1alpha1.SetupSecuresignWebhookWithManager},
+			{"Fulcio", webhookv1alpha1.SetupFulcioWebhookWithManager},
+			{"Trillian", webhookv1alpha1.SetupTrillianWebhookWithManager},
+			{"Rekor", webhookv1alpha1.SetupRekorWebhookWithManager},
+			{"Tuf", webhookv1alpha1.SetupTufWebhookWithManager},
+			{"CTlog", webhookv1alpha1.SetupCTlogWebhookWithManager},
+		} {
+			if err = w.setup(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", w.kind)
+				os.Exit(1)
+			}
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
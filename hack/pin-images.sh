@@ -0,0 +1,15 @@
+#!/usr/bin/env bash
+# Pins the operand images referenced by a tag to the digest the tag currently points to, in the defaults of the
+# operator and in the related images of the ClusterServiceVersion. Needs skopeo logged in to registry.redhat.io.
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+SKOPEO=${SKOPEO:-skopeo}
+FILES=(internal/controller/constants/images.go config/manifests/bases/rhtas-operator.clusterserviceversion.yaml)
+
+for image in $(grep -hoE '[a-z0-9.-]+\.[a-z]+(/[a-z0-9._-]+)+:[A-Za-z0-9._-]+' "${FILES[@]}" | sort -u); do
+  # the digest of the manifest list keeps the image multi-arch
+  digest=sha256:$($SKOPEO inspect --raw "docker://$image" | sha256sum | cut -d' ' -f1)
+  echo "$image -> ${image%:*}@$digest"
+  sed -i "s|$image|${image%:*}@$digest|g" "${FILES[@]}"
+done
@@ -0,0 +1,8 @@
+package apis
+
+// ObservedGenerationAwareObject represents a CRD type reporting the generation of the spec its controller reconciled.
+type ObservedGenerationAwareObject interface {
+	ConditionsAwareObject
+	GetObservedGeneration() int64
+	SetObservedGeneration(generation int64)
+}
@@ -12,6 +12,21 @@ const (
 
 	// TreeId Annotation inform that resource is associated with specific Merkle Tree
 	TreeId = "rhtas.redhat.com/treeId"
+
+	// RestoreBackup Annotation triggers the restore of the named database backup, "latest" restores the newest backup
+	RestoreBackup = "rhtas.redhat.com/restore-backup"
+
+	// ResignTufMetadata Annotation triggers new versions of the TUF metadata of the comma-separated roles
+	ResignTufMetadata = "rhtas.redhat.com/resign-tuf-metadata"
+
+	// LegacyNames Annotation marks the resources installed before the names of the generated resources were derived
+	// from the resource name, the generated resources keep their fixed names. The operator sets it to "false"
+	// for the existing resources that use the derived names.
+	LegacyNames = "rhtas.redhat.com/legacy-names"
+
+	// SkipTreeDeletion Annotation removes the TrillianTree resource without deleting its tree, even with the Delete
+	// deletion policy. Used when the Trillian backend is unreachable.
+	SkipTreeDeletion = "rhtas.redhat.com/skip-tree-deletion"
 )
 
 var inheritable = []string{
@@ -0,0 +1,68 @@
+package transitions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/securesign/operator/internal/apis"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LegacyResourceSupplier returns a resource generated with its fixed name for the instances installed before
+// the names were derived from the instance name
+type LegacyResourceSupplier[T apis.ConditionsAwareObject] func(T) client.Object
+
+// NewLegacyNamesAction annotates the instances installed with the fixed names of the generated resources,
+// so that they keep using them
+func NewLegacyNamesAction[T apis.ConditionsAwareObject](legacyResource LegacyResourceSupplier[T]) action.Action[T] {
+	return &legacyNames[T]{legacyResource: legacyResource}
+}
+
+type legacyNames[T apis.ConditionsAwareObject] struct {
+	action.BaseAction
+	legacyResource LegacyResourceSupplier[T]
+}
+
+func (i legacyNames[T]) Name() string {
+	return "legacy names"
+}
+
+func (i legacyNames[T]) CanHandle(_ context.Context, instance T) bool {
+	// new instances are reconciled with the derived names from the start
+	if meta.FindStatusCondition(instance.GetConditions(), constants.Ready) == nil {
+		return false
+	}
+	_, ok := instance.GetAnnotations()[annotations.LegacyNames]
+	return !ok
+}
+
+func (i legacyNames[T]) Handle(ctx context.Context, instance T) *action.Result {
+	legacy := i.legacyResource(instance)
+	err := i.Client.Get(ctx, client.ObjectKey{Namespace: instance.GetNamespace(), Name: legacy.GetName()}, legacy)
+	if client.IgnoreNotFound(err) != nil {
+		return i.Failed(fmt.Errorf("could not read %s: %w", legacy.GetName(), err))
+	}
+	// the decision is recorded in both cases, the instance is not checked again
+	isLegacy := err == nil && metav1.IsControlledBy(legacy, instance)
+
+	patch := client.MergeFrom(instance.DeepCopyObject().(client.Object))
+	instanceAnnotations := instance.GetAnnotations()
+	if instanceAnnotations == nil {
+		instanceAnnotations = map[string]string{}
+	}
+	instanceAnnotations[annotations.LegacyNames] = strconv.FormatBool(isLegacy)
+	instance.SetAnnotations(instanceAnnotations)
+	if err := i.Client.Patch(ctx, instance, patch); err != nil {
+		return i.Failed(fmt.Errorf("could not annotate legacy names: %w", err))
+	}
+	if isLegacy {
+		i.Logger.Info("Keeping the legacy names of the generated resources", "resource", legacy.GetName())
+	}
+	return i.Continue()
+}
@@ -0,0 +1,59 @@
+package transitions
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestLegacyNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		legacy bool
+		want   string
+	}{
+		{
+			name:   "legacy deployment",
+			legacy: true,
+			want:   "true",
+		},
+		{
+			name: "no legacy deployment",
+			want: "false",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+			instance := &rhtasv1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default", UID: "uid"}}
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{Type: constants.Ready, Reason: constants.Ready})
+
+			objects := []client.Object{instance}
+			if tt.legacy {
+				deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "rekor-server", Namespace: "default"}}
+				g.Expect(controllerutil.SetControllerReference(instance, deployment, testAction.FakeClientBuilder().Build().Scheme())).To(Succeed())
+				objects = append(objects, deployment)
+			}
+			c := testAction.FakeClientBuilder().WithObjects(objects...).Build()
+			a := testAction.PrepareAction(c, NewLegacyNamesAction[*rhtasv1alpha1.Rekor](func(_ *rhtasv1alpha1.Rekor) client.Object {
+				return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "rekor-server"}}
+			}))
+
+			g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+			g.Expect(a.Handle(ctx, instance)).To(BeNil())
+			g.Expect(instance.GetAnnotations()).To(HaveKeyWithValue(annotations.LegacyNames, tt.want))
+			g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package transitions
+
+import (
+	"context"
+
+	"github.com/securesign/operator/internal/apis"
+	"github.com/securesign/operator/internal/controller/common/action"
+)
+
+// NewObservedGenerationAction records the generation of the spec once every preceding action is done with it
+func NewObservedGenerationAction[T apis.ObservedGenerationAwareObject]() action.Action[T] {
+	return &observedGenerationAction[T]{}
+}
+
+type observedGenerationAction[T apis.ObservedGenerationAwareObject] struct {
+	action.BaseAction
+}
+
+func (i observedGenerationAction[T]) Name() string {
+	return "observed generation"
+}
+
+func (i observedGenerationAction[T]) CanHandle(_ context.Context, instance T) bool {
+	return instance.GetObservedGeneration() != instance.GetGeneration()
+}
+
+func (i observedGenerationAction[T]) Handle(ctx context.Context, instance T) *action.Result {
+	instance.SetObservedGeneration(instance.GetGeneration())
+	return i.StatusUpdate(ctx, instance)
+}
@@ -2,22 +2,50 @@ package common
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"time"
 
 	"github.com/google/trillian"
-	"github.com/google/trillian/client"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"k8s.io/klog/v2"
 )
 
+// CreateTrillianTree returns the Merkle tree tagged for the owner in the Trillian backend.
+// A new tree is created only when no tagged tree exists, true is returned in that case.
+// The connection uses TLS verified by caCert when it is set.
 // reference code https://github.com/sigstore/scaffolding/blob/main/cmd/trillian/createtree/main.go
-func CreateTrillianTree(ctx context.Context, displayName string, trillianURL string, deadline int64) (*trillian.Tree, error) {
-	var err error
+func CreateTrillianTree(ctx context.Context, displayName string, owner TreeOwner, trillianURL string, deadline int64, caCert []byte) (*trillian.Tree, bool, error) {
+	req, err := newRequest(displayName)
+	if err != nil {
+		return nil, false, err
+	}
+	trillianClient, err := NewTrillianClient(trillianURL, caCert)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { _ = trillianClient.Close() }()
+
+	timeout := time.Duration(deadline) * time.Second
+	ctx2, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	tree, created, err := EnsureTree(ctx2, trillianClient, owner, req.Tree)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not create Trillian tree: %w", err)
+	}
+	return tree, created, nil
+}
+
+// dialTrillian opens a gRPC connection to the Trillian log server.
+// The connection uses TLS verified by caCert when it is set.
+func dialTrillian(trillianURL string, caCert []byte) (*grpc.ClientConn, error) {
 	inContainer, err := kubernetes.ContainerMode()
 	if err == nil {
 		if !inContainer {
@@ -37,30 +65,22 @@ func CreateTrillianTree(ctx context.Context, displayName string, trillianURL str
 	} else {
 		klog.Info("Can't recognise operator mode - expecting in-container run")
 	}
-	req, err := newRequest(displayName)
-	if err != nil {
-		return nil, err
-	}
 	var opts grpc.DialOption
-	klog.Warning("Using an insecure gRPC connection to Trillian")
-	opts = grpc.WithTransportCredentials(insecure.NewCredentials())
+	if len(caCert) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse Trillian CA certificate")
+		}
+		opts = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+	} else {
+		klog.Warning("Using an insecure gRPC connection to Trillian")
+		opts = grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
 	conn, err := grpc.Dial(trillianURL, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial: %w", err)
 	}
-	defer func() { _ = conn.Close() }()
-
-	adminClient := trillian.NewTrillianAdminClient(conn)
-	logClient := trillian.NewTrillianLogClient(conn)
-
-	timeout := time.Duration(deadline) * time.Second
-	ctx2, cancel := context.WithTimeout(ctx, timeout)
-	tree, err := client.CreateAndInitTree(ctx2, req, adminClient, logClient)
-	defer cancel()
-	if err != nil {
-		return nil, fmt.Errorf("could not create Trillian tree: %w", err)
-	}
-	return tree, err
+	return conn, nil
 }
 
 func rawConnect(host string, port string) bool {
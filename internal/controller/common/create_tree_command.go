@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// CreateTreeCommand is the manager sub-command creating a Trillian tree from a Job
+	CreateTreeCommand = "create-tree"
+	// TerminationLogPath is the file the create-tree command writes the tree ID to
+	TerminationLogPath = "/dev/termination-log"
+	// TreeCreated and TreeAdopted follow the tree ID written by the create-tree command
+	TreeCreated = "created"
+	TreeAdopted = "adopted"
+)
+
+// RunCreateTree implements the create-tree command. It resolves the tree tagged for the owner
+// the same way as the operator does and writes its ID to the termination log of the container.
+func RunCreateTree(ctx context.Context, args []string) error {
+	var (
+		owner                                             TreeOwner
+		uid, trillianURL, displayName, caCertFile, output string
+		deadline                                          int64
+	)
+	fs := flag.NewFlagSet(CreateTreeCommand, flag.ContinueOnError)
+	fs.StringVar(&trillianURL, "trillian-url", "", "Address of the Trillian Log Server (host:port).")
+	fs.StringVar(&caCertFile, "ca-cert", "", "File with the CA certificate used to verify the Trillian Log Server.")
+	fs.StringVar(&displayName, "display-name", "", "Display name of the tree.")
+	fs.StringVar(&owner.Kind, "owner-kind", "", "Kind of the resource the tree is created for.")
+	fs.StringVar(&owner.Namespace, "owner-namespace", "", "Namespace of the resource the tree is created for.")
+	fs.StringVar(&owner.Name, "owner-name", "", "Name of the resource the tree is created for.")
+	fs.StringVar(&uid, "owner-uid", "", "UID of the resource the tree is created for.")
+	fs.Int64Var(&deadline, "deadline", 1200, "The time allowance (in seconds) to create the tree.")
+	fs.StringVar(&output, "output", TerminationLogPath, "File the tree ID is written to.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	owner.UID = types.UID(uid)
+
+	if trillianURL == "" || owner.Kind == "" || owner.Namespace == "" || owner.Name == "" || owner.UID == "" {
+		return errors.New("trillian-url, owner-kind, owner-namespace, owner-name and owner-uid are required")
+	}
+
+	treeID, created, err := createTree(ctx, displayName, owner, trillianURL, deadline, caCertFile)
+	if err != nil {
+		// the error is reported to the operator in the termination message
+		_ = os.WriteFile(output, []byte(err.Error()), 0600)
+		return err
+	}
+	outcome := TreeAdopted
+	if created {
+		outcome = TreeCreated
+	}
+	fmt.Printf("Trillian tree %s: %d\n", outcome, treeID)
+	return os.WriteFile(output, []byte(strconv.FormatInt(treeID, 10)+" "+outcome), 0600)
+}
+
+func createTree(ctx context.Context, displayName string, owner TreeOwner, trillianURL string, deadline int64, caCertFile string) (int64, bool, error) {
+	var caCert []byte
+	if caCertFile != "" {
+		var err error
+		if caCert, err = os.ReadFile(caCertFile); err != nil {
+			return 0, false, fmt.Errorf("could not read Trillian CA certificate: %w", err)
+		}
+	}
+
+	tree, created, err := CreateTrillianTree(ctx, displayName, owner, trillianURL, deadline, caCert)
+	if err != nil {
+		return 0, false, err
+	}
+	return tree.TreeId, created, nil
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/client"
+	"github.com/google/trillian/quota/etcd/quotapb"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// TrillianClient manages trees in the Trillian backend
+type TrillianClient interface {
+	// CreateTree creates and initializes a new tree
+	CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error)
+	GetTree(ctx context.Context, treeID int64) (*trillian.Tree, error)
+	// ListTrees returns trees which are not deleted
+	ListTrees(ctx context.Context) ([]*trillian.Tree, error)
+	// UpdateTree updates the tree fields listed in paths
+	UpdateTree(ctx context.Context, tree *trillian.Tree, paths ...string) (*trillian.Tree, error)
+	DeleteTree(ctx context.Context, treeID int64) error
+	// TreeSize returns the size of the latest signed log root
+	TreeSize(ctx context.Context, treeID int64) (uint64, error)
+	// GetQuota returns the quota config, the quota API is served only with the etcd quota system
+	GetQuota(ctx context.Context, name string) (*quotapb.Config, error)
+	CreateQuota(ctx context.Context, config *quotapb.Config) (*quotapb.Config, error)
+	// UpdateQuota updates the quota config fields listed in paths
+	UpdateQuota(ctx context.Context, config *quotapb.Config, paths ...string) (*quotapb.Config, error)
+	Close() error
+}
+
+// NewTrillianClientFunc opens a connection to the Trillian log server
+type NewTrillianClientFunc func(trillianURL string, caCert []byte) (TrillianClient, error)
+
+// NewTrillianClient opens a connection to the Trillian log server.
+// The connection uses TLS verified by caCert when it is set.
+func NewTrillianClient(trillianURL string, caCert []byte) (TrillianClient, error) {
+	conn, err := dialTrillian(trillianURL, caCert)
+	if err != nil {
+		return nil, err
+	}
+	return &trillianClient{
+		conn:  conn,
+		admin: trillian.NewTrillianAdminClient(conn),
+		log:   trillian.NewTrillianLogClient(conn),
+		quota: quotapb.NewQuotaClient(conn),
+	}, nil
+}
+
+type trillianClient struct {
+	conn  *grpc.ClientConn
+	admin trillian.TrillianAdminClient
+	log   trillian.TrillianLogClient
+	quota quotapb.QuotaClient
+}
+
+func (c *trillianClient) CreateTree(ctx context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
+	return client.CreateAndInitTree(ctx, &trillian.CreateTreeRequest{Tree: tree}, c.admin, c.log)
+}
+
+func (c *trillianClient) GetTree(ctx context.Context, treeID int64) (*trillian.Tree, error) {
+	return c.admin.GetTree(ctx, &trillian.GetTreeRequest{TreeId: treeID})
+}
+
+func (c *trillianClient) ListTrees(ctx context.Context) ([]*trillian.Tree, error) {
+	resp, err := c.admin.ListTrees(ctx, &trillian.ListTreesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTree(), nil
+}
+
+func (c *trillianClient) UpdateTree(ctx context.Context, tree *trillian.Tree, paths ...string) (*trillian.Tree, error) {
+	return c.admin.UpdateTree(ctx, &trillian.UpdateTreeRequest{Tree: tree, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+}
+
+func (c *trillianClient) DeleteTree(ctx context.Context, treeID int64) error {
+	_, err := c.admin.DeleteTree(ctx, &trillian.DeleteTreeRequest{TreeId: treeID})
+	return err
+}
+
+func (c *trillianClient) TreeSize(ctx context.Context, treeID int64) (uint64, error) {
+	resp, err := c.log.GetLatestSignedLogRoot(ctx, &trillian.GetLatestSignedLogRootRequest{LogId: treeID})
+	if err != nil {
+		return 0, err
+	}
+	var root types.LogRootV1
+	if err = root.UnmarshalBinary(resp.GetSignedLogRoot().GetLogRoot()); err != nil {
+		return 0, fmt.Errorf("could not parse log root: %w", err)
+	}
+	return root.TreeSize, nil
+}
+
+func (c *trillianClient) GetQuota(ctx context.Context, name string) (*quotapb.Config, error) {
+	return c.quota.GetConfig(ctx, &quotapb.GetConfigRequest{Name: name})
+}
+
+func (c *trillianClient) CreateQuota(ctx context.Context, config *quotapb.Config) (*quotapb.Config, error) {
+	return c.quota.CreateConfig(ctx, &quotapb.CreateConfigRequest{Name: config.Name, Config: config})
+}
+
+func (c *trillianClient) UpdateQuota(ctx context.Context, config *quotapb.Config, paths ...string) (*quotapb.Config, error) {
+	return c.quota.UpdateConfig(ctx, &quotapb.UpdateConfigRequest{Name: config.Name, Config: config, UpdateMask: &fieldmaskpb.FieldMask{Paths: paths}})
+}
+
+func (c *trillianClient) Close() error {
+	return c.conn.Close()
+}
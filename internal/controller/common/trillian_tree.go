@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/trillian"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	treeDescriptionPrefix = "rhtas-operator"
+	// maxTreeDescriptionLength is the length of the tree description accepted by Trillian
+	maxTreeDescriptionLength = 200
+)
+
+// ErrAmbiguousTree is returned when more than one Trillian tree is tagged for the same resource
+var ErrAmbiguousTree = errors.New("more than one Trillian tree is tagged for the resource")
+
+// TreeOwner identifies the resource a Trillian tree is created for
+type TreeOwner struct {
+	Kind      string
+	Namespace string
+	Name      string
+	UID       types.UID
+}
+
+func NewTreeOwner(kind string, obj client.Object) TreeOwner {
+	return TreeOwner{
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       obj.GetUID(),
+	}
+}
+
+// Description returns the deterministic description that tags trees created for the owner
+func (o TreeOwner) Description() string {
+	return fmt.Sprintf("%s %s", o.key(), o.UID)
+}
+
+// key identifies the owner independently of its UID, which changes when the resource is recreated.
+// The namespace and name are replaced by their hash when the description would exceed the Trillian limit.
+func (o TreeOwner) key() string {
+	key := fmt.Sprintf("%s:%s/%s/%s", treeDescriptionPrefix, o.Kind, o.Namespace, o.Name)
+	if len(key)+1+len(o.UID) <= maxTreeDescriptionLength {
+		return key
+	}
+	return fmt.Sprintf("%s:%s/%x", treeDescriptionPrefix, o.Kind, sha256.Sum256([]byte(o.Namespace+"/"+o.Name)))
+}
+
+// FindTree returns the tree tagged for the owner or nil when there is none.
+// A tree tagged with the owner UID takes precedence over trees of a previous
+// incarnation of the resource. ErrAmbiguousTree is returned when the match is not unique.
+func FindTree(ctx context.Context, c TrillianClient, owner TreeOwner) (*trillian.Tree, error) {
+	trees, err := c.ListTrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list Trillian trees: %w", err)
+	}
+
+	var tagged, exact []*trillian.Tree
+	for _, tree := range trees {
+		if tree.Deleted || !strings.HasPrefix(tree.Description, owner.key()+" ") {
+			continue
+		}
+		tagged = append(tagged, tree)
+		if tree.Description == owner.Description() {
+			exact = append(exact, tree)
+		}
+	}
+
+	candidates := tagged
+	if len(exact) > 0 {
+		candidates = exact
+	}
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return candidates[0], nil
+	default:
+		ids := make([]string, len(candidates))
+		for i, tree := range candidates {
+			ids[i] = fmt.Sprint(tree.TreeId)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrAmbiguousTree, strings.Join(ids, ", "))
+	}
+}
+
+// EnsureTree adopts the tree tagged for the owner or creates a new one from the template.
+// It returns true when a new tree was created.
+func EnsureTree(ctx context.Context, c TrillianClient, owner TreeOwner, template *trillian.Tree) (*trillian.Tree, bool, error) {
+	tree, err := FindTree(ctx, c, owner)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if tree == nil {
+		template.Description = owner.Description()
+		tree, err = c.CreateTree(ctx, template)
+		if err != nil {
+			return nil, false, err
+		}
+		return tree, true, nil
+	}
+
+	log.FromContext(ctx).Info("adopting existing Trillian tree", "treeID", tree.TreeId, "description", tree.Description)
+	if tree.Description != owner.Description() {
+		// re-tag the tree for the current incarnation of the resource
+		tree.Description = owner.Description()
+		if tree, err = c.UpdateTree(ctx, tree, "description"); err != nil {
+			return nil, false, fmt.Errorf("could not update description of Trillian tree: %w", err)
+		}
+	}
+	return tree, false, nil
+}
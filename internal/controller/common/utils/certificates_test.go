@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCreateServerCertificate(t *testing.T) {
+	g := NewWithT(t)
+
+	caCert, caKey, err := CreateCACertificate("test-ca", 24*time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cert, key, err := CreateServerCertificate(caCert, caKey, []string{"service", "service.ns.svc"}, 48*time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(key).ToNot(BeEmpty())
+
+	ca, err := ParseCertificate(caCert)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ca.IsCA).To(BeTrue())
+
+	server, err := ParseCertificate(cert)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server.DNSNames).To(ConsistOf("service", "service.ns.svc"))
+	// certificate must not outlive its issuer
+	g.Expect(server.NotAfter).To(Equal(ca.NotAfter))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	_, err = server.Verify(x509.VerifyOptions{DNSName: "service.ns.svc", Roots: pool})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, _, err = CreateServerCertificate(caCert, caKey, []string{}, time.Hour)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCertificateNeedsRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cert, _, err := CreateCACertificate("test-ca", 30*time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rotate, err := CertificateNeedsRotation(cert, time.Now())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotate).To(BeFalse())
+
+	rotate, err = CertificateNeedsRotation(cert, time.Now().Add(25*time.Hour))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotate).To(BeTrue())
+
+	_, err = CertificateNeedsRotation([]byte("invalid"), time.Now())
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,52 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PodNameEnvVar and PodNamespaceEnvVar identify the operator pod, they are set by the downward API
+	PodNameEnvVar      = "POD_NAME"
+	PodNamespaceEnvVar = "POD_NAMESPACE"
+
+	defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+)
+
+// OperatorImage returns the image of the running operator container.
+// The operator pod is identified by the downward API environment variables, the container
+// is the default container of the pod.
+func OperatorImage(ctx context.Context, c client.Reader) (string, error) {
+	name, namespace := os.Getenv(PodNameEnvVar), os.Getenv(PodNamespaceEnvVar)
+	if name == "" || namespace == "" {
+		return "", fmt.Errorf("%s and %s environment variables are not set", PodNameEnvVar, PodNamespaceEnvVar)
+	}
+
+	pod := &corev1.Pod{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+		return "", fmt.Errorf("could not get operator pod: %w", err)
+	}
+	return podImage(pod)
+}
+
+// podImage returns the image of the default container of the pod
+func podImage(pod *corev1.Pod) (string, error) {
+	if len(pod.Spec.Containers) == 0 {
+		return "", errors.New("operator pod has no containers")
+	}
+	if name, ok := pod.Annotations[defaultContainerAnnotation]; ok {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == name {
+				return container.Image, nil
+			}
+		}
+		return "", fmt.Errorf("default container %s not found in operator pod", name)
+	}
+	return pod.Spec.Containers[0].Image, nil
+}
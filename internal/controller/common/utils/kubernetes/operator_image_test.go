@@ -0,0 +1,40 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOperatorImage(t *testing.T) {
+	g := NewWithT(t)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "operator",
+			Namespace:   "operator-namespace",
+			Annotations: map[string]string{defaultContainerAnnotation: "manager"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "kube-rbac-proxy", Image: "proxy"},
+				{Name: "manager", Image: "operator@sha256:digest"},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(pod).Build()
+
+	_, err := OperatorImage(context.TODO(), c)
+	g.Expect(err).To(MatchError(ContainSubstring(PodNameEnvVar)))
+
+	t.Setenv(PodNameEnvVar, "operator")
+	t.Setenv(PodNamespaceEnvVar, "operator-namespace")
+	g.Expect(OperatorImage(context.TODO(), c)).To(Equal("operator@sha256:digest"))
+
+	// the first container is used without the default container annotation
+	pod.Annotations = nil
+	g.Expect(podImage(pod)).To(Equal("proxy"))
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/securesign/operator/internal/controller/annotations"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// resourceNameHashLength is the length of the instance name hash keeping the shortened resource names unique
+const resourceNameHashLength = 8
+
+// ResourceName returns the name of the resource generated for the instance, the base name prefixed with the instance name.
+// The resources generated for instances with the legacy names annotation keep the base name.
+//
+// The name is a valid DNS-1035 label so that it can name a Service. When the instance name doesn't fit,
+// it is shortened and followed by a hash of the full instance name.
+func ResourceName(instance metav1.Object, base string) string {
+	if IsLegacyNames(instance) {
+		return base
+	}
+	name := instance.GetName() + "-" + base
+	if len(validation.IsDNS1035Label(name)) == 0 {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(instance.GetName())))[:resourceNameHashLength]
+	prefix := strings.ReplaceAll(instance.GetName(), ".", "-")
+	if prefix[0] < 'a' || prefix[0] > 'z' {
+		prefix = "x" + prefix
+	}
+	if maxLength := validation.DNS1035LabelMaxLength - len(hash) - len(base) - 2; len(prefix) > maxLength {
+		prefix = strings.TrimRight(prefix[:maxLength], "-")
+	}
+	return prefix + "-" + hash + "-" + base
+}
+
+// IsLegacyNames returns true when the resources generated for the instance keep their fixed names
+func IsLegacyNames(instance metav1.Object) bool {
+	legacy, _ := strconv.ParseBool(instance.GetAnnotations()[annotations.LegacyNames])
+	return legacy
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		name        string
+		instance    string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name: "derived from the instance name",
+			want: "instance-rekor-server",
+		},
+		{
+			name:     "long instance name",
+			instance: strings.Repeat("a", 60),
+			want:     strings.Repeat("a", 41) + "-11ee3912-rekor-server",
+		},
+		{
+			name:     "instance name with dots",
+			instance: "my.instance",
+			want:     "my-instance-1731c5ea-rekor-server",
+		},
+		{
+			name:     "instance name starting with a digit",
+			instance: "1instance",
+			want:     "x1instance-55b8f080-rekor-server",
+		},
+		{
+			name:        "legacy names",
+			annotations: map[string]string{annotations.LegacyNames: "true"},
+			want:        "rekor-server",
+		},
+		{
+			name:        "legacy names disabled",
+			annotations: map[string]string{annotations.LegacyNames: "false"},
+			want:        "instance-rekor-server",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			name := tt.instance
+			if name == "" {
+				name = "instance"
+			}
+			instance := &v1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: tt.annotations}}
+			got := ResourceName(instance, "rekor-server")
+			g.Expect(got).To(Equal(tt.want))
+			g.Expect(validation.IsDNS1035Label(got)).To(BeEmpty())
+		})
+	}
+}
@@ -2,5 +2,7 @@ package constants
 
 var (
 	CreateTreeDeadline int64 = 1200
-	Openshift          bool
+	// CreateTreeInJob enables creation of Trillian trees in a Job running in the target namespace
+	CreateTreeInJob bool
+	Openshift       bool
 )
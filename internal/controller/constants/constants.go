@@ -9,4 +9,9 @@ const (
 	Creating   = "Creating"
 	Initialize = "Initialize"
 	Failure    = "Failure"
+
+	// TreeCondition reports resolution of the Trillian tree
+	TreeCondition = "TreeResolved"
+	// TreeAmbiguous reason is used when more than one Trillian tree is tagged for the resource
+	TreeAmbiguous = "Ambiguous"
 )
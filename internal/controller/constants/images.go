@@ -1,9 +1,21 @@
 package constants
 
 var (
+	// The Trillian images are built from Trillian v1.7.1 or later, the operator depends on the PostgreSQL storage and
+	// on the --mysql_tls_ca and --mysql_server_name flags.
 	TrillianLogSignerImage = "registry.redhat.io/rhtas/trillian-logsigner-rhel9@sha256:37028258a88bba4dfaadb59fc88b6efe9c119a808e212ad5214d65072abb29d0"
 	TrillianServerImage    = "registry.redhat.io/rhtas/trillian-logserver-rhel9@sha256:994a860e569f2200211b01f9919de11d14b86c669230184c4997f3d875c79208"
 	TrillianDbImage        = "registry.redhat.io/rhtas/trillian-database-rhel9@sha256:909f584804245f8a9e05ecc4d6874c26d56c0d742ba793c1a4357a14f5e67eb0"
+	// TrillianPostgresqlImage is used by the managed PostgreSQL database and to initialize the PostgreSQL schema.
+	// TODO: pin by digest with `make pin-images`, until then it can be overridden with TRILLIAN_POSTGRESQL_IMAGE
+	TrillianPostgresqlImage = "registry.redhat.io/rhel9/postgresql-16:latest"
+	// TrillianBackupS3Image transfers the database backups from and to an S3-compatible bucket.
+	// TODO: replace with a Red Hat build, pin by digest with `make pin-images`, until then it can be overridden
+	// with TRILLIAN_BACKUP_S3_IMAGE
+	TrillianBackupS3Image = "public.ecr.aws/aws-cli/aws-cli:2.17.50"
+	// TrillianEtcdImage is used by the managed etcd backing the Logsigner master election.
+	// TODO: pin by digest with `make pin-images`, until then it can be overridden with TRILLIAN_ETCD_IMAGE
+	TrillianEtcdImage = "quay.io/coreos/etcd:v3.5.15"
 
 	// TODO: remove and check the DB pod status
 	TrillianNetcatImage = "registry.redhat.io/openshift4/ose-tools-rhel8@sha256:486b4d2dd0d10c5ef0212714c94334e04fe8a3d36cf619881986201a50f123c7"
@@ -16,6 +28,8 @@ var (
 	BackfillRedisImage = "registry.redhat.io/rhtas/rekor-backfill-redis-rhel9@sha256:88869eb582cbb94baa50c212689c50ed405cc94669c2c03f781b12ad867827ce"
 
 	TufImage = "registry.redhat.io/rhtas/tuf-server-rhel9@sha256:092ee1327639c2c8fee809ea66ecd11ca7bc9951c1832391df0df6f1f4d62a6a"
+	// TufRepositoryImage serves the TUF repository generated by the operator
+	TufRepositoryImage = "registry.access.redhat.com/ubi9/httpd-24@sha256:7874b82335a80269dcf99e5983c2330876f5fe8bdc33dc6aa4374958a2ffaaee"
 
 	CTLogImage = "registry.redhat.io/rhtas/certificate-transparency-rhel9@sha256:a0c7d71fc8f4cb7530169a6b54dc3a67215c4058a45f84b87bb04fc62e6e8141"
 
@@ -23,4 +37,8 @@ var (
 	ClientServerImage_cg = "registry.redhat.io/rhtas/client-server-cg-rhel9@sha256:987c630213065a6339b2b2582138f7b921473b86dfe82e91a002f08386a899ed"
 	ClientServerImage_re = "registry.redhat.io/rhtas/client-server-re-rhel9@sha256:dc4667af49ce6cc70d70bf83cab9d7a14b424d8ae1aae7e4863ff5c4ac769a96"
 	SegmentBackupImage   = "registry.redhat.io/rhtas/segment-reporting-rhel9@sha256:3fcf8f14a0cfdd36f9ec263f83ba1597f892e6fa923d3d61bacbc467af643c9d"
+
+	// CreateTreeImage runs the create-tree command of the manager in a Job.
+	// It is resolved to the image of the running operator when not set.
+	CreateTreeImage = ""
 )
@@ -0,0 +1,75 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	cutils "github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewCleanupServerConfigAction() action.Action[*rhtasv1alpha1.CTlog] {
+	return &cleanupServerConfig{}
+}
+
+// cleanupServerConfig removes the server configs generated by the operator that are not used anymore.
+// They are removed only once the deployment runs with the current config.
+type cleanupServerConfig struct {
+	action.BaseAction
+}
+
+func (i cleanupServerConfig) Name() string {
+	return "cleanup server config"
+}
+
+func (i cleanupServerConfig) CanHandle(_ context.Context, instance *rhtasv1alpha1.CTlog) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c != nil && c.Reason == constants.Ready && instance.Status.ServerConfigRef != nil
+}
+
+func (i cleanupServerConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog) *action.Result {
+	deployment := &appsv1.Deployment{}
+	err := i.Client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: cutils.ResourceName(instance, DeploymentName)}, deployment)
+	if client.IgnoreNotFound(err) != nil {
+		return i.Failed(err)
+	}
+	if err != nil || !k8sutils.DeploymentIsRolledOut(deployment) {
+		// the previous config may still be mounted, the deployment changes trigger the next attempt
+		return i.Continue()
+	}
+
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := i.Client.List(ctx, list, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(constants.LabelsFor(ComponentName, DeploymentName, instance.Name))); err != nil {
+		return i.Failed(fmt.Errorf("could not list server configs: %w", err))
+	}
+
+	for _, secret := range list.Items {
+		switch {
+		case secret.Name == instance.Status.ServerConfigRef.Name:
+			continue
+		case !strings.HasPrefix(secret.Name, serverConfigPrefix(instance)):
+			continue
+		case !metav1.IsControlledBy(&secret, instance):
+			// never remove the secrets the operator didn't create
+			continue
+		}
+		stale := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secret.Namespace, Name: secret.Name}}
+		if err := i.Client.Delete(ctx, stale, client.Preconditions{UID: &secret.UID}); client.IgnoreNotFound(err) != nil {
+			return i.Failed(fmt.Errorf("could not remove server config %s: %w", secret.Name, err))
+		}
+		i.Logger.Info("removed unused server config", "secret", secret.Name)
+	}
+	return i.Continue()
+}
@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestCleanupServerConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		rolledOut bool
+		removed   []string
+	}{
+		{
+			name:      "deployment is rolled out",
+			rolledOut: true,
+			removed:   []string{"ctlog-config-ctlogold"},
+		},
+		{
+			name:      "deployment is rolling out",
+			rolledOut: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+			instance := &rhtasv1alpha1.CTlog{
+				ObjectMeta: metav1.ObjectMeta{Name: "ctlog", Namespace: "default", UID: "uid"},
+				Status: rhtasv1alpha1.CTlogStatus{
+					ServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "ctlog-config-ctlognew"},
+				},
+			}
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+				Type:   constants.Ready,
+				Reason: constants.Ready,
+			})
+
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "ctlog-ctlog", Namespace: "default", Generation: 2},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 1},
+			}
+			if tt.rolledOut {
+				deployment.Status.Replicas = 1
+			}
+
+			labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
+			secret := func(name string, owned bool) client.Object {
+				s := kubernetes.CreateSecret(name, "default", map[string][]byte{}, labels)
+				if owned {
+					g.Expect(controllerutil.SetControllerReference(instance, s, testAction.FakeClientBuilder().Build().Scheme())).To(Succeed())
+				}
+				return s
+			}
+
+			c := testAction.FakeClientBuilder().
+				WithObjects(instance, deployment).
+				WithObjects(
+					secret("ctlog-config-ctlognew", true),
+					secret("ctlog-config-ctlogold", true),
+					// created by the user
+					secret("ctlog-config-ctloguser", false),
+					// not a server config
+					secret("ctlog-keys", true),
+				).
+				Build()
+
+			a := testAction.PrepareAction(c, NewCleanupServerConfigAction())
+			g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+			g.Expect(a.Handle(ctx, instance)).To(BeNil())
+
+			for _, name := range []string{"ctlog-config-ctlognew", "ctlog-config-ctlogold", "ctlog-config-ctloguser", "ctlog-keys"} {
+				err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &v1.Secret{})
+				if slices.Contains(tt.removed, name) {
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), name)
+				} else {
+					g.Expect(err).ToNot(HaveOccurred(), name)
+				}
+			}
+		})
+	}
+}
@@ -4,13 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	cutils "github.com/securesign/operator/internal/controller/common/utils"
-
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	cutils "github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/ctlog/utils"
-	trillian "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -41,12 +40,26 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 
 	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
 
-	switch {
-	case instance.Spec.Trillian.Address == "":
-		instance.Spec.Trillian.Address = fmt.Sprintf("%s.%s.svc", trillian.LogserverDeploymentName, instance.Namespace)
+	caCertRef, err := trillianUtils.ResolveCACert(ctx, i.Client, instance, instance.Spec.Trillian)
+	if err != nil {
+		// never fall back to a plain connection to a Trillian serving TLS
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not resolve Trillian CA certificate: %w", err), instance)
+	}
+	instance.Spec.Trillian.CACertRef = caCertRef
+
+	if instance.Spec.Trillian.Address == "" {
+		if instance.Spec.Trillian.Address, err = trillianUtils.LogserverAddress(ctx, i.Client, instance); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian address: %w", err))
+		}
 	}
 
-	dp, err := utils.CreateDeployment(instance, DeploymentName, RBACName, labels, ServerTargetPort, MetricsPort)
+	dp, err := utils.CreateDeployment(instance, cutils.ResourceName(instance, DeploymentName), cutils.ResourceName(instance, RBACName), labels, ServerTargetPort, MetricsPort)
 	if err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    constants.Ready,
@@ -44,7 +44,7 @@ func (g handleFulcioCert) CanHandle(ctx context.Context, instance *v1alpha1.CTlo
 
 	if len(instance.Spec.RootCertificates) == 0 {
 		// test if autodiscovery find new secret
-		if scr, _ := k8sutils.FindSecret(ctx, g.Client, instance.Namespace, actions.FulcioCALabel); scr != nil {
+		if scr, _ := k8sutils.FindSecret(ctx, g.Client, rootCertificatesNamespace(instance), actions.FulcioCALabel); scr != nil {
 			return !slices.Contains(instance.Status.RootCertificates, v1alpha1.SecretKeySelector{
 				LocalObjectReference: v1alpha1.LocalObjectReference{Name: scr.Name},
 				Key:                  scr.Labels[actions.FulcioCALabel],
@@ -68,7 +68,7 @@ func (g handleFulcioCert) Handle(ctx context.Context, instance *v1alpha1.CTlog)
 	}
 
 	if len(instance.Spec.RootCertificates) == 0 {
-		scr, err := k8sutils.FindSecret(ctx, g.Client, instance.Namespace, actions.FulcioCALabel)
+		scr, err := k8sutils.FindSecret(ctx, g.Client, rootCertificatesNamespace(instance), actions.FulcioCALabel)
 		if err != nil {
 			if !k8sErrors.IsNotFound(err) {
 				return g.Failed(err)
@@ -118,3 +118,11 @@ func (g handleFulcioCert) Handle(ctx context.Context, instance *v1alpha1.CTlog)
 	)
 	return g.StatusUpdate(ctx, instance)
 }
+
+// rootCertificatesNamespace returns the namespace the root certificates are read from and the Fulcio CA is discovered in
+func rootCertificatesNamespace(instance *v1alpha1.CTlog) string {
+	if instance.Spec.RootCertificatesNamespace != "" {
+		return instance.Spec.RootCertificatesNamespace
+	}
+	return instance.Namespace
+}
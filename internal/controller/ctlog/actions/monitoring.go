@@ -7,6 +7,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	v1 "k8s.io/api/rbac/v1"
@@ -41,7 +42,7 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CT
 
 	role := kubernetes.CreateRole(
 		instance.Namespace,
-		MonitoringRoleName,
+		utils.ResourceName(instance, MonitoringRoleName),
 		monitoringLabels,
 		[]v1.PolicyRule{
 			{
@@ -68,12 +69,12 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CT
 
 	roleBinding := kubernetes.CreateRoleBinding(
 		instance.Namespace,
-		MonitoringRoleName,
+		utils.ResourceName(instance, MonitoringRoleName),
 		monitoringLabels,
 		v1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
 			Kind:     "Role",
-			Name:     MonitoringRoleName,
+			Name:     utils.ResourceName(instance, MonitoringRoleName),
 		},
 		[]v1.Subject{
 			{Kind: "ServiceAccount", Name: "prometheus-k8s", Namespace: "openshift-monitoring"},
@@ -95,7 +96,7 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CT
 
 	serviceMonitor := kubernetes.CreateServiceMonitor(
 		instance.Namespace,
-		DeploymentName,
+		utils.ResourceName(instance, DeploymentName),
 		monitoringLabels,
 		[]monitoringv1.Endpoint{
 			{
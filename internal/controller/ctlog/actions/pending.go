@@ -2,12 +2,13 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
 	utils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
-	trillian "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -30,7 +31,11 @@ func (i pendingAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.CTlo
 
 func (i pendingAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog) *action.Result {
 	var err error
-	_, err = utils.GetInternalUrl(ctx, i.Client, instance.Namespace, trillian.LogserverDeploymentName)
+	service, err := trillianUtils.LogserverService(ctx, i.Client, instance)
+	if err != nil {
+		return i.Failed(fmt.Errorf("could not resolve Trillian service: %w", err))
+	}
+	_, err = utils.GetInternalUrl(ctx, i.Client, instance.Namespace, service)
 	if err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    constants.Ready,
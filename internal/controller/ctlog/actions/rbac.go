@@ -6,6 +6,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	v1 "k8s.io/api/core/v1"
@@ -40,7 +41,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog) *
 
 	sa := &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      RBACName,
+			Name:      utils.ResourceName(instance, RBACName),
 			Namespace: instance.Namespace,
 			Labels:    labels,
 		},
@@ -60,7 +61,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog) *
 		})
 		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create SA: %w", err), instance)
 	}
-	role := kubernetes.CreateRole(instance.Namespace, RBACName, labels, []rbacv1.PolicyRule{
+	role := kubernetes.CreateRole(instance.Namespace, utils.ResourceName(instance, RBACName), labels, []rbacv1.PolicyRule{
 		{
 			APIGroups: []string{""},
 			Resources: []string{"configmaps"},
@@ -86,13 +87,13 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog) *
 		})
 		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create Role: %w", err), instance)
 	}
-	rb := kubernetes.CreateRoleBinding(instance.Namespace, RBACName, labels, rbacv1.RoleRef{
+	rb := kubernetes.CreateRoleBinding(instance.Namespace, utils.ResourceName(instance, RBACName), labels, rbacv1.RoleRef{
 		APIGroup: v1.SchemeGroupVersion.Group,
 		Kind:     "Role",
-		Name:     RBACName,
+		Name:     utils.ResourceName(instance, RBACName),
 	},
 		[]rbacv1.Subject{
-			{Kind: "ServiceAccount", Name: RBACName, Namespace: instance.Namespace},
+			{Kind: "ServiceAccount", Name: utils.ResourceName(instance, RBACName), Namespace: instance.Namespace},
 		})
 
 	if err = ctrl.SetControllerReference(instance, rb, i.Client.Scheme()); err != nil {
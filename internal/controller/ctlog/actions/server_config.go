@@ -3,13 +3,14 @@ package actions
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
 	utils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	ctlogUtils "github.com/securesign/operator/internal/controller/ctlog/utils"
-	trillian "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -41,8 +42,11 @@ func (i serverConfig) CanHandle(_ context.Context, instance *rhtasv1alpha1.CTlog
 		return true
 	case instance.Spec.ServerConfigRef != nil:
 		return !equality.Semantic.DeepEqual(instance.Spec.ServerConfigRef, instance.Status.ServerConfigRef)
+	case !isGeneratedServerConfig(instance, instance.Status.ServerConfigRef):
+		// spec.serverConfigRef was cleared, the config is generated again
+		return true
 	default:
-		return false
+		return !equality.Semantic.DeepEqual(instance.Spec.Admission, instance.Status.Admission)
 	}
 }
 
@@ -67,7 +71,11 @@ func (i serverConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 	case instance.Spec.Trillian.Port == nil:
 		return i.Failed(fmt.Errorf("%s: %v", i.Name(), ctlogUtils.TrillianPortNotSpecified))
 	case instance.Spec.Trillian.Address == "":
-		instance.Spec.Trillian.Address = fmt.Sprintf("%s.%s.svc", trillian.LogserverDeploymentName, instance.Namespace)
+		address, err := trillianUtils.LogserverAddress(ctx, i.Client, instance)
+		if err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian address: %w", err))
+		}
+		instance.Spec.Trillian.Address = address
 	}
 
 	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
@@ -99,7 +107,7 @@ func (i serverConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 	}
 
 	var cfg map[string][]byte
-	if cfg, err = ctlogUtils.CreateCtlogConfig(fmt.Sprintf("%s:%d", trillianService.Address, *trillianService.Port), *instance.Status.TreeID, rootCerts, certConfig); err != nil {
+	if cfg, err = ctlogUtils.CreateCtlogConfig(fmt.Sprintf("%s:%d", trillianService.Address, *trillianService.Port), *instance.Status.TreeID, rootCerts, certConfig, instance.Spec.Admission); err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    constants.Ready,
 			Status:  metav1.ConditionFalse,
@@ -109,7 +117,7 @@ func (i serverConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create CTLog configuration: %w", err), instance)
 	}
 
-	newConfig := utils.CreateImmutableSecret(fmt.Sprintf("ctlog-config-%s", instance.Name), instance.Namespace, cfg, labels)
+	newConfig := utils.CreateImmutableSecret(serverConfigPrefix(instance), instance.Namespace, cfg, labels)
 
 	if err = controllerutil.SetControllerReference(instance, newConfig, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for Secret: %w", err))
@@ -126,7 +134,9 @@ func (i serverConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 		return i.FailedWithStatusUpdate(ctx, err, instance)
 	}
 
+	// the previous config is removed by the cleanup action once the deployment uses the new one
 	instance.Status.ServerConfigRef = &rhtasv1alpha1.LocalObjectReference{Name: newConfig.Name}
+	instance.Status.Admission = instance.Spec.Admission.DeepCopy()
 
 	i.Recorder.Event(instance, corev1.EventTypeNormal, "CTLogConfigUpdated", "CTLog config updated")
 	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{Type: constants.Ready,
@@ -134,6 +144,16 @@ func (i serverConfig) Handle(ctx context.Context, instance *rhtasv1alpha1.CTlog)
 	return i.StatusUpdate(ctx, instance)
 }
 
+// serverConfigPrefix returns the name prefix of the server config secrets generated for the instance
+func serverConfigPrefix(instance *rhtasv1alpha1.CTlog) string {
+	return fmt.Sprintf("ctlog-config-%s", instance.Name)
+}
+
+// isGeneratedServerConfig returns true when the reference points to a server config generated by the operator
+func isGeneratedServerConfig(instance *rhtasv1alpha1.CTlog, ref *rhtasv1alpha1.LocalObjectReference) bool {
+	return ref != nil && strings.HasPrefix(ref.Name, serverConfigPrefix(instance))
+}
+
 func (i serverConfig) handlePrivateKey(instance *rhtasv1alpha1.CTlog) (*ctlogUtils.PrivateKeyConfig, error) {
 	if instance == nil {
 		return nil, nil
@@ -162,7 +182,7 @@ func (i serverConfig) handleRootCertificates(instance *rhtasv1alpha1.CTlog) ([]c
 	certs := make([]ctlogUtils.RootCertificate, 0)
 
 	for _, selector := range instance.Status.RootCertificates {
-		data, err := utils.GetSecretData(i.Client, instance.Namespace, &selector)
+		data, err := utils.GetSecretData(i.Client, rootCertificatesNamespace(instance), &selector)
 		if err != nil {
 			return nil, fmt.Errorf("%s/%s: %w", selector.Name, selector.Key, err)
 		}
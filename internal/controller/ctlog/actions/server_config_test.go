@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/onsi/gomega/gstruct"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
@@ -16,7 +17,9 @@ import (
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
 	"github.com/securesign/operator/internal/controller/constants"
+	ctlogUtils "github.com/securesign/operator/internal/controller/ctlog/utils"
 	testAction "github.com/securesign/operator/internal/testing/action"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -37,6 +40,8 @@ func TestServerConfig_CanHandle(t *testing.T) {
 		canHandle             bool
 		serverConfigRef       *rhtasv1alpha1.LocalObjectReference
 		statusServerConfigRef *rhtasv1alpha1.LocalObjectReference
+		admission             *rhtasv1alpha1.CTlogAdmission
+		statusAdmission       *rhtasv1alpha1.CTlogAdmission
 	}{
 		{
 			name:                  "spec.serverConfigRef is not nil and status.serverConfigRef is nil",
@@ -46,10 +51,17 @@ func TestServerConfig_CanHandle(t *testing.T) {
 			statusServerConfigRef: nil,
 		},
 		{
-			name:                  "spec.serverConfigRef is nil and status.serverConfigRef is not nil",
+			name:                  "spec.serverConfigRef is nil and status.serverConfigRef is generated",
 			phase:                 constants.Creating,
 			canHandle:             false,
 			serverConfigRef:       nil,
+			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "ctlog-config-abcde"},
+		},
+		{
+			name:                  "spec.serverConfigRef was cleared",
+			phase:                 constants.Ready,
+			canHandle:             true,
+			serverConfigRef:       nil,
 			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "config"},
 		},
 		{
@@ -73,6 +85,29 @@ func TestServerConfig_CanHandle(t *testing.T) {
 			serverConfigRef:       &rhtasv1alpha1.LocalObjectReference{Name: "config"},
 			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "config"},
 		},
+		{
+			name:                  "spec.admission != status.admission",
+			phase:                 constants.Ready,
+			canHandle:             true,
+			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "ctlog-config-abcde"},
+			admission:             &rhtasv1alpha1.CTlogAdmission{RejectExpired: true},
+		},
+		{
+			name:                  "spec.admission == status.admission",
+			phase:                 constants.Ready,
+			canHandle:             false,
+			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "ctlog-config-abcde"},
+			admission:             &rhtasv1alpha1.CTlogAdmission{RejectExpired: true},
+			statusAdmission:       &rhtasv1alpha1.CTlogAdmission{RejectExpired: true},
+		},
+		{
+			name:                  "spec.admission is ignored with spec.serverConfigRef",
+			phase:                 constants.Ready,
+			canHandle:             false,
+			serverConfigRef:       &rhtasv1alpha1.LocalObjectReference{Name: "config"},
+			statusServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "config"},
+			admission:             &rhtasv1alpha1.CTlogAdmission{RejectExpired: true},
+		},
 		{
 			name:      "no phase condition",
 			phase:     "",
@@ -111,9 +146,11 @@ func TestServerConfig_CanHandle(t *testing.T) {
 			instance := rhtasv1alpha1.CTlog{
 				Spec: rhtasv1alpha1.CTlogSpec{
 					ServerConfigRef: tt.serverConfigRef,
+					Admission:       tt.admission,
 				},
 				Status: rhtasv1alpha1.CTlogStatus{
 					ServerConfigRef: tt.statusServerConfigRef,
+					Admission:       tt.statusAdmission,
 				},
 			}
 			if tt.phase != "" {
@@ -318,3 +355,108 @@ func TestServerConfig_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestServerConfig_Admission(t *testing.T) {
+	tests := []struct {
+		name      string
+		admission *rhtasv1alpha1.CTlogAdmission
+		verify    func(Gomega, *action.Result, *rhtasv1alpha1.CTlog, client.Client)
+	}{
+		{
+			name: "rotate config",
+			admission: &rhtasv1alpha1.CTlogAdmission{
+				RejectExpired:    true,
+				ExtKeyUsages:     []rhtasv1alpha1.ExtKeyUsage{"CodeSigning", "ClientAuth"},
+				NotAfterStart:    &metav1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				NotAfterLimit:    &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				RejectExtensions: []string{"1.3.6.1.4.1.11129.2.4.3"},
+			},
+			verify: func(g Gomega, result *action.Result, instance *rhtasv1alpha1.CTlog, c client.Client) {
+				g.Expect(result).To(Equal(testAction.StatusUpdate()))
+				g.Expect(instance.Status.ServerConfigRef.Name).ShouldNot(Equal("old-config"))
+				g.Expect(instance.Status.Admission).To(Equal(instance.Spec.Admission))
+
+				// the previous config is still used by the deployment
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Name: "old-config", Namespace: "default"}, &v1.Secret{})).To(Succeed())
+
+				secret := &v1.Secret{}
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Name: instance.Status.ServerConfigRef.Name, Namespace: "default"}, secret)).To(Succeed())
+				g.Expect(string(secret.Data[ctlogUtils.ConfigKey])).To(And(
+					ContainSubstring("reject_expired:true"),
+					ContainSubstring(`ext_key_usages:"ClientAuth"`),
+					ContainSubstring("not_after_limit:"),
+					ContainSubstring(`reject_extensions:"1.3.6.1.4.1.11129.2.4.3"`),
+				))
+			},
+		},
+		{
+			name: "reject invalid config",
+			admission: &rhtasv1alpha1.CTlogAdmission{
+				RejectExpired:   true,
+				RejectUnexpired: true,
+			},
+			verify: func(g Gomega, result *action.Result, instance *rhtasv1alpha1.CTlog, c client.Client) {
+				g.Expect(testAction.IsFailed(result)).To(BeTrue())
+				g.Expect(result.Err).To(MatchError(ContainSubstring("rejecting all certificates")))
+				g.Expect(instance.Status.ServerConfigRef.Name).Should(Equal("old-config"))
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Name: "old-config", Namespace: "default"}, &v1.Secret{})).To(Succeed())
+			},
+		},
+		{
+			name: "reject invalid extension",
+			admission: &rhtasv1alpha1.CTlogAdmission{
+				RejectExtensions: []string{"not-an-oid"},
+			},
+			verify: func(g Gomega, result *action.Result, instance *rhtasv1alpha1.CTlog, _ client.Client) {
+				g.Expect(testAction.IsFailed(result)).To(BeTrue())
+				g.Expect(result.Err).To(MatchError(ContainSubstring("not-an-oid")))
+				g.Expect(instance.Status.ServerConfigRef.Name).Should(Equal("old-config"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &rhtasv1alpha1.CTlog{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ctlog",
+					Namespace: "default",
+				},
+				Spec: rhtasv1alpha1.CTlogSpec{
+					Trillian:  rhtasv1alpha1.TrillianService{Port: ptr.To(int32(80))},
+					Admission: tt.admission,
+				},
+				Status: rhtasv1alpha1.CTlogStatus{
+					ServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "old-config"},
+					TreeID:          ptr.To(int64(123456)),
+					RootCertificates: []rhtasv1alpha1.SecretKeySelector{
+						{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "secret"}, Key: "cert"},
+					},
+					PrivateKeyRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "secret"}, Key: "private"},
+					PublicKeyRef:  &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "secret"}, Key: "public"},
+				},
+			}
+			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+				Type:   constants.Ready,
+				Reason: constants.Ready,
+			})
+
+			c := testAction.FakeClientBuilder().
+				WithObjects(instance).
+				WithStatusSubresource(instance).
+				WithObjects(
+					kubernetes.CreateSecret("secret", "default", map[string][]byte{
+						"cert":    cert,
+						"private": privateKey,
+						"public":  publicKey,
+					}, map[string]string{}),
+					kubernetes.CreateSecret("old-config", "default", map[string][]byte{}, map[string]string{}),
+				).
+				Build()
+
+			a := testAction.PrepareAction(c, NewServerConfigAction())
+			g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+			tt.verify(g, a.Handle(context.TODO(), instance), instance, c)
+		})
+	}
+}
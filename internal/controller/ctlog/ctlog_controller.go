@@ -26,6 +26,7 @@ import (
 
 	"github.com/securesign/operator/internal/controller/ctlog/actions"
 	fulcioActions "github.com/securesign/operator/internal/controller/fulcio/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	v12 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -55,6 +56,9 @@ type CTlogReconciler struct {
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=ctlogs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=ctlogs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=ctlogs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trilliantrees,verbs=get;list;watch
+//+kubebuilder:rbac:groups="batch",resources=jobs,verbs=create;get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,6 +92,9 @@ func (r *CTlogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	target := instance.DeepCopy()
 	acs := []action.Action[*rhtasv1alpha1.CTlog]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.CTlog](func(_ *rhtasv1alpha1.CTlog) client.Object {
+			return &v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: actions.DeploymentName}}
+		}),
 		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.CTlog](func(_ *rhtasv1alpha1.CTlog) []string {
 			return []string{actions.CertCondition}
 		}),
@@ -109,6 +116,8 @@ func (r *CTlogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		transitions.NewToInitializePhaseAction[*rhtasv1alpha1.CTlog](),
 
 		actions.NewInitializeAction(),
+		actions.NewCleanupServerConfigAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.CTlog](),
 	}
 
 	for _, a := range acs {
@@ -159,21 +168,29 @@ func (r *CTlogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&v1.Deployment{}).
 		Owns(&v12.Service{}).
 		WatchesMetadata(partialSecret, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
-			val, ok := object.GetLabels()["app.kubernetes.io/instance"]
-			if ok {
-				return []reconcile.Request{
-					{
-						NamespacedName: types.NamespacedName{
-							Namespace: object.GetNamespace(),
-							Name:      val,
-						},
-					},
+			requests := make([]reconcile.Request, 0)
+			list := &rhtasv1alpha1.CTlogList{}
+			if err := mgr.GetClient().List(ctx, list); err != nil {
+				return requests
+			}
+
+			val, labelled := object.GetLabels()["app.kubernetes.io/instance"]
+			for _, k := range list.Items {
+				switch {
+				case k.Namespace == object.GetNamespace() && (!labelled || k.Name == val):
+				case k.Spec.RootCertificatesNamespace == object.GetNamespace():
+					// the root certificates are discovered in the namespace of the secret
+				default:
+					continue
 				}
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: k.Namespace, Name: k.Name}})
 			}
+			return requests
 
+		}), builder.WithPredicates(secretPredicate)).
+		Watches(&rhtasv1alpha1.Trillian{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
 			list := &rhtasv1alpha1.CTlogList{}
-			err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace()))
-			if err != nil {
+			if err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace())); err != nil {
 				return make([]reconcile.Request, 0)
 			}
 
@@ -182,7 +199,20 @@ func (r *CTlogReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: k.Name}}
 			}
 			return requests
+		}), builder.WithPredicates(trillianUtils.TLSChangedPredicate())).
+		Watches(&rhtasv1alpha1.TrillianTree{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+			list := &rhtasv1alpha1.CTlogList{}
+			if err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace())); err != nil {
+				return make([]reconcile.Request, 0)
+			}
 
-		}), builder.WithPredicates(secretPredicate)).
+			requests := make([]reconcile.Request, 0)
+			for _, k := range list.Items {
+				if k.Spec.TreeRef != nil && k.Spec.TreeRef.Name == object.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: k.Name}})
+				}
+			}
+			return requests
+		}), builder.WithPredicates(trillianUtils.TreeIDChangedPredicate())).
 		Complete(r)
 }
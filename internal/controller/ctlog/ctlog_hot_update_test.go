@@ -123,7 +123,7 @@ var _ = Describe("CTlog update test", func() {
 			deployment := &appsv1.Deployment{}
 			By("Checking if Deployment was successfully created in the reconciliation")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 
 			By("Move to Ready phase")
@@ -160,7 +160,7 @@ var _ = Describe("CTlog update test", func() {
 			By("CTL deployment is updated")
 			Eventually(func() bool {
 				updated := &appsv1.Deployment{}
-				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 
@@ -170,7 +170,7 @@ var _ = Describe("CTlog update test", func() {
 			Expect(k8sClient.Create(ctx, kubernetes.CreateSecret("key-secret", Namespace,
 				map[string][]byte{"private": key.PrivateKey}, constants.LabelsFor(actions.ComponentName, Name, instance.Name)))).To(Succeed())
 
-			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)).To(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)).To(Succeed())
 			found := &v1alpha1.CTlog{}
 			Eventually(func(g Gomega) error {
 				g.Expect(k8sClient.Get(ctx, typeNamespaceName, found)).Should(Succeed())
@@ -193,7 +193,7 @@ var _ = Describe("CTlog update test", func() {
 			By("CTL deployment is updated")
 			Eventually(func(g Gomega) bool {
 				updated := &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 		})
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serviceName is the base name of the CTlog service, it matches the name of the CTlog deployment
+const serviceName = "ctlog"
+
+// ResolveCTlog returns the CTlog instance serving the owner when no address is configured.
+// The CTlog with the same name as the owner takes precedence, otherwise the only CTlog
+// in the namespace is used. Nil is returned when the namespace has no CTlog.
+func ResolveCTlog(ctx context.Context, c client.Client, owner metav1.Object) (*v1alpha1.CTlog, error) {
+	ctlog := &v1alpha1.CTlog{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: owner.GetName()}, ctlog)
+	switch {
+	case err == nil:
+		return ctlog, nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	list := &v1alpha1.CTlogList{}
+	if err := c.List(ctx, list, client.InNamespace(owner.GetNamespace())); err != nil {
+		return nil, err
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &list.Items[0], nil
+	default:
+		return nil, fmt.Errorf("found %d CTlog instances in namespace %s, set the CTlog address explicitly", len(list.Items), owner.GetNamespace())
+	}
+}
+
+// ServiceAddress returns the in-cluster URL of the CTlog service used by the owner when no address is configured.
+// The legacy name is used when the namespace has no CTlog.
+func ServiceAddress(ctx context.Context, c client.Client, owner metav1.Object) (string, error) {
+	ctlog, err := ResolveCTlog(ctx, c, owner)
+	if err != nil {
+		return "", err
+	}
+	service := serviceName
+	if ctlog != nil {
+		service = utils.ResourceName(ctlog, serviceName)
+	}
+	return fmt.Sprintf("http://%s.%s.svc", service, owner.GetNamespace()), nil
+}
@@ -7,13 +7,19 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/google/certificate-transparency-go/asn1"
+	"github.com/google/certificate-transparency-go/trillian/ctfe"
 	"github.com/google/certificate-transparency-go/trillian/ctfe/configpb"
 	"github.com/google/trillian/crypto/keyspb"
+	"github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // reference code https://github.com/sigstore/scaffolding/blob/main/cmd/ctlog/createctconfig/main.go
@@ -33,6 +39,9 @@ const (
 	rootsPemFileDir = "/ctfe-keys/"
 	// This file contains the private key for the CTLog
 	privateKeyFile = "/ctfe-keys/private"
+
+	// defaultExtKeyUsage is accepted by the log when admission does not specify any
+	defaultExtKeyUsage = "CodeSigning"
 )
 
 var supportedCurves = map[string]elliptic.Curve{
@@ -60,6 +69,50 @@ type Config struct {
 	// there will be a period of time when we allow both. It might also contain
 	// multiple Root Certificates, if we choose to support admitting certificates from fulcio instances run by others
 	RootCerts []RootCertificate
+
+	// Admission policy applied to the submitted certificate chains
+	RejectExpired    bool
+	RejectUnexpired  bool
+	ExtKeyUsages     []string
+	NotAfterStart    *timestamppb.Timestamp
+	NotAfterLimit    *timestamppb.Timestamp
+	AcceptOnlyCA     bool
+	RejectExtensions []string
+}
+
+// SetAdmission applies the admission policy to the config.
+// If the policy does not specify any extended key usage, CodeSigning is used.
+func (c *Config) SetAdmission(admission *v1alpha1.CTlogAdmission) error {
+	c.ExtKeyUsages = []string{defaultExtKeyUsage}
+	if admission == nil {
+		return nil
+	}
+
+	c.RejectExpired = admission.RejectExpired
+	c.RejectUnexpired = admission.RejectUnexpired
+	c.AcceptOnlyCA = admission.AcceptOnlyCA
+
+	if len(admission.ExtKeyUsages) > 0 {
+		c.ExtKeyUsages = make([]string, 0, len(admission.ExtKeyUsages))
+		for _, eku := range admission.ExtKeyUsages {
+			c.ExtKeyUsages = append(c.ExtKeyUsages, string(eku))
+		}
+	}
+
+	if admission.NotAfterStart != nil {
+		c.NotAfterStart = timestamppb.New(admission.NotAfterStart.Time)
+	}
+	if admission.NotAfterLimit != nil {
+		c.NotAfterLimit = timestamppb.New(admission.NotAfterLimit.Time)
+	}
+
+	for _, oid := range admission.RejectExtensions {
+		if _, err := parseOID(oid); err != nil {
+			return fmt.Errorf("invalid rejected extension %q: %w", oid, err)
+		}
+	}
+	c.RejectExtensions = admission.RejectExtensions
+	return nil
 }
 
 // AddRootCertificate will add the specified root certificate to truststore.
@@ -107,9 +160,15 @@ func (c *Config) MarshalConfig() ([]byte, error) {
 		PrivateKey: mustMarshalAny(&keyspb.PEMKeyFile{
 			Path:     privateKeyFile,
 			Password: string(c.PrivKeyPassword)}),
-		PublicKey:      &keyspb.PublicKey{Der: block.Bytes},
-		LogBackendName: "trillian",
-		ExtKeyUsages:   []string{"CodeSigning"},
+		PublicKey:        &keyspb.PublicKey{Der: block.Bytes},
+		LogBackendName:   "trillian",
+		ExtKeyUsages:     c.ExtKeyUsages,
+		RejectExpired:    c.RejectExpired,
+		RejectUnexpired:  c.RejectUnexpired,
+		NotAfterStart:    c.NotAfterStart,
+		NotAfterLimit:    c.NotAfterLimit,
+		AcceptOnlyCa:     c.AcceptOnlyCA,
+		RejectExtensions: c.RejectExtensions,
 	}
 
 	multiConfig := configpb.LogMultiConfig{
@@ -123,6 +182,9 @@ func (c *Config) MarshalConfig() ([]byte, error) {
 			}},
 		},
 	}
+	if _, err := ctfe.ValidateLogMultiConfig(&multiConfig); err != nil {
+		return nil, fmt.Errorf("invalid ctlog config: %w", err)
+	}
 	marshalledConfig, err := prototext.Marshal(&multiConfig)
 	if err != nil {
 		return nil, err
@@ -130,6 +192,23 @@ func (c *Config) MarshalConfig() ([]byte, error) {
 	return marshalledConfig, nil
 }
 
+// parseOID parses a dotted decimal object identifier, e.g. 1.3.6.1.4.1.11129.2.4.2
+func parseOID(oid string) (asn1.ObjectIdentifier, error) {
+	parts := strings.Split(oid, ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("object identifier must have at least two components")
+	}
+	ret := make(asn1.ObjectIdentifier, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid object identifier component %q", part)
+		}
+		ret[i] = n
+	}
+	return ret, nil
+}
+
 func mustMarshalAny(pb proto.Message) *anypb.Any {
 	ret, err := anypb.New(pb)
 	if err != nil {
@@ -168,7 +247,7 @@ func createConfigWithKeys(certConfig *PrivateKeyConfig) (*Config, error) {
 	return config, nil
 }
 
-func CreateCtlogConfig(trillianUrl string, treeID int64, rootCerts []RootCertificate, keyConfig *PrivateKeyConfig) (map[string][]byte, error) {
+func CreateCtlogConfig(trillianUrl string, treeID int64, rootCerts []RootCertificate, keyConfig *PrivateKeyConfig, admission *v1alpha1.CTlogAdmission) (map[string][]byte, error) {
 	ctlogConfig, err := createConfigWithKeys(keyConfig)
 	if err != nil {
 		return nil, err
@@ -177,6 +256,10 @@ func CreateCtlogConfig(trillianUrl string, treeID int64, rootCerts []RootCertifi
 	ctlogConfig.LogPrefix = "trusted-artifact-signer"
 	ctlogConfig.TrillianServerAddr = trillianUrl
 
+	if err = ctlogConfig.SetAdmission(admission); err != nil {
+		return nil, err
+	}
+
 	for _, cert := range rootCerts {
 		if err = ctlogConfig.AddRootCertificate(cert); err != nil {
 			return nil, fmt.Errorf("Failed to add fulcio root: %v", err)
@@ -7,6 +7,7 @@ import (
 	"github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -120,6 +121,11 @@ func CreateDeployment(instance *v1alpha1.CTlog, deploymentName string, sa string
 			},
 		},
 	}
+	if ref := instance.Spec.Trillian.CACertRef; ref != nil {
+		container := &dep.Spec.Template.Spec.Containers[0]
+		container.Args = append(container.Args, "--trillian_tls_ca_cert_file="+trillianUtils.CACertPath)
+		trillianUtils.MountCACert(&dep.Spec.Template, container, ref)
+	}
 	utils.SetProxyEnvs(dep)
 	return dep, nil
 }
@@ -6,7 +6,9 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
+	ctlogUtils "github.com/securesign/operator/internal/controller/ctlog/utils"
 	futils "github.com/securesign/operator/internal/controller/fulcio/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,12 +42,14 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Fulcio
 
 	switch {
 	case instance.Spec.Ctlog.Address == "":
-		instance.Spec.Ctlog.Address = fmt.Sprintf("http://ctlog.%s.svc", instance.Namespace)
+		if instance.Spec.Ctlog.Address, err = ctlogUtils.ServiceAddress(ctx, i.Client, instance); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve CTlog address: %w", err))
+		}
 	case instance.Spec.Ctlog.Port == nil:
 		port := int32(80)
 		instance.Spec.Ctlog.Port = &port
 	}
-	dp, err := futils.CreateDeployment(instance, DeploymentName, RBACName, labels)
+	dp, err := futils.CreateDeployment(instance, utils.ResourceName(instance, DeploymentName), utils.ResourceName(instance, RBACName), labels)
 	if err != nil {
 		if err != nil {
 			meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
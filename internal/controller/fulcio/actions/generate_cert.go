@@ -11,6 +11,7 @@ import (
 	"github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common"
 	"github.com/securesign/operator/internal/controller/common/action"
+	commonUtils "github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/fulcio/utils"
@@ -205,7 +206,7 @@ func (g handleCert) setupCert(ctx context.Context, instance *v1alpha1.Fulcio) (*
 		}
 		config.RootCert = key
 	} else {
-		rootCert, err := utils.CreateFulcioCA(ctx, g.Client, config, instance, DeploymentName)
+		rootCert, err := utils.CreateFulcioCA(ctx, g.Client, config, instance, commonUtils.ResourceName(instance, DeploymentName))
 		if err != nil {
 			return nil, err
 		}
@@ -6,6 +6,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	v1 "k8s.io/api/core/v1"
@@ -35,7 +36,7 @@ func (i ingressAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Fulc
 
 func (i ingressAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Fulcio) *action.Result {
 	var updated bool
-	ok := types.NamespacedName{Name: DeploymentName, Namespace: instance.Namespace}
+	ok := types.NamespacedName{Name: utils.ResourceName(instance, DeploymentName), Namespace: instance.Namespace}
 	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
 
 	svc := &v1.Service{}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	v12 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -56,7 +57,7 @@ func (i initializeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Fu
 	if instance.Spec.ExternalAccess.Enabled {
 		protocol := "http://"
 		ingress := &v12.Ingress{}
-		err = i.Client.Get(ctx, types.NamespacedName{Name: DeploymentName, Namespace: instance.Namespace}, ingress)
+		err = i.Client.Get(ctx, types.NamespacedName{Name: utils.ResourceName(instance, DeploymentName), Namespace: instance.Namespace}, ingress)
 		if err != nil {
 			return i.Failed(err)
 		}
@@ -65,7 +66,7 @@ func (i initializeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Fu
 		}
 		instance.Status.Url = protocol + ingress.Spec.Rules[0].Host
 	} else {
-		instance.Status.Url = fmt.Sprintf("http://%s.%s.svc", DeploymentName, instance.Namespace)
+		instance.Status.Url = fmt.Sprintf("http://%s.%s.svc", utils.ResourceName(instance, DeploymentName), instance.Namespace)
 	}
 
 	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{Type: constants.Ready,
@@ -33,6 +33,7 @@ import (
 	"github.com/securesign/operator/internal/controller/common/action"
 
 	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -91,6 +92,9 @@ func (r *FulcioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 	target := instance.DeepCopy()
 	acs := []action.Action[*rhtasv1alpha1.Fulcio]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.Fulcio](func(_ *rhtasv1alpha1.Fulcio) client.Object {
+			return &v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: actions.DeploymentName}}
+		}),
 		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.Fulcio](func(_ *rhtasv1alpha1.Fulcio) []string {
 			return []string{actions.CertCondition}
 		}),
@@ -104,6 +108,7 @@ func (r *FulcioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		actions.NewIngressAction(),
 		transitions.NewToInitializePhaseAction[*rhtasv1alpha1.Fulcio](),
 		actions.NewInitializeAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.Fulcio](),
 	}
 
 	for _, a := range acs {
@@ -188,7 +188,7 @@ var _ = Describe("Fulcio controller", func() {
 			deployment := &appsv1.Deployment{}
 			By("Checking if Deployment was successfully created in the reconciliation")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 
 			By("Move to Ready phase")
@@ -207,7 +207,7 @@ var _ = Describe("Fulcio controller", func() {
 			By("Checking if Service was successfully created in the reconciliation")
 			service := &corev1.Service{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, service)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, service)
 			}).Should(Succeed())
 			Expect(service.Spec.Ports[0].Port).Should(Equal(int32(80)))
 			Expect(service.Spec.Ports[1].Port).Should(Equal(int32(5554)))
@@ -215,7 +215,7 @@ var _ = Describe("Fulcio controller", func() {
 			By("Checking if Ingress was successfully created in the reconciliation")
 			ingress := &v1.Ingress{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, ingress)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, ingress)
 			}).Should(Succeed())
 			Expect(ingress.Spec.Rules[0].Host).Should(Equal("fulcio.localhost"))
 			Expect(ingress.Spec.Rules[0].IngressRuleValue.HTTP.Paths[0].Backend.Service.Name).Should(Equal(service.Name))
@@ -224,14 +224,14 @@ var _ = Describe("Fulcio controller", func() {
 			By("Checking if controller will return deployment to desired state")
 			deployment = &appsv1.Deployment{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 			replicas := int32(99)
 			deployment.Spec.Replicas = &replicas
 			Expect(k8sClient.Status().Update(ctx, deployment)).Should(Succeed())
 			Eventually(func(g Gomega) int32 {
 				deployment = &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
 				return *deployment.Spec.Replicas
 			}).Should(Equal(int32(1)))
 		})
@@ -125,7 +125,7 @@ var _ = Describe("Fulcio hot update", func() {
 			deployment := &appsv1.Deployment{}
 			By("Checking if Deployment was successfully created in the reconciliation")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 
 			By("Move to Ready phase")
@@ -181,14 +181,14 @@ var _ = Describe("Fulcio hot update", func() {
 			By("Fulcio deployment is updated")
 			Eventually(func(g Gomega) bool {
 				updated := &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 
 			time.Sleep(10 * time.Second)
 
 			By("Config update")
-			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, deployment)).To(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, deployment)).To(Succeed())
 
 			By("Update OIDC")
 			Expect(k8sClient.Get(ctx, typeNamespaceName, found)).Should(Succeed())
@@ -203,7 +203,7 @@ var _ = Describe("Fulcio hot update", func() {
 			By("Fulcio deployment is updated")
 			Eventually(func(g Gomega) bool {
 				updated := &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DeploymentName, Namespace: Namespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 		})
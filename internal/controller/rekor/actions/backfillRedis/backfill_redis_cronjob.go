@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/robfig/cron/v3"
-	"github.com/securesign/operator/internal/controller/common/utils"
 
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
 	batchv1 "k8s.io/api/batch/v1"
@@ -49,9 +49,10 @@ func (i backfillRedisCronJob) Handle(ctx context.Context, instance *rhtasv1alpha
 	}
 
 	labels := constants.LabelsFor(actions.BackfillRedisCronJobName, actions.BackfillRedisCronJobName, instance.Name)
+	server := utils.ResourceName(instance, actions.ServerDeploymentName)
 	backfillRedisCronJob := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      actions.BackfillRedisCronJobName,
+			Name:      utils.ResourceName(instance, actions.BackfillRedisCronJobName),
 			Namespace: instance.Namespace,
 			Labels:    labels,
 		},
@@ -61,7 +62,7 @@ func (i backfillRedisCronJob) Handle(ctx context.Context, instance *rhtasv1alpha
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
 						Spec: corev1.PodSpec{
-							ServiceAccountName: actions.RBACName,
+							ServiceAccountName: utils.ResourceName(instance, actions.RBACName),
 							RestartPolicy:      "OnFailure",
 							Containers: []corev1.Container{
 								{
@@ -69,7 +70,7 @@ func (i backfillRedisCronJob) Handle(ctx context.Context, instance *rhtasv1alpha
 									Image:   constants.BackfillRedisImage,
 									Command: []string{"/bin/sh", "-c"},
 									Args: []string{
-										fmt.Sprintf(`endIndex=$(curl -sS http://%s/api/v1/log | sed -E 's/.*"treeSize":([0-9]+).*/\1/'); endIndex=$((endIndex-1)); if [ $endIndex -lt 0 ]; then echo "info: no rekor entries found"; exit 0; fi; backfill-redis --hostname=rekor-redis --port=6379 --rekor-address=http://%s --start=0 --end=$endIndex`, actions.ServerComponentName, actions.ServerComponentName),
+										fmt.Sprintf(`endIndex=$(curl -sS http://%s/api/v1/log | sed -E 's/.*"treeSize":([0-9]+).*/\1/'); endIndex=$((endIndex-1)); if [ $endIndex -lt 0 ]; then echo "info: no rekor entries found"; exit 0; fi; backfill-redis --hostname=%s --port=6379 --rekor-address=http://%s --start=0 --end=$endIndex`, server, utils.ResourceName(instance, actions.RedisDeploymentName), server),
 									},
 								},
 							},
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	commonUtils "github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
 	"github.com/securesign/operator/internal/controller/rekor/utils"
@@ -38,7 +39,7 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rekor)
 		updated bool
 	)
 	labels := constants.LabelsFor(actions.RedisComponentName, actions.RedisDeploymentName, instance.Name)
-	dp := utils.CreateRedisDeployment(instance.Namespace, actions.RedisDeploymentName, actions.RBACName, labels)
+	dp := utils.CreateRedisDeployment(instance.Namespace, commonUtils.ResourceName(instance, actions.RedisDeploymentName), commonUtils.ResourceName(instance, actions.RBACName), labels)
 	if err = controllerutil.SetControllerReference(instance, dp, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for Deployment: %w", err))
 	}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	cutils "github.com/securesign/operator/internal/controller/common/utils"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	cutils "github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
 	"github.com/securesign/operator/internal/controller/rekor/utils"
-	actions2 "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -44,11 +44,29 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rekor)
 	labels := constants.LabelsFor(actions.ServerComponentName, actions.ServerDeploymentName, instance.Name)
 
 	insCopy := instance.DeepCopy()
+	if insCopy.Spec.Trillian.CACertRef, err = trillianUtils.ResolveCACert(ctx, i.Client, instance, instance.Spec.Trillian); err != nil {
+		// never fall back to a plain connection to a Trillian serving TLS
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ServerCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not resolve Trillian CA certificate: %w", err), instance)
+	}
 	if insCopy.Spec.Trillian.Address == "" {
-		insCopy.Spec.Trillian.Address = fmt.Sprintf("%s.%s.svc", actions2.LogserverDeploymentName, instance.Namespace)
+		if insCopy.Spec.Trillian.Address, err = trillianUtils.LogserverAddress(ctx, i.Client, instance); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian address: %w", err))
+		}
 	}
 	i.Logger.V(1).Info("trillian logserver", "address", insCopy.Spec.Trillian.Address)
-	dp, err := utils.CreateRekorDeployment(insCopy, actions.ServerDeploymentName, actions.RBACName, labels)
+	dp, err := utils.CreateRekorDeployment(insCopy, cutils.ResourceName(instance, actions.ServerDeploymentName), cutils.ResourceName(instance, actions.RBACName), labels)
 	if err == nil {
 		err = cutils.SetTrustedCA(&dp.Spec.Template, cutils.TrustedCAAnnotationToReference(instance.Annotations))
 	}
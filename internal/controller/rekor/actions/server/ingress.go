@@ -6,6 +6,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
@@ -36,7 +37,7 @@ func (i ingressAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Reko
 
 func (i ingressAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rekor) *action.Result {
 	var updated bool
-	ok := types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: instance.Namespace}
+	ok := types.NamespacedName{Name: utils.ResourceName(instance, actions.ServerDeploymentName), Namespace: instance.Namespace}
 	labels := constants.LabelsFor(actions.ServerComponentName, actions.ServerDeploymentName, instance.Name)
 
 	svc := &v1.Service{}
@@ -7,6 +7,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
@@ -42,7 +43,7 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Re
 
 	role := kubernetes.CreateRole(
 		instance.Namespace,
-		actions.MonitoringRoleName,
+		utils.ResourceName(instance, actions.MonitoringRoleName),
 		monitoringLabels,
 		[]v1.PolicyRule{
 			{
@@ -75,12 +76,12 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Re
 
 	roleBinding := kubernetes.CreateRoleBinding(
 		instance.Namespace,
-		actions.MonitoringRoleName,
+		utils.ResourceName(instance, actions.MonitoringRoleName),
 		monitoringLabels,
 		v1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
 			Kind:     "Role",
-			Name:     actions.MonitoringRoleName,
+			Name:     utils.ResourceName(instance, actions.MonitoringRoleName),
 		},
 		[]v1.Subject{
 			{Kind: "ServiceAccount", Name: "prometheus-k8s", Namespace: "openshift-monitoring"},
@@ -108,7 +109,7 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Re
 
 	serviceMonitor := kubernetes.CreateServiceMonitor(
 		instance.Namespace,
-		actions.ServerDeploymentName,
+		utils.ResourceName(instance, actions.ServerDeploymentName),
 		monitoringLabels,
 		[]monitoringv1.Endpoint{
 			{
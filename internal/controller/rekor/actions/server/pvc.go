@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
-
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
@@ -15,6 +15,7 @@ import (
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/annotations"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
@@ -152,7 +153,7 @@ func (i resolvePubKeyAction) resolvePubKey(instance rhtasv1alpha1.Rekor) ([]byte
 		err  error
 	)
 
-	if data, err = i.requestPublicKey(fmt.Sprintf("http://%s.%s.svc", actions.ServerDeploymentName, instance.Namespace)); err == nil {
+	if data, err = i.requestPublicKey(fmt.Sprintf("http://%s.%s.svc", utils.ResourceName(&instance, actions.ServerDeploymentName), instance.Namespace)); err == nil {
 		return data, nil
 	}
 	i.Logger.Info("retrying to get rekor public key")
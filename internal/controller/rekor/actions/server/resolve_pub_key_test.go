@@ -174,7 +174,7 @@ func TestResolvePubKey_Handle(t *testing.T) {
 				WithStatusSubresource(instance).
 				WithObjects(tt.env.objects...).Build()
 			httpmock.SetMockTransport(http.DefaultClient, map[string]httpmock.RoundTripFunc{
-				"http://rekor-server.default.svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
+				"http://rekor-rekor-server.default.svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
 					if tt.want.publicKey == nil {
 						return &http.Response{
 							StatusCode: http.StatusBadRequest,
@@ -2,23 +2,25 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/trillian"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common"
 	"github.com/securesign/operator/internal/controller/common/action"
+	commonUtils "github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
 	"github.com/securesign/operator/internal/controller/rekor/utils"
-	actions2 "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-type createTree func(ctx context.Context, displayName string, trillianURL string, deadline int64) (*trillian.Tree, error)
+type createTree func(ctx context.Context, displayName string, owner common.TreeOwner, trillianURL string, deadline int64, caCert []byte) (*trillian.Tree, bool, error)
 
 func NewResolveTreeAction(opts ...func(*resolveTreeAction)) action.Action[*rhtasv1alpha1.Rekor] {
 	a := &resolveTreeAction{
@@ -47,6 +49,9 @@ func (i resolveTreeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.
 		return false
 	case c.Reason != constants.Creating && c.Reason != constants.Ready:
 		return false
+	case instance.Spec.TreeRef != nil:
+		// the referenced TrillianTree may resolve the tree at any time
+		return true
 	case instance.Status.TreeID == nil:
 		return true
 	case instance.Spec.TreeID != nil:
@@ -57,6 +62,20 @@ func (i resolveTreeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.
 }
 
 func (i resolveTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rekor) *action.Result {
+	if instance.Spec.TreeRef != nil {
+		treeID, err := trillianUtils.GetTreeID(ctx, i.Client, instance.Namespace, instance.Spec.TreeRef)
+		switch {
+		case err != nil:
+			return i.Failed(err)
+		case treeID == nil:
+			i.Logger.V(1).Info("waiting for TrillianTree to resolve the tree", "name", instance.Spec.TreeRef.Name)
+			return i.Requeue()
+		case equality.Semantic.DeepEqual(treeID, instance.Status.TreeID):
+			return i.Continue()
+		}
+		instance.Status.TreeID = treeID
+		return i.StatusUpdate(ctx, instance)
+	}
 	if instance.Spec.TreeID != nil && *instance.Spec.TreeID != int64(0) {
 		instance.Status.TreeID = instance.Spec.TreeID
 		return i.StatusUpdate(ctx, instance)
@@ -69,7 +88,10 @@ func (i resolveTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.R
 	case instance.Spec.Trillian.Port == nil:
 		err = fmt.Errorf("%s: %v", i.Name(), utils.TrillianPortNotSpecified)
 	case instance.Spec.Trillian.Address == "":
-		trillUrl = fmt.Sprintf("%s.%s.svc:%d", actions2.LogserverDeploymentName, instance.Namespace, *instance.Spec.Trillian.Port)
+		var address string
+		if address, err = trillianUtils.LogserverAddress(ctx, i.Client, instance); err == nil {
+			trillUrl = fmt.Sprintf("%s:%d", address, *instance.Spec.Trillian.Port)
+		}
 	default:
 		trillUrl = fmt.Sprintf("%s:%d", instance.Spec.Trillian.Address, *instance.Spec.Trillian.Port)
 	}
@@ -78,7 +100,33 @@ func (i resolveTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.R
 	}
 	i.Logger.V(1).Info("trillian logserver", "address", trillUrl)
 
-	tree, err = i.createTree(ctx, "rekor-tree", trillUrl, constants.CreateTreeDeadline)
+	var treeID *int64
+	var created bool
+	if constants.CreateTreeInJob {
+		if treeID, created, err = i.createTreeInJob(ctx, instance, trillUrl); err == nil && treeID == nil {
+			i.Logger.V(1).Info("waiting for the create tree job")
+			return i.Requeue()
+		}
+	} else {
+		var caCert []byte
+		if caCert, err = trillianUtils.GetCACert(ctx, i.Client, instance, instance.Spec.Trillian); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian CA certificate: %w", err))
+		}
+		if tree, created, err = i.createTree(ctx, "rekor-tree", common.NewTreeOwner("Rekor", instance), trillUrl, constants.CreateTreeDeadline, caCert); err == nil {
+			treeID = &tree.TreeId
+		}
+	}
+	if errors.Is(err, common.ErrAmbiguousTree) {
+		// refuse to pick one of the trees, the user has to resolve the conflict
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.TreeCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.TreeAmbiguous,
+			Message: err.Error(),
+		})
+		i.Recorder.Event(instance, v1.EventTypeWarning, "TrillianTreeAmbiguous", err.Error())
+		return i.FailedWithStatusUpdate(ctx, err, instance)
+	}
 	if err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    actions.ServerCondition,
@@ -94,8 +142,25 @@ func (i resolveTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.R
 		})
 		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create trillian tree: %v", err), instance)
 	}
-	i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeCreated", "New Trillian tree created: %d", tree.TreeId)
-	instance.Status.TreeID = &tree.TreeId
+	if created {
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeCreated", "New Trillian tree created: %d", *treeID)
+	} else {
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeAdopted", "Existing Trillian tree adopted: %d", *treeID)
+	}
+	instance.Status.TreeID = treeID
+	meta.RemoveStatusCondition(&instance.Status.Conditions, constants.TreeCondition)
 
 	return i.StatusUpdate(ctx, instance)
 }
+
+// createTreeInJob creates the tree from a Job running in the namespace of the instance.
+// Nil tree ID is returned while the Job is running, true is returned when the Job created a new tree.
+func (i resolveTreeAction) createTreeInJob(ctx context.Context, instance *rhtasv1alpha1.Rekor, trillUrl string) (*int64, bool, error) {
+	caCertRef, err := trillianUtils.ResolveCACert(ctx, i.Client, instance, instance.Spec.Trillian)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not resolve Trillian CA certificate: %w", err)
+	}
+	labels := constants.LabelsFor(actions.ServerComponentName, trillianUtils.CreateTreeJobName, instance.Name)
+	job := trillianUtils.CreateTreeJob(common.NewTreeOwner("Rekor", instance), "rekor-tree", trillUrl, caCertRef, commonUtils.ResourceName(instance, actions.RBACName), labels)
+	return trillianUtils.ResolveTreeWithJob(ctx, i.Client, instance, job)
+}
@@ -10,14 +10,19 @@ import (
 	"github.com/google/trillian"
 	. "github.com/onsi/gomega"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/utils"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
 	testAction "github.com/securesign/operator/internal/testing/action"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestResolveTree_CanHandle(t *testing.T) {
@@ -115,6 +120,7 @@ func TestResolveTree_Handle(t *testing.T) {
 		spec         rhtasv1alpha1.RekorSpec
 		statusTreeId *int64
 		createTree   createTree
+		objects      []client.Object
 	}
 	type want struct {
 		result *action.Result
@@ -205,7 +211,7 @@ func TestResolveTree_Handle(t *testing.T) {
 				spec: rhtasv1alpha1.RekorSpec{
 					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(1234))},
 				},
-				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64) {
+				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64, caCert []byte) {
 					g.Expect(trillianURL).Should(Equal(fmt.Sprintf("%s.%s.svc:%d", actions.LogserverDeploymentName, "default", 1234)))
 				}),
 			},
@@ -219,7 +225,7 @@ func TestResolveTree_Handle(t *testing.T) {
 				spec: rhtasv1alpha1.RekorSpec{
 					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(1234)), Address: "custom-address.namespace.svc"},
 				},
-				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64) {
+				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64, caCert []byte) {
 					g.Expect(trillianURL).Should(Equal(fmt.Sprintf("custom-address.namespace.svc:%d", 1234)))
 				}),
 			},
@@ -227,6 +233,115 @@ func TestResolveTree_Handle(t *testing.T) {
 				result: testAction.StatusUpdate(),
 			},
 		},
+		{
+			name: "trillian TLS CA",
+			env: env{
+				spec: rhtasv1alpha1.RekorSpec{
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+				},
+				objects: []client.Object{
+					&rhtasv1alpha1.Trillian{
+						ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+						Status: rhtasv1alpha1.TrillianStatus{
+							TLS: rhtasv1alpha1.TLS{
+								Enabled:   true,
+								CACertRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "trillian-ca"}, Key: "cert"},
+							},
+						},
+					},
+					kubernetes.CreateSecret("trillian-ca", "default", map[string][]byte{"cert": []byte("ca")}, map[string]string{}),
+				},
+				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64, caCert []byte) {
+					g.Expect(caCert).Should(Equal([]byte("ca")))
+				}),
+			},
+			want: want{
+				result: testAction.StatusUpdate(),
+			},
+		},
+		{
+			name: "custom trillian address without TLS",
+			env: env{
+				spec: rhtasv1alpha1.RekorSpec{
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(1234)), Address: "custom-address.namespace.svc"},
+				},
+				objects: []client.Object{
+					&rhtasv1alpha1.Trillian{
+						ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+						Status: rhtasv1alpha1.TrillianStatus{
+							TLS: rhtasv1alpha1.TLS{
+								Enabled:   true,
+								CACertRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "trillian-ca"}, Key: "cert"},
+							},
+						},
+					},
+				},
+				createTree: mockCreateTree(&trillian.Tree{TreeId: 5555555}, nil, func(displayName string, trillianURL string, deadline int64, caCert []byte) {
+					g.Expect(caCert).Should(BeNil())
+				}),
+			},
+			want: want{
+				result: testAction.StatusUpdate(),
+			},
+		},
+		{
+			name: "ambiguous tree",
+			env: env{
+				spec: rhtasv1alpha1.RekorSpec{
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+				},
+				createTree: mockCreateTree(nil, common.ErrAmbiguousTree, nil),
+			},
+			want: want{
+				result: testAction.FailedWithStatusUpdate(common.ErrAmbiguousTree),
+				verify: func(g Gomega, instance *rhtasv1alpha1.Rekor) {
+					g.Expect(instance.Status.TreeID).To(BeNil())
+					g.Expect(meta.FindStatusCondition(instance.Status.Conditions, constants.TreeCondition).Reason).To(Equal(constants.TreeAmbiguous))
+					g.Expect(meta.FindStatusCondition(instance.Status.Conditions, constants.Ready).Reason).To(Equal(constants.Creating))
+				},
+			},
+		},
+		{
+			name: "use tree from TrillianTree",
+			env: env{
+				spec: rhtasv1alpha1.RekorSpec{
+					TreeRef:  &rhtasv1alpha1.LocalObjectReference{Name: "tree"},
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+				},
+				objects: []client.Object{
+					&rhtasv1alpha1.TrillianTree{
+						ObjectMeta: metav1.ObjectMeta{Name: "tree", Namespace: "default"},
+						Status:     rhtasv1alpha1.TrillianTreeStatus{TreeID: ptr.To(int64(777))},
+					},
+				},
+			},
+			want: want{
+				result: testAction.StatusUpdate(),
+				verify: func(g Gomega, instance *rhtasv1alpha1.Rekor) {
+					g.Expect(instance.Status.TreeID).To(HaveValue(BeNumerically("==", 777)))
+				},
+			},
+		},
+		{
+			name: "wait for TrillianTree",
+			env: env{
+				spec: rhtasv1alpha1.RekorSpec{
+					TreeRef:  &rhtasv1alpha1.LocalObjectReference{Name: "tree"},
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+				},
+				objects: []client.Object{
+					&rhtasv1alpha1.TrillianTree{
+						ObjectMeta: metav1.ObjectMeta{Name: "tree", Namespace: "default"},
+					},
+				},
+			},
+			want: want{
+				result: testAction.Requeue(),
+				verify: func(g Gomega, instance *rhtasv1alpha1.Rekor) {
+					g.Expect(instance.Status.TreeID).To(BeNil())
+				},
+			},
+		},
 		{
 			name: "trillian port not specified",
 			env: env{
@@ -262,6 +377,7 @@ func TestResolveTree_Handle(t *testing.T) {
 			c := testAction.FakeClientBuilder().
 				WithObjects(instance).
 				WithStatusSubresource(instance).
+				WithObjects(tt.env.objects...).
 				Build()
 
 			a := testAction.PrepareAction(c, NewResolveTreeAction(func(t *resolveTreeAction) {
@@ -282,11 +398,86 @@ func TestResolveTree_Handle(t *testing.T) {
 	}
 }
 
-func mockCreateTree(tree *trillian.Tree, err error, verify func(displayName string, trillianURL string, deadline int64)) createTree {
-	return func(ctx context.Context, displayName string, trillianURL string, deadline int64) (*trillian.Tree, error) {
+func TestResolveTree_HandleEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		created bool
+		event   string
+	}{
+		{
+			name:    "tree created",
+			created: true,
+			event:   "TrillianTreeCreated",
+		},
+		{
+			name:  "tree adopted",
+			event: "TrillianTreeAdopted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &rhtasv1alpha1.Rekor{
+				ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default"},
+				Spec: rhtasv1alpha1.RekorSpec{
+					Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+				},
+				Status: rhtasv1alpha1.RekorStatus{
+					Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Creating}},
+				},
+			}
+			c := testAction.FakeClientBuilder().
+				WithObjects(instance).
+				WithStatusSubresource(instance).
+				Build()
+			a := testAction.PrepareAction(c, NewResolveTreeAction(func(a *resolveTreeAction) {
+				a.createTree = func(context.Context, string, common.TreeOwner, string, int64, []byte) (*trillian.Tree, bool, error) {
+					return &trillian.Tree{TreeId: 5555555}, tt.created, nil
+				}
+			}))
+
+			g.Expect(a.Handle(context.TODO(), instance)).To(Equal(testAction.StatusUpdate()))
+			g.Expect(a.(*resolveTreeAction).Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(tt.event)))
+		})
+	}
+}
+
+func TestResolveTree_HandleInJob(t *testing.T) {
+	g := NewWithT(t)
+	constants.CreateTreeInJob = true
+	defer func() { constants.CreateTreeInJob = false }()
+
+	instance := &rhtasv1alpha1.Rekor{
+		ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.RekorSpec{
+			Trillian: rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+		},
+		Status: rhtasv1alpha1.RekorStatus{
+			Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Creating}},
+		},
+	}
+	c := testAction.FakeClientBuilder().
+		WithObjects(instance).
+		WithStatusSubresource(instance).
+		Build()
+	a := testAction.PrepareAction(c, NewResolveTreeAction(func(t *resolveTreeAction) {
+		t.createTree = mockCreateTree(nil, errors.New("createTree should not be executed"), nil)
+	}))
+
+	g.Expect(a.Handle(context.TODO(), instance)).To(Equal(testAction.Requeue()))
+	g.Expect(instance.Status.TreeID).To(BeNil())
+
+	jobs := &batchv1.JobList{}
+	g.Expect(c.List(context.TODO(), jobs, client.InNamespace("default"))).To(Succeed())
+	g.Expect(jobs.Items).To(HaveLen(1))
+	g.Expect(jobs.Items[0].Spec.Template.Spec.Containers[0].Command).To(ContainElement("--owner-name=rekor"))
+}
+
+func mockCreateTree(tree *trillian.Tree, err error, verify func(displayName string, trillianURL string, deadline int64, caCert []byte)) createTree {
+	return func(ctx context.Context, displayName string, _ common.TreeOwner, trillianURL string, deadline int64, caCert []byte) (*trillian.Tree, bool, error) {
 		if verify != nil {
-			verify(displayName, trillianURL, deadline)
+			verify(displayName, trillianURL, deadline, caCert)
 		}
-		return tree, err
+		return tree, true, err
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/rekor/actions"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -36,7 +37,7 @@ func (i statusUrlAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rek
 	if instance.Spec.ExternalAccess.Enabled {
 		protocol := "http://"
 		ingress := &v12.Ingress{}
-		err := i.Client.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: instance.Namespace}, ingress)
+		err := i.Client.Get(ctx, types.NamespacedName{Name: utils.ResourceName(instance, actions.ServerDeploymentName), Namespace: instance.Namespace}, ingress)
 		if err != nil {
 			return i.Failed(err)
 		}
@@ -45,7 +46,7 @@ func (i statusUrlAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rek
 		}
 		url = protocol + ingress.Spec.Rules[0].Host
 	} else {
-		url = fmt.Sprintf("http://%s.%s.svc", actions.ServerDeploymentName, instance.Namespace)
+		url = fmt.Sprintf("http://%s.%s.svc", utils.ResourceName(instance, actions.ServerDeploymentName), instance.Namespace)
 	}
 
 	if url == instance.Status.Url {
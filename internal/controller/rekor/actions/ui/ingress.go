@@ -40,7 +40,7 @@ func (i ingressAction) CanHandle(ctx context.Context, instance *rhtasv1alpha1.Re
 
 func (i ingressAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Rekor) *action.Result {
 	var updated bool
-	ok := types.NamespacedName{Name: actions.SearchUiDeploymentName, Namespace: instance.Namespace}
+	ok := types.NamespacedName{Name: utils.ResourceName(instance, actions.SearchUiDeploymentName), Namespace: instance.Namespace}
 	labels := constants.LabelsFor(actions.UIComponentName, actions.SearchUiDeploymentName, instance.Name)
 
 	svc := &v1.Service{}
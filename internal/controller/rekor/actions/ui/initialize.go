@@ -56,7 +56,7 @@ func (i initializeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Re
 
 	protocol := "http://"
 	ingress := &v12.Ingress{}
-	err = i.Client.Get(ctx, types.NamespacedName{Name: actions.SearchUiDeploymentName, Namespace: instance.Namespace}, ingress)
+	err = i.Client.Get(ctx, types.NamespacedName{Name: utils.ResourceName(instance, actions.SearchUiDeploymentName), Namespace: instance.Namespace}, ingress)
 	if err != nil {
 		// condition error
 		return i.FailedWithStatusUpdate(ctx, err, instance)
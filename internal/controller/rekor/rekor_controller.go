@@ -30,15 +30,19 @@ import (
 	"github.com/securesign/operator/internal/controller/rekor/actions/redis"
 	"github.com/securesign/operator/internal/controller/rekor/actions/server"
 	"github.com/securesign/operator/internal/controller/rekor/actions/ui"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	v13 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/networking/v1"
 	"k8s.io/client-go/tools/record"
 
 	"github.com/securesign/operator/internal/controller/common/action"
 	v12 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -56,11 +60,13 @@ type RekorReconciler struct {
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=rekors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=rekors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=rekors/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trilliantrees,verbs=get;list;watch
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=secrets,verbs=create;get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=create;get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create;get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=create;get;list;watch;update;patch;delete
 //+kubebuilder:rbac:groups="batch",resources=cronjobs,verbs=create;get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups="batch",resources=jobs,verbs=create;get;list;watch;delete
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
 
@@ -95,6 +101,9 @@ func (r *RekorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	target := instance.DeepCopy()
 	actions := []action.Action[*rhtasv1alpha1.Rekor]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.Rekor](func(_ *rhtasv1alpha1.Rekor) client.Object {
+			return &v12.Deployment{ObjectMeta: metav1.ObjectMeta{Name: actions2.ServerDeploymentName}}
+		}),
 		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.Rekor](func(rekor *rhtasv1alpha1.Rekor) []string {
 			components := []string{actions2.ServerCondition, actions2.RedisCondition, actions2.SignerCondition}
 			if *rekor.Spec.RekorSearchUI.Enabled {
@@ -136,6 +145,7 @@ func (r *RekorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 		// INITIALIZE -> READY
 		actions2.NewInitializeAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.Rekor](),
 	}
 
 	for _, a := range actions {
@@ -169,5 +179,31 @@ func (r *RekorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&v13.Service{}).
 		Owns(&v1.Ingress{}).
 		Owns(&batchv1.CronJob{}).
+		Watches(&rhtasv1alpha1.Trillian{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+			list := &rhtasv1alpha1.RekorList{}
+			if err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace())); err != nil {
+				return make([]reconcile.Request, 0)
+			}
+
+			requests := make([]reconcile.Request, len(list.Items))
+			for i, k := range list.Items {
+				requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: k.Name}}
+			}
+			return requests
+		}), builder.WithPredicates(trillianUtils.TLSChangedPredicate())).
+		Watches(&rhtasv1alpha1.TrillianTree{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+			list := &rhtasv1alpha1.RekorList{}
+			if err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace())); err != nil {
+				return make([]reconcile.Request, 0)
+			}
+
+			requests := make([]reconcile.Request, 0)
+			for _, k := range list.Items {
+				if k.Spec.TreeRef != nil && k.Spec.TreeRef.Name == object.GetName() {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: k.Name}})
+				}
+			}
+			return requests
+		}), builder.WithPredicates(trillianUtils.TreeIDChangedPredicate())).
 		Complete(r)
 }
@@ -155,7 +155,7 @@ var _ = Describe("Rekor controller", func() {
 			Expect(err).To(Succeed())
 
 			httpmock.SetMockTransport(http.DefaultClient, map[string]httpmock.RoundTripFunc{
-				"http://rekor-server.default.svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
+				"http://" + Name + "-rekor-server.default.svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
 					return &http.Response{
 						StatusCode: http.StatusOK,
 						Body:       io.NopCloser(bytes.NewReader(pubKeyData)),
@@ -173,37 +173,37 @@ var _ = Describe("Rekor controller", func() {
 
 			By("Rekor server SVC created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, &corev1.Service{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, &corev1.Service{})
 			}).Should(Succeed())
 
 			By("Rekor server deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("Redis Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.RedisDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.RedisDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("Redis svc created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.RedisDeploymentName, Namespace: Namespace}, &corev1.Service{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.RedisDeploymentName, Namespace: Namespace}, &corev1.Service{})
 			}).Should(Succeed())
 
 			By("UI Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.SearchUiDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.SearchUiDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("UI svc created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.SearchUiDeploymentName, Namespace: Namespace}, &corev1.Service{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.SearchUiDeploymentName, Namespace: Namespace}, &corev1.Service{})
 			}).Should(Succeed())
 
 			By("Backfill Redis Cronjob Created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.BackfillRedisCronJobName, Namespace: Namespace}, &batchv1.CronJob{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.BackfillRedisCronJobName, Namespace: Namespace}, &batchv1.CronJob{})
 			}).Should(Succeed())
 
 			By("Waiting until Rekor instance is Initialization")
@@ -247,14 +247,14 @@ var _ = Describe("Rekor controller", func() {
 			By("Checking if controller will return deployment to desired state")
 			deployment := &appsv1.Deployment{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 			replicas := int32(99)
 			deployment.Spec.Replicas = &replicas
 			Expect(k8sClient.Status().Update(ctx, deployment)).Should(Succeed())
 			Eventually(func(g Gomega) int32 {
 				deployment = &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
 				return *deployment.Spec.Replicas
 			}).Should(Equal(int32(1)))
 		})
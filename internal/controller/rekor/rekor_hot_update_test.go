@@ -145,7 +145,7 @@ var _ = Describe("Rekor hot update test", func() {
 			Expect(err).To(Succeed())
 
 			httpmock.SetMockTransport(http.DefaultClient, map[string]httpmock.RoundTripFunc{
-				"http://rekor-server." + Namespace + ".svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
+				"http://" + Name + "-rekor-server." + Namespace + ".svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
 					return &http.Response{
 						StatusCode: http.StatusOK,
 						Body:       io.NopCloser(bytes.NewReader(pubKeyData)),
@@ -188,7 +188,7 @@ var _ = Describe("Rekor hot update test", func() {
 
 			By("Save the Deployment configuration")
 			deployment := &appsv1.Deployment{}
-			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
 
 			By("Patch the signer key")
 			Eventually(func(g Gomega) error {
@@ -207,7 +207,7 @@ var _ = Describe("Rekor hot update test", func() {
 			Expect(k8sClient.Create(ctx, kubernetes.CreateSecret("key-secret", Namespace, map[string][]byte{"private": []byte("fake")}, constants.LabelsFor(actions.ServerComponentName, actions.ServerDeploymentName, instance.Name)))).To(Succeed())
 
 			httpmock.SetMockTransport(http.DefaultClient, map[string]httpmock.RoundTripFunc{
-				"http://rekor-server." + Namespace + ".svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
+				"http://" + Name + "-rekor-server." + Namespace + ".svc/api/v1/log/publicKey": func(req *http.Request) *http.Response {
 					return &http.Response{
 						StatusCode: http.StatusOK,
 						Body:       io.NopCloser(bytes.NewReader([]byte("newPublicKey"))),
@@ -229,7 +229,7 @@ var _ = Describe("Rekor hot update test", func() {
 			By("Rekor deployment is updated")
 			Eventually(func(g Gomega) bool {
 				updated := &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.ServerDeploymentName, Namespace: Namespace}, updated)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.ServerDeploymentName, Namespace: Namespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 
@@ -8,6 +8,8 @@ import (
 	"github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/rekor/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,7 +34,7 @@ func CreateRekorDeployment(instance *v1alpha1.Rekor, dpName string, sa string, l
 		fmt.Sprintf("--trillian_log_server.address=%s", instance.Spec.Trillian.Address),
 		fmt.Sprintf("--trillian_log_server.port=%d", *instance.Spec.Trillian.Port),
 		"--trillian_log_server.sharding_config=/sharding/sharding-config.yaml",
-		"--redis_server.address=rekor-redis",
+		fmt.Sprintf("--redis_server.address=%s", utils.ResourceName(instance, actions.RedisDeploymentName)),
 		"--redis_server.port=6379",
 		"--rekor_server.address=0.0.0.0",
 		"--enable_retrieve_api=true",
@@ -200,6 +202,14 @@ func CreateRekorDeployment(instance *v1alpha1.Rekor, dpName string, sa string, l
 			},
 		},
 	}
+	if ref := instance.Spec.Trillian.CACertRef; ref != nil {
+		container := &dep.Spec.Template.Spec.Containers[0]
+		container.Args = append(container.Args,
+			"--trillian_log_server.tls=true",
+			"--trillian_log_server.tls_ca_cert="+trillianUtils.CACertPath,
+		)
+		trillianUtils.MountCACert(&dep.Spec.Template, container, ref)
+	}
 	utils.SetProxyEnvs(dep)
 	return dep, nil
 }
@@ -0,0 +1,251 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// managedConditions returns the conditions of the components deployed by the Securesign resource
+func managedConditions(instance *rhtasv1alpha1.Securesign) []string {
+	components := instance.Spec.Components
+	var conditions []string
+	for _, c := range []struct {
+		condition string
+		component rhtasv1alpha1.SecuresignComponent
+	}{
+		{TrillianCondition, components.Trillian},
+		{FulcioCondition, components.Fulcio},
+		{RekorCondition, components.Rekor},
+		{CTlogCondition, components.Ctlog},
+		{TufCondition, components.Tuf},
+	} {
+		if c.component.IsManaged() {
+			conditions = append(conditions, c.condition)
+		}
+	}
+	return conditions
+}
+
+// componentNamespace returns the namespace the resource of the component is created in
+func componentNamespace(instance *rhtasv1alpha1.Securesign, component rhtasv1alpha1.SecuresignComponent) string {
+	if component.Namespace != "" {
+		return component.Namespace
+	}
+	return instance.Namespace
+}
+
+// crossNamespaceOwner returns the owner the address of a component deployed in another namespace than its client is
+// resolved for. Nil is returned when the client reaches the component through its default address.
+func crossNamespaceOwner(instance *rhtasv1alpha1.Securesign, component rhtasv1alpha1.SecuresignComponent, clientNamespace string) v1.Object {
+	namespace := componentNamespace(instance, component)
+	if !component.IsManaged() || namespace == clientNamespace {
+		return nil
+	}
+	return &v1.ObjectMeta{Name: instance.Name, Namespace: namespace}
+}
+
+// setOwner makes the Securesign resource the controller of the component resource. Owner references can't cross
+// namespaces, a resource in another namespace is labelled with the namespace of the Securesign resource instead
+// and deleted by its finalizer.
+func setOwner(instance *rhtasv1alpha1.Securesign, object client.Object, scheme *runtime.Scheme) error {
+	if object.GetNamespace() == instance.Namespace {
+		return controllerutil.SetControllerReference(instance, object, scheme)
+	}
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[InstanceNamespaceLabel] = instance.Namespace
+	object.SetLabels(labels)
+	return nil
+}
+
+// isOwnedBy returns true when the component resource was created by the Securesign resource
+func isOwnedBy(instance *rhtasv1alpha1.Securesign, object client.Object) bool {
+	if object.GetNamespace() == instance.Namespace {
+		return v1.IsControlledBy(object, instance)
+	}
+	labels := object.GetLabels()
+	return labels["app.kubernetes.io/instance"] == instance.Name && labels[InstanceNamespaceLabel] == instance.Namespace
+}
+
+// OwnerRequest maps a component resource created in another namespace to the request of its Securesign resource
+func OwnerRequest(_ context.Context, object client.Object) []reconcile.Request {
+	labels := object.GetLabels()
+	namespace, ok := labels[InstanceNamespaceLabel]
+	if !ok || namespace == object.GetNamespace() || labels["app.kubernetes.io/instance"] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: labels["app.kubernetes.io/instance"]}}}
+}
+
+// componentLists returns the lists of the component resources the Securesign resource may create
+func componentLists() []client.ObjectList {
+	return []client.ObjectList{
+		&rhtasv1alpha1.TrillianList{},
+		&rhtasv1alpha1.FulcioList{},
+		&rhtasv1alpha1.RekorList{},
+		&rhtasv1alpha1.CTlogList{},
+		&rhtasv1alpha1.TufList{},
+	}
+}
+
+// ownedComponents returns the component resources of the list type the Securesign resource created in any namespace
+func ownedComponents(ctx context.Context, c client.Client, instance *rhtasv1alpha1.Securesign, list client.ObjectList) ([]client.Object, error) {
+	if err := c.List(ctx, list, client.MatchingLabels{"app.kubernetes.io/instance": instance.Name}); err != nil {
+		return nil, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	var owned []client.Object
+	for _, item := range items {
+		if object, ok := item.(client.Object); ok && isOwnedBy(instance, object) {
+			owned = append(owned, object)
+		}
+	}
+	return owned, nil
+}
+
+// DeleteCrossNamespaceComponents deletes the component resources the Securesign resource created in other namespaces,
+// they are not garbage collected with it. The resources are found by their labels, so the resources left in a
+// namespace the component was moved from are deleted as well.
+func DeleteCrossNamespaceComponents(ctx context.Context, c client.Client, instance *rhtasv1alpha1.Securesign) error {
+	for _, list := range componentLists() {
+		owned, err := ownedComponents(ctx, c, instance, list)
+		if err != nil {
+			return err
+		}
+		for _, object := range owned {
+			if object.GetNamespace() == instance.Namespace {
+				continue
+			}
+			if err = c.Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeStaleComponents deletes the component resources the Securesign resource created in other namespaces than the
+// namespace the component is deployed in, e.g. when the namespace of the component changed. Nothing else deletes them,
+// the resources in other namespaces have no owner reference and the Securesign resource still exists. An empty
+// namespace deletes the resources in every namespace.
+func removeStaleComponents(ctx context.Context, c client.Client, recorder record.EventRecorder, instance *rhtasv1alpha1.Securesign, kind, namespace string, list client.ObjectList) error {
+	owned, err := ownedComponents(ctx, c, instance, list)
+	if err != nil {
+		return err
+	}
+	for _, object := range owned {
+		if object.GetNamespace() == namespace {
+			continue
+		}
+		if err = c.Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		recorder.Eventf(instance, corev1.EventTypeNormal, kind+"Removed", "%s resource removed: %s/%s", kind, object.GetNamespace(), object.GetName())
+	}
+	return nil
+}
+
+// removeComponent deletes the resources of a component that is no longer deployed by the Securesign resource
+// and drops its condition. Resources not created by the Securesign resource are left untouched.
+func removeComponent(ctx context.Context, c client.Client, recorder record.EventRecorder, instance *rhtasv1alpha1.Securesign, kind string, list client.ObjectList, condition string) error {
+	if err := removeStaleComponents(ctx, c, recorder, instance, kind, "", list); err != nil {
+		return err
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, condition)
+	return nil
+}
+
+// isRemoved returns true when the component is not deployed and its condition is already dropped
+func isRemoved(instance *rhtasv1alpha1.Securesign, component rhtasv1alpha1.SecuresignComponent, condition string) bool {
+	return !component.IsManaged() && meta.FindStatusCondition(instance.Status.Conditions, condition) == nil
+}
+
+// copyComponentStatus copies the Ready condition and the aggregated status of the component resource
+// and records its transitions. It returns true when the status of the Securesign resource changed.
+func copyComponentStatus[S any](recorder record.EventRecorder, instance *rhtasv1alpha1.Securesign, kind, conditionType string, conditions []v1.Condition, current *S, observed S) bool {
+	objectStatus := meta.FindStatusCondition(conditions, constants.Ready)
+	if objectStatus == nil {
+		// not initialized yet, wait for update
+		return false
+	}
+	changed := false
+	if c := meta.FindStatusCondition(instance.Status.Conditions, conditionType); c == nil ||
+		c.Status != objectStatus.Status || c.Reason != objectStatus.Reason {
+		recordTransition(recorder, instance, kind, c, *objectStatus)
+		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
+			Type:   conditionType,
+			Status: objectStatus.Status,
+			Reason: objectStatus.Reason,
+		})
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(*current, observed) {
+		*current = observed
+		changed = true
+	}
+	return changed
+}
+
+// StatusChangedPredicate filters the updates of the component resources to those changing their status
+func StatusChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if e.ObjectOld == nil || e.ObjectNew == nil {
+				return false
+			}
+			oldStatus := reflect.ValueOf(e.ObjectOld).Elem().FieldByName("Status")
+			newStatus := reflect.ValueOf(e.ObjectNew).Elem().FieldByName("Status")
+			if !oldStatus.IsValid() || !newStatus.IsValid() {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldStatus.Interface(), newStatus.Interface())
+		},
+	}
+}
+
+// recordTransition records the transition of the component resource to the Ready reason of the new condition
+func recordTransition(recorder record.EventRecorder, instance *rhtasv1alpha1.Securesign, kind string, old *v1.Condition, new v1.Condition) {
+	if old != nil && old.Reason == new.Reason {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if new.Reason == constants.Failure {
+		eventType = corev1.EventTypeWarning
+	}
+	message := fmt.Sprintf("%s resource %s: %s", kind, instance.Name, new.Reason)
+	if new.Message != "" {
+		message += ", " + new.Message
+	}
+	recorder.Event(instance, eventType, kind+new.Reason, message)
+}
+
+// recordEnsured records the creation or the update of the component resource
+func recordEnsured(recorder record.EventRecorder, instance *rhtasv1alpha1.Securesign, kind string, object client.Object) {
+	// Ensure creates the passed object, an existing one is updated through a copy
+	if object.GetResourceVersion() != "" {
+		recorder.Eventf(instance, corev1.EventTypeNormal, kind+"Created", "%s resource created: %s", kind, object.GetName())
+		return
+	}
+	recorder.Eventf(instance, corev1.EventTypeNormal, kind+"Updated", "%s resource updated: %s", kind, object.GetName())
+}
@@ -0,0 +1,277 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUpdateStatus_components(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Securesign{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default"},
+		Spec: rhtasv1alpha1.SecuresignSpec{Components: rhtasv1alpha1.SecuresignComponents{
+			Fulcio:   rhtasv1alpha1.SecuresignComponent{Enabled: ptr.To(false)},
+			Trillian: rhtasv1alpha1.SecuresignComponent{External: true},
+		}},
+	}
+	g.Expect(managedConditions(instance)).To(Equal([]string{RekorCondition, CTlogCondition, TufCondition}))
+
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	_ = testAction.PrepareAction(c, NewInitializeStatusAction()).Handle(context.TODO(), instance)
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, FulcioCondition)).To(BeNil())
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, TrillianCondition)).To(BeNil())
+
+	for _, condition := range []string{RekorCondition, CTlogCondition, TufCondition} {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{Type: condition, Status: metav1.ConditionTrue, Reason: constants.Ready})
+	}
+	_ = testAction.PrepareAction(c, NewUpdateStatusAction()).Handle(context.TODO(), instance)
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, constants.Ready)).To(BeTrue())
+
+	// the re-enabled component is pending until its condition is set
+	instance.Spec.Components.Fulcio.Enabled = ptr.To(true)
+	_ = testAction.PrepareAction(c, NewUpdateStatusAction()).Handle(context.TODO(), instance)
+	ready := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	g.Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(ready.Reason).To(Equal(constants.Pending))
+}
+
+func TestRemoveComponent(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Securesign{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.SecuresignSpec{Components: rhtasv1alpha1.SecuresignComponents{
+			Fulcio: rhtasv1alpha1.SecuresignComponent{Enabled: ptr.To(false)},
+			Rekor:  rhtasv1alpha1.SecuresignComponent{External: true},
+		}},
+		Status: rhtasv1alpha1.SecuresignStatus{
+			Conditions: []metav1.Condition{
+				{Type: FulcioCondition, Status: metav1.ConditionTrue, Reason: constants.Ready},
+				{Type: RekorCondition, Status: metav1.ConditionTrue, Reason: constants.Ready},
+			},
+			FulcioStatus: rhtasv1alpha1.SecuresignFulcioStatus{Url: "https://fulcio"},
+		},
+	}
+	fulcio := &rhtasv1alpha1.Fulcio{ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default", Labels: constants.LabelsFor("fulcio", "securesign", "securesign")}}
+	// the external Rekor is not created by the Securesign resource
+	rekor := &rhtasv1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default"}}
+	c := testAction.FakeClientBuilder().WithObjects(instance, rekor).WithStatusSubresource(instance).Build()
+	g.Expect(controllerutil.SetControllerReference(instance, fulcio, c.Scheme())).To(Succeed())
+	g.Expect(c.Create(context.TODO(), fulcio)).To(Succeed())
+
+	a := testAction.PrepareAction(c, NewFulcioAction())
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+	g.Expect(a.Handle(context.TODO(), instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(errors.IsNotFound(c.Get(context.TODO(), client.ObjectKeyFromObject(fulcio), &rhtasv1alpha1.Fulcio{}))).To(BeTrue())
+	g.Expect(a.(*fulcioAction).Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("FulcioRemoved")))
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, FulcioCondition)).To(BeNil())
+	g.Expect(instance.Status.FulcioStatus.Url).To(BeEmpty())
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+
+	a = testAction.PrepareAction(c, NewRekorAction())
+	g.Expect(a.Handle(context.TODO(), instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(rekor), &rhtasv1alpha1.Rekor{})).To(Succeed())
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, RekorCondition)).To(BeNil())
+}
+
+func TestCrossNamespaceComponents(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Securesign{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.SecuresignSpec{Components: rhtasv1alpha1.SecuresignComponents{
+			Trillian: rhtasv1alpha1.SecuresignComponent{Namespace: "trillian"},
+			Fulcio:   rhtasv1alpha1.SecuresignComponent{Namespace: "signing"},
+		}},
+	}
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	for _, a := range []action.Action[*rhtasv1alpha1.Securesign]{NewTrillianAction(), NewFulcioAction(), NewRekorAction(), NewCtlogAction()} {
+		g.Expect(testAction.PrepareAction(c, a).Handle(context.TODO(), instance)).To(Equal(testAction.StatusUpdate()))
+	}
+	// Fulcio is created before the CTlog it connects to, its address is updated on the next reconciliation
+	g.Expect(testAction.PrepareAction(c, NewFulcioAction()).Handle(context.TODO(), instance)).To(Equal(testAction.StatusUpdate()))
+
+	trillian := &rhtasv1alpha1.Trillian{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "trillian", Name: "securesign"}, trillian)).To(Succeed())
+	g.Expect(trillian.OwnerReferences).To(BeEmpty())
+	g.Expect(trillian.Labels).To(HaveKeyWithValue(InstanceNamespaceLabel, "default"))
+	g.Expect(isOwnedBy(instance, trillian)).To(BeTrue())
+	g.Expect(OwnerRequest(context.TODO(), trillian)).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "securesign"}}))
+
+	rekor := &rhtasv1alpha1.Rekor{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "securesign"}, rekor)).To(Succeed())
+	g.Expect(metav1.IsControlledBy(rekor, instance)).To(BeTrue())
+	g.Expect(OwnerRequest(context.TODO(), rekor)).To(BeEmpty())
+	g.Expect(rekor.Spec.Trillian.Address).To(Equal("securesign-trillian-logserver.trillian.svc"))
+
+	ctlog := &rhtasv1alpha1.CTlog{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "securesign"}, ctlog)).To(Succeed())
+	g.Expect(ctlog.Spec.Trillian.Address).To(Equal("securesign-trillian-logserver.trillian.svc"))
+	g.Expect(ctlog.Spec.RootCertificatesNamespace).To(Equal("signing"))
+
+	fulcio := &rhtasv1alpha1.Fulcio{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "signing", Name: "securesign"}, fulcio)).To(Succeed())
+	g.Expect(fulcio.Spec.Ctlog.Address).To(Equal("http://securesign-ctlog.default.svc"))
+
+	keys := []rhtasv1alpha1.TufKey{
+		{Name: "fulcio_v1.crt.pem"},
+		{Name: "rekor.pub"},
+		{Name: "ctfe.pub", SecretRef: &rhtasv1alpha1.SecretKeySelector{Key: "public"}},
+	}
+	g.Expect(keyNamespaces(instance, "default", keys)).To(Equal([]rhtasv1alpha1.TufKey{
+		{Name: "fulcio_v1.crt.pem", Namespace: "signing"},
+		{Name: "rekor.pub"},
+		{Name: "ctfe.pub", SecretRef: &rhtasv1alpha1.SecretKeySelector{Key: "public"}},
+	}))
+
+	g.Expect(DeleteCrossNamespaceComponents(context.TODO(), c, instance)).To(Succeed())
+	g.Expect(errors.IsNotFound(c.Get(context.TODO(), client.ObjectKeyFromObject(trillian), &rhtasv1alpha1.Trillian{}))).To(BeTrue())
+	g.Expect(errors.IsNotFound(c.Get(context.TODO(), client.ObjectKeyFromObject(fulcio), &rhtasv1alpha1.Fulcio{}))).To(BeTrue())
+	// the components in the namespace of the Securesign resource are garbage collected
+	g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(rekor), &rhtasv1alpha1.Rekor{})).To(Succeed())
+}
+
+func TestMoveComponent(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+	instance := &rhtasv1alpha1.Securesign{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.SecuresignSpec{Components: rhtasv1alpha1.SecuresignComponents{
+			Trillian: rhtasv1alpha1.SecuresignComponent{Namespace: "first"},
+		}},
+	}
+	// a Trillian of another Securesign resource with the same name
+	other := &rhtasv1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "other", Labels: map[string]string{
+		"app.kubernetes.io/instance": "securesign",
+		InstanceNamespaceLabel:       "other",
+	}}}
+	c := testAction.FakeClientBuilder().WithObjects(instance, other).WithStatusSubresource(instance).Build()
+	trillian := func(namespace string) error {
+		return c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "securesign"}, &rhtasv1alpha1.Trillian{})
+	}
+
+	a := testAction.PrepareAction(c, NewTrillianAction())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(trillian("first")).To(Succeed())
+	events := a.(*trillianAction).Recorder.(*record.FakeRecorder).Events
+	g.Expect(events).To(Receive(ContainSubstring("TrillianCreated")))
+
+	// moved to another namespace
+	instance.Spec.Components.Trillian.Namespace = "second"
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(trillian("second")).To(Succeed())
+	g.Expect(errors.IsNotFound(trillian("first"))).To(BeTrue())
+	g.Expect(events).To(Receive(ContainSubstring("TrillianRemoved")))
+
+	// moved back to the namespace of the Securesign resource
+	instance.Spec.Components.Trillian.Namespace = ""
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(trillian("default")).To(Succeed())
+	g.Expect(errors.IsNotFound(trillian("second"))).To(BeTrue())
+	g.Expect(trillian("other")).To(Succeed())
+
+	// moved away from the namespace of the Securesign resource, the owned resource is not garbage collected
+	instance.Spec.Components.Trillian.Namespace = "first"
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(trillian("first")).To(Succeed())
+	g.Expect(errors.IsNotFound(trillian("default"))).To(BeTrue())
+	g.Expect(trillian("other")).To(Succeed())
+}
+
+func TestEnabledKeys(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Securesign{Spec: rhtasv1alpha1.SecuresignSpec{Components: rhtasv1alpha1.SecuresignComponents{
+		Fulcio: rhtasv1alpha1.SecuresignComponent{Enabled: ptr.To(false)},
+		Ctlog:  rhtasv1alpha1.SecuresignComponent{Enabled: ptr.To(false)},
+	}}}
+	keys := []rhtasv1alpha1.TufKey{
+		{Name: "rekor.pub"},
+		{Name: "ctfe.pub"},
+		{Name: "fulcio_v1.crt.pem"},
+		{Name: "ctfe-external.pub", Usage: "CTFE", SecretRef: &rhtasv1alpha1.SecretKeySelector{Key: "public"}},
+	}
+	g.Expect(enabledKeys(instance, keys)).To(Equal([]rhtasv1alpha1.TufKey{keys[0], keys[3]}))
+}
+
+func TestSortByStatus(t *testing.T) {
+	g := NewWithT(t)
+	conditions := []metav1.Condition{
+		{Type: TrillianCondition, Reason: constants.Ready},
+		{Type: FulcioCondition, Reason: constants.Creating},
+		{Type: RekorCondition, Reason: constants.Failure},
+		{Type: TufCondition, Reason: constants.Initialize},
+	}
+	g.Expect(sortByStatus(conditions, []string{TrillianCondition, FulcioCondition, RekorCondition, CTlogCondition, TufCondition})).
+		To(Equal([]string{RekorCondition, CTlogCondition, FulcioCondition, TufCondition, TrillianCondition}))
+	g.Expect(sortByStatus(conditions, []string{TrillianCondition, TufCondition})).To(Equal([]string{TufCondition, TrillianCondition}))
+}
+
+func TestCopyStatus(t *testing.T) {
+	g := NewWithT(t)
+	caCert, _, err := utils.CreateCACertificate("fulcio", time.Hour)
+	g.Expect(err).ToNot(HaveOccurred())
+	caRef := &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "fulcio-ca"}, Key: "cert"}
+	instance := &rhtasv1alpha1.Securesign{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default"},
+		Status: rhtasv1alpha1.SecuresignStatus{Conditions: []metav1.Condition{
+			{Type: FulcioCondition, Status: metav1.ConditionFalse, Reason: constants.Creating},
+		}},
+	}
+	fulcio := &rhtasv1alpha1.Fulcio{
+		ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: "default"},
+		Status: rhtasv1alpha1.FulcioStatus{
+			Url:         "https://fulcio",
+			Certificate: &rhtasv1alpha1.FulcioCert{CARef: caRef},
+			Conditions:  []metav1.Condition{{Type: constants.Ready, Status: metav1.ConditionFalse, Reason: constants.Initialize}},
+		},
+	}
+	c := testAction.FakeClientBuilder().
+		WithObjects(instance, fulcio, kubernetes.CreateSecret("fulcio-ca", "default", map[string][]byte{"cert": caCert}, nil)).
+		WithStatusSubresource(instance).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	a := fulcioAction{}
+	a.InjectClient(c)
+	a.InjectRecorder(recorder)
+
+	g.Expect(a.CopyStatus(context.TODO(), client.ObjectKeyFromObject(fulcio), instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, FulcioCondition).Reason).To(Equal(constants.Initialize))
+	g.Expect(instance.Status.FulcioStatus.Url).To(Equal("https://fulcio"))
+	g.Expect(instance.Status.FulcioStatus.CARef).To(Equal(caRef))
+	g.Expect(instance.Status.FulcioStatus.CAExpiration).ToNot(BeNil())
+	g.Expect(instance.Status.FulcioStatus.CAExpiration.Time).To(BeTemporally(">", time.Now()))
+	g.Expect(recorder.Events).To(Receive(Equal("Normal FulcioInitialize Fulcio resource securesign: Initialize")))
+
+	// nothing changed
+	g.Expect(a.CopyStatus(context.TODO(), client.ObjectKeyFromObject(fulcio), instance)).To(BeNil())
+}
+
+func TestStatusChangedPredicate(t *testing.T) {
+	g := NewWithT(t)
+	p := StatusChangedPredicate()
+	old := &rhtasv1alpha1.Rekor{Status: rhtasv1alpha1.RekorStatus{Url: "https://rekor"}}
+
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"label": "value"}
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeFalse())
+
+	updated.Status.Conditions = []metav1.Condition{{Type: constants.Ready, Status: metav1.ConditionTrue, Reason: constants.Ready}}
+	g.Expect(p.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated})).To(BeTrue())
+}
@@ -11,4 +11,6 @@ const (
 	SegmentRBACName          = "rhtas-segment-backup-job"
 	MetricsCondition         = "MetricsAvailable"
 	AnalyiticsCronSchedule   = " 0 0 * * *"
+	// InstanceNamespaceLabel records the namespace of the Securesign resource on the resources it can't own
+	InstanceNamespaceLabel = "app.kubernetes.io/instance-namespace"
 )
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/securesign/operator/internal/controller/annotations"
 
@@ -9,10 +10,10 @@ import (
 	"github.com/securesign/operator/internal/controller/common/action"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/ctlog/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func NewCtlogAction() action.Action[*rhtasv1alpha1.Securesign] {
@@ -27,11 +28,19 @@ func (i ctlogAction) Name() string {
 	return "create ctlog"
 }
 
-func (i ctlogAction) CanHandle(context.Context, *rhtasv1alpha1.Securesign) bool {
-	return true
+func (i ctlogAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Securesign) bool {
+	return !isRemoved(instance, instance.Spec.Components.Ctlog, CTlogCondition)
 }
 
 func (i ctlogAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
+	if !instance.Spec.Components.Ctlog.IsManaged() {
+		if err := removeComponent(ctx, i.Client, i.Recorder, instance, "CTlog", &rhtasv1alpha1.CTlogList{}, CTlogCondition); err != nil {
+			return i.Failed(err)
+		}
+		instance.Status.CTlogStatus = rhtasv1alpha1.SecuresignCTlogStatus{}
+		return i.StatusUpdate(ctx, instance)
+	}
+
 	var (
 		err     error
 		updated bool
@@ -39,13 +48,28 @@ func (i ctlogAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secures
 	ctlog := &rhtasv1alpha1.CTlog{}
 
 	ctlog.Name = instance.Name
-	ctlog.Namespace = instance.Namespace
+	ctlog.Namespace = componentNamespace(instance, instance.Spec.Components.Ctlog)
 	ctlog.Labels = constants.LabelsFor(actions.ComponentName, ctlog.Name, instance.Name)
 	ctlog.Annotations = annotations.FilterInheritable(instance.Annotations)
 
-	ctlog.Spec = instance.Spec.Ctlog
+	ctlog.Spec = *instance.Spec.Ctlog.DeepCopy()
+	rhtasv1alpha1.DefaultCTlogSpec(&ctlog.Spec)
+
+	if owner := crossNamespaceOwner(instance, instance.Spec.Components.Trillian, ctlog.Namespace); owner != nil && ctlog.Spec.Trillian.Address == "" {
+		if ctlog.Spec.Trillian.Address, err = trillianUtils.LogserverAddress(ctx, i.Client, owner); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian address: %w", err))
+		}
+	}
+	if owner := crossNamespaceOwner(instance, instance.Spec.Components.Fulcio, ctlog.Namespace); owner != nil && len(ctlog.Spec.RootCertificates) == 0 && ctlog.Spec.RootCertificatesNamespace == "" {
+		ctlog.Spec.RootCertificatesNamespace = owner.GetNamespace()
+	}
 
-	if err = controllerutil.SetControllerReference(instance, ctlog, i.Client.Scheme()); err != nil {
+	// the resource is left in the previous namespace when the namespace of the component changes
+	if err = removeStaleComponents(ctx, i.Client, i.Recorder, instance, "CTlog", ctlog.Namespace, &rhtasv1alpha1.CTlogList{}); err != nil {
+		return i.Failed(err)
+	}
+
+	if err = setOwner(instance, ctlog, i.Client.Scheme()); err != nil {
 		return i.Failed(err)
 	}
 
@@ -60,6 +84,7 @@ func (i ctlogAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secures
 	}
 
 	if updated {
+		recordEnsured(i.Recorder, instance, "CTlog", ctlog)
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:    CTlogCondition,
 			Status:  v1.ConditionFalse,
@@ -73,21 +98,15 @@ func (i ctlogAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secures
 }
 
 func (i ctlogAction) CopyStatus(ctx context.Context, ok client.ObjectKey, instance *rhtasv1alpha1.Securesign) *action.Result {
-	ctl := &rhtasv1alpha1.CTlog{}
-	if err := i.Client.Get(ctx, ok, ctl); err != nil {
+	object := &rhtasv1alpha1.CTlog{}
+	if err := i.Client.Get(ctx, ok, object); err != nil {
 		return i.Failed(err)
 	}
-	objectStatus := meta.FindStatusCondition(ctl.Status.Conditions, constants.Ready)
-	if objectStatus == nil {
-		// not initialized yet, wait for update
-		return i.Continue()
+	status := rhtasv1alpha1.SecuresignCTlogStatus{
+		TreeID:       object.Status.TreeID,
+		PublicKeyRef: object.Status.PublicKeyRef,
 	}
-	if !meta.IsStatusConditionPresentAndEqual(instance.Status.Conditions, CTlogCondition, objectStatus.Status) {
-		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-			Type:   CTlogCondition,
-			Status: objectStatus.Status,
-			Reason: objectStatus.Reason,
-		})
+	if copyComponentStatus(i.Recorder, instance, "CTlog", CTlogCondition, object.Status.Conditions, &instance.Status.CTlogStatus, status) {
 		return i.StatusUpdate(ctx, instance)
 	}
 	return i.Continue()
@@ -2,17 +2,20 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/securesign/operator/internal/controller/annotations"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
+	ctlogUtils "github.com/securesign/operator/internal/controller/ctlog/utils"
 	"github.com/securesign/operator/internal/controller/fulcio/actions"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func NewFulcioAction() action.Action[*rhtasv1alpha1.Securesign] {
@@ -27,11 +30,19 @@ func (i fulcioAction) Name() string {
 	return "create fulcio"
 }
 
-func (i fulcioAction) CanHandle(context.Context, *rhtasv1alpha1.Securesign) bool {
-	return true
+func (i fulcioAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Securesign) bool {
+	return !isRemoved(instance, instance.Spec.Components.Fulcio, FulcioCondition)
 }
 
 func (i fulcioAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
+	if !instance.Spec.Components.Fulcio.IsManaged() {
+		if err := removeComponent(ctx, i.Client, i.Recorder, instance, "Fulcio", &rhtasv1alpha1.FulcioList{}, FulcioCondition); err != nil {
+			return i.Failed(err)
+		}
+		instance.Status.FulcioStatus = rhtasv1alpha1.SecuresignFulcioStatus{}
+		return i.StatusUpdate(ctx, instance)
+	}
+
 	var (
 		err     error
 		updated bool
@@ -39,13 +50,23 @@ func (i fulcioAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secure
 	fulcio := &rhtasv1alpha1.Fulcio{}
 
 	fulcio.Name = instance.Name
-	fulcio.Namespace = instance.Namespace
+	fulcio.Namespace = componentNamespace(instance, instance.Spec.Components.Fulcio)
 	fulcio.Labels = constants.LabelsFor(actions.ComponentName, fulcio.Name, instance.Name)
 	fulcio.Annotations = annotations.FilterInheritable(instance.Annotations)
 
-	fulcio.Spec = instance.Spec.Fulcio
+	fulcio.Spec = *instance.Spec.Fulcio.DeepCopy()
+	if owner := crossNamespaceOwner(instance, instance.Spec.Components.Ctlog, fulcio.Namespace); owner != nil && fulcio.Spec.Ctlog.Address == "" {
+		if fulcio.Spec.Ctlog.Address, err = ctlogUtils.ServiceAddress(ctx, i.Client, owner); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve CTlog address: %w", err))
+		}
+	}
+
+	// the resource is left in the previous namespace when the namespace of the component changes
+	if err = removeStaleComponents(ctx, i.Client, i.Recorder, instance, "Fulcio", fulcio.Namespace, &rhtasv1alpha1.FulcioList{}); err != nil {
+		return i.Failed(err)
+	}
 
-	if err = controllerutil.SetControllerReference(instance, fulcio, i.Client.Scheme()); err != nil {
+	if err = setOwner(instance, fulcio, i.Client.Scheme()); err != nil {
 		return i.Failed(err)
 	}
 
@@ -60,6 +81,7 @@ func (i fulcioAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secure
 	}
 
 	if updated {
+		recordEnsured(i.Recorder, instance, "Fulcio", fulcio)
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:    FulcioCondition,
 			Status:  v1.ConditionFalse,
@@ -77,21 +99,31 @@ func (i fulcioAction) CopyStatus(ctx context.Context, ok client.ObjectKey, insta
 	if err := i.Client.Get(ctx, ok, object); err != nil {
 		return i.Failed(err)
 	}
-	objectStatus := meta.FindStatusCondition(object.Status.Conditions, constants.Ready)
-	if objectStatus == nil {
-		// not initialized yet, wait for update
-		return i.Continue()
-	}
-	if !meta.IsStatusConditionPresentAndEqual(instance.Status.Conditions, FulcioCondition, objectStatus.Status) {
-		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-			Type:   FulcioCondition,
-			Status: objectStatus.Status,
-			Reason: objectStatus.Reason,
-		})
-		if objectStatus.Status == v1.ConditionTrue {
-			instance.Status.FulcioStatus.Url = object.Status.Url
+	status := rhtasv1alpha1.SecuresignFulcioStatus{Url: object.Status.Url}
+	if object.Status.Certificate != nil && object.Status.Certificate.CARef != nil {
+		status.CARef = object.Status.Certificate.CARef
+		expiration, err := caExpiration(i.Client, object.Namespace, status.CARef)
+		if err != nil {
+			i.Logger.Error(err, "could not read Fulcio CA certificate")
 		}
+		status.CAExpiration = expiration
+	}
+	if copyComponentStatus(i.Recorder, instance, "Fulcio", FulcioCondition, object.Status.Conditions, &instance.Status.FulcioStatus, status) {
 		return i.StatusUpdate(ctx, instance)
 	}
 	return i.Continue()
 }
+
+// caExpiration returns the expiration of the CA certificate
+func caExpiration(c client.Client, namespace string, caRef *rhtasv1alpha1.SecretKeySelector) (*v1.Time, error) {
+	data, err := k8sutils.GetSecretData(c, namespace, caRef)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := utils.ParseCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+	expiration := v1.NewTime(cert.NotAfter)
+	return &expiration, nil
+}
@@ -2,16 +2,17 @@ package actions
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/securesign/operator/internal/controller/annotations"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
 	"github.com/securesign/operator/internal/controller/constants"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func NewRekorAction() action.Action[*rhtasv1alpha1.Securesign] {
@@ -26,11 +27,19 @@ func (i rekorAction) Name() string {
 	return "create rekor"
 }
 
-func (i rekorAction) CanHandle(context.Context, *rhtasv1alpha1.Securesign) bool {
-	return true
+func (i rekorAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Securesign) bool {
+	return !isRemoved(instance, instance.Spec.Components.Rekor, RekorCondition)
 }
 
 func (i rekorAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
+	if !instance.Spec.Components.Rekor.IsManaged() {
+		if err := removeComponent(ctx, i.Client, i.Recorder, instance, "Rekor", &rhtasv1alpha1.RekorList{}, RekorCondition); err != nil {
+			return i.Failed(err)
+		}
+		instance.Status.RekorStatus = rhtasv1alpha1.SecuresignRekorStatus{}
+		return i.StatusUpdate(ctx, instance)
+	}
+
 	var (
 		err     error
 		updated bool
@@ -38,13 +47,25 @@ func (i rekorAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secures
 	rekor := &rhtasv1alpha1.Rekor{}
 
 	rekor.Name = instance.Name
-	rekor.Namespace = instance.Namespace
+	rekor.Namespace = componentNamespace(instance, instance.Spec.Components.Rekor)
 	rekor.Labels = constants.LabelsFor("rekor", rekor.Name, instance.Name)
 	rekor.Annotations = annotations.FilterInheritable(instance.Annotations)
 
-	rekor.Spec = instance.Spec.Rekor
+	rekor.Spec = *instance.Spec.Rekor.DeepCopy()
+	rhtasv1alpha1.DefaultRekorSpec(&rekor.Spec)
+
+	if owner := crossNamespaceOwner(instance, instance.Spec.Components.Trillian, rekor.Namespace); owner != nil && rekor.Spec.Trillian.Address == "" {
+		if rekor.Spec.Trillian.Address, err = trillianUtils.LogserverAddress(ctx, i.Client, owner); err != nil {
+			return i.Failed(fmt.Errorf("could not resolve Trillian address: %w", err))
+		}
+	}
 
-	if err = controllerutil.SetControllerReference(instance, rekor, i.Client.Scheme()); err != nil {
+	// the resource is left in the previous namespace when the namespace of the component changes
+	if err = removeStaleComponents(ctx, i.Client, i.Recorder, instance, "Rekor", rekor.Namespace, &rhtasv1alpha1.RekorList{}); err != nil {
+		return i.Failed(err)
+	}
+
+	if err = setOwner(instance, rekor, i.Client.Scheme()); err != nil {
 		return i.Failed(err)
 	}
 
@@ -59,6 +80,7 @@ func (i rekorAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secures
 	}
 
 	if updated {
+		recordEnsured(i.Recorder, instance, "Rekor", rekor)
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:    RekorCondition,
 			Status:  v1.ConditionFalse,
@@ -76,20 +98,13 @@ func (i rekorAction) CopyStatus(ctx context.Context, ok client.ObjectKey, instan
 	if err := i.Client.Get(ctx, ok, object); err != nil {
 		return i.Failed(err)
 	}
-	objectStatus := meta.FindStatusCondition(object.Status.Conditions, constants.Ready)
-	if objectStatus == nil {
-		// not initialized yet, wait for update
-		return i.Continue()
+	status := rhtasv1alpha1.SecuresignRekorStatus{
+		Url:              object.Status.Url,
+		RekorSearchUIUrl: object.Status.RekorSearchUIUrl,
+		TreeID:           object.Status.TreeID,
+		Sharding:         object.Spec.Sharding,
 	}
-	if !meta.IsStatusConditionPresentAndEqual(instance.Status.Conditions, RekorCondition, objectStatus.Status) {
-		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-			Type:   RekorCondition,
-			Status: objectStatus.Status,
-			Reason: objectStatus.Reason,
-		})
-		if objectStatus.Status == v1.ConditionTrue {
-			instance.Status.RekorStatus.Url = object.Status.Url
-		}
+	if copyComponentStatus(i.Recorder, instance, "Rekor", RekorCondition, object.Status.Conditions, &instance.Status.RekorStatus, status) {
 		return i.StatusUpdate(ctx, instance)
 	}
 	return i.Continue()
@@ -11,7 +11,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func NewTrillianAction() action.Action[*rhtasv1alpha1.Securesign] {
@@ -26,11 +25,19 @@ func (i trillianAction) Name() string {
 	return "create trillian"
 }
 
-func (i trillianAction) CanHandle(context.Context, *rhtasv1alpha1.Securesign) bool {
-	return true
+func (i trillianAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Securesign) bool {
+	return !isRemoved(instance, instance.Spec.Components.Trillian, TrillianCondition)
 }
 
 func (i trillianAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
+	if !instance.Spec.Components.Trillian.IsManaged() {
+		if err := removeComponent(ctx, i.Client, i.Recorder, instance, "Trillian", &rhtasv1alpha1.TrillianList{}, TrillianCondition); err != nil {
+			return i.Failed(err)
+		}
+		instance.Status.TrillianStatus = rhtasv1alpha1.SecuresignTrillianStatus{}
+		return i.StatusUpdate(ctx, instance)
+	}
+
 	var (
 		err     error
 		updated bool
@@ -38,13 +45,19 @@ func (i trillianAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secu
 	trillian := &rhtasv1alpha1.Trillian{}
 
 	trillian.Name = instance.Name
-	trillian.Namespace = instance.Namespace
+	trillian.Namespace = componentNamespace(instance, instance.Spec.Components.Trillian)
 	trillian.Labels = constants.LabelsFor("trillian", trillian.Name, instance.Name)
 	trillian.Annotations = annotations.FilterInheritable(instance.Annotations)
 
-	trillian.Spec = instance.Spec.Trillian
+	trillian.Spec = *instance.Spec.Trillian.DeepCopy()
+	rhtasv1alpha1.DefaultTrillianSpec(&trillian.Spec)
+
+	// the resource is left in the previous namespace when the namespace of the component changes
+	if err = removeStaleComponents(ctx, i.Client, i.Recorder, instance, "Trillian", trillian.Namespace, &rhtasv1alpha1.TrillianList{}); err != nil {
+		return i.Failed(err)
+	}
 
-	if err = controllerutil.SetControllerReference(instance, trillian, i.Client.Scheme()); err != nil {
+	if err = setOwner(instance, trillian, i.Client.Scheme()); err != nil {
 		return i.Failed(err)
 	}
 
@@ -59,6 +72,7 @@ func (i trillianAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Secu
 	}
 
 	if updated {
+		recordEnsured(i.Recorder, instance, "Trillian", trillian)
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:    TrillianCondition,
 			Status:  v1.ConditionFalse,
@@ -76,17 +90,10 @@ func (i trillianAction) CopyStatus(ctx context.Context, ok client.ObjectKey, ins
 	if err := i.Client.Get(ctx, ok, object); err != nil {
 		return i.Failed(err)
 	}
-	objectStatus := meta.FindStatusCondition(object.Status.Conditions, constants.Ready)
-	if objectStatus == nil {
-		// not initialized yet, wait for update
-		return i.Continue()
+	status := rhtasv1alpha1.SecuresignTrillianStatus{
+		DatabaseSecretRef: object.Status.Db.DatabaseSecretRef,
 	}
-	if meta.FindStatusCondition(instance.Status.Conditions, TrillianCondition).Reason != objectStatus.Reason {
-		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-			Type:   TrillianCondition,
-			Status: objectStatus.Status,
-			Reason: objectStatus.Reason,
-		})
+	if copyComponentStatus(i.Recorder, instance, "Trillian", TrillianCondition, object.Status.Conditions, &instance.Status.TrillianStatus, status) {
 		return i.StatusUpdate(ctx, instance)
 	}
 	return i.Continue()
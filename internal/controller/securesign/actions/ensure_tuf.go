@@ -12,7 +12,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 func NewTufAction() action.Action[*rhtasv1alpha1.Securesign] {
@@ -27,11 +26,19 @@ func (i tufAction) Name() string {
 	return "create tuf"
 }
 
-func (i tufAction) CanHandle(context.Context, *rhtasv1alpha1.Securesign) bool {
-	return true
+func (i tufAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Securesign) bool {
+	return !isRemoved(instance, instance.Spec.Components.Tuf, TufCondition)
 }
 
 func (i tufAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
+	if !instance.Spec.Components.Tuf.IsManaged() {
+		if err := removeComponent(ctx, i.Client, i.Recorder, instance, "Tuf", &rhtasv1alpha1.TufList{}, TufCondition); err != nil {
+			return i.Failed(err)
+		}
+		instance.Status.TufStatus = rhtasv1alpha1.SecuresignTufStatus{}
+		return i.StatusUpdate(ctx, instance)
+	}
+
 	var (
 		err     error
 		updated bool
@@ -39,13 +46,26 @@ func (i tufAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesig
 	tuf := &rhtasv1alpha1.Tuf{}
 
 	tuf.Name = instance.Name
-	tuf.Namespace = instance.Namespace
+	tuf.Namespace = componentNamespace(instance, instance.Spec.Components.Tuf)
 	tuf.Labels = constants.LabelsFor(actions.ComponentName, tuf.Name, instance.Name)
 	tuf.Annotations = annotations.FilterInheritable(instance.Annotations)
 
-	tuf.Spec = instance.Spec.Tuf
+	tuf.Spec = *instance.Spec.Tuf.DeepCopy()
+	rhtasv1alpha1.DefaultTufSpec(&tuf.Spec)
+	tuf.Spec.Keys = enabledKeys(instance, tuf.Spec.Keys)
+	if tuf.Spec.Repository != nil {
+		tuf.Spec.Keys = keyNamespaces(instance, tuf.Namespace, tuf.Spec.Keys)
+	}
+	if tuf.Spec.Repository != nil && tuf.Spec.Mirror == nil {
+		tuf.Spec.Repository.TrustedRoot = trustedRoot(instance, tuf.Spec.Repository.TrustedRoot)
+	}
+
+	// the resource is left in the previous namespace when the namespace of the component changes
+	if err = removeStaleComponents(ctx, i.Client, i.Recorder, instance, "Tuf", tuf.Namespace, &rhtasv1alpha1.TufList{}); err != nil {
+		return i.Failed(err)
+	}
 
-	if err = controllerutil.SetControllerReference(instance, tuf, i.Client.Scheme()); err != nil {
+	if err = setOwner(instance, tuf, i.Client.Scheme()); err != nil {
 		return i.Failed(err)
 	}
 
@@ -60,6 +80,7 @@ func (i tufAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesig
 	}
 
 	if updated {
+		recordEnsured(i.Recorder, instance, "Tuf", tuf)
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:    TufCondition,
 			Status:  v1.ConditionFalse,
@@ -77,21 +98,81 @@ func (i tufAction) CopyStatus(ctx context.Context, ok client.ObjectKey, instance
 	if err := i.Client.Get(ctx, ok, object); err != nil {
 		return i.Failed(err)
 	}
-	objectStatus := meta.FindStatusCondition(object.Status.Conditions, constants.Ready)
-	if objectStatus == nil {
-		// not initialized yet, wait for update
-		return i.Continue()
+	status := rhtasv1alpha1.SecuresignTufStatus{
+		Url:  object.Status.Url,
+		Keys: object.Status.Keys,
 	}
-	if !meta.IsStatusConditionPresentAndEqual(instance.Status.Conditions, TufCondition, objectStatus.Status) {
-		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-			Type:   TufCondition,
-			Status: objectStatus.Status,
-			Reason: objectStatus.Reason,
-		})
-		if objectStatus.Status == v1.ConditionTrue {
-			instance.Status.TufStatus.Url = object.Status.Url
-		}
+	if copyComponentStatus(i.Recorder, instance, "Tuf", TufCondition, object.Status.Conditions, &instance.Status.TufStatus, status) {
 		return i.StatusUpdate(ctx, instance)
 	}
 	return i.Continue()
 }
+
+// trustedRoot fills the unset service URLs and Rekor shards of the trusted root from the Securesign resource
+func trustedRoot(instance *rhtasv1alpha1.Securesign, trustedRoot *rhtasv1alpha1.TufTrustedRoot) *rhtasv1alpha1.TufTrustedRoot {
+	if trustedRoot == nil {
+		trustedRoot = &rhtasv1alpha1.TufTrustedRoot{}
+	}
+	if trustedRoot.FulcioURL == "" {
+		trustedRoot.FulcioURL = instance.Status.FulcioStatus.Url
+	}
+	if trustedRoot.RekorURL == "" {
+		trustedRoot.RekorURL = instance.Status.RekorStatus.Url
+	}
+	if issuers := instance.Spec.Fulcio.Config.OIDCIssuers; trustedRoot.OIDCURL == "" && len(issuers) > 0 {
+		trustedRoot.OIDCURL = issuers[0].IssuerURL
+		if trustedRoot.OIDCURL == "" {
+			trustedRoot.OIDCURL = issuers[0].Issuer
+		}
+	}
+	if len(trustedRoot.RekorSharding) == 0 {
+		trustedRoot.RekorSharding = instance.Spec.Rekor.Sharding
+	}
+	return trustedRoot
+}
+
+// keyComponents returns the components serving the autoconfigured keys by their usage
+func keyComponents(instance *rhtasv1alpha1.Securesign) map[string]rhtasv1alpha1.SecuresignComponent {
+	return map[string]rhtasv1alpha1.SecuresignComponent{
+		"Fulcio": instance.Spec.Components.Fulcio,
+		"Rekor":  instance.Spec.Components.Rekor,
+		"CTFE":   instance.Spec.Components.Ctlog,
+	}
+}
+
+// keyUsage returns the usage of the key, derived from its name when unset
+func keyUsage(key rhtasv1alpha1.TufKey) string {
+	if key.Usage != "" {
+		return key.Usage
+	}
+	return rhtasv1alpha1.TargetUsage(key.Name)
+}
+
+// keyNamespaces points the autoconfigured keys of the components deployed in another namespace than the TUF
+// resource to the namespace of the component
+func keyNamespaces(instance *rhtasv1alpha1.Securesign, namespace string, keys []rhtasv1alpha1.TufKey) []rhtasv1alpha1.TufKey {
+	components := keyComponents(instance)
+	for i, key := range keys {
+		component, ok := components[keyUsage(key)]
+		if !ok || key.SecretRef != nil || key.Namespace != "" || !component.IsManaged() {
+			continue
+		}
+		if ns := componentNamespace(instance, component); ns != namespace {
+			keys[i].Namespace = ns
+		}
+	}
+	return keys
+}
+
+// enabledKeys drops the autoconfigured keys of the disabled components
+func enabledKeys(instance *rhtasv1alpha1.Securesign, keys []rhtasv1alpha1.TufKey) []rhtasv1alpha1.TufKey {
+	components := keyComponents(instance)
+	enabled := make([]rhtasv1alpha1.TufKey, 0, len(keys))
+	for _, key := range keys {
+		if component, ok := components[keyUsage(key)]; ok && key.SecretRef == nil && !component.IsEnabled() {
+			continue
+		}
+		enabled = append(enabled, key)
+	}
+	return enabled
+}
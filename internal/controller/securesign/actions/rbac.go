@@ -9,6 +9,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	v1 "k8s.io/api/core/v1"
@@ -19,8 +20,8 @@ import (
 )
 
 const (
-	namespacedNamePattern  = SegmentRBACName + "-%s"
-	clusterWideNamePattern = SegmentRBACName + "-%s" + "-%s"
+	namespacedNamePattern  = "%s-%s"
+	clusterWideNamePattern = "%s-%s-%s"
 	OpenshiftMonitoringNS  = "openshift-monitoring"
 )
 
@@ -51,11 +52,12 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 	var err error
 
 	labels := constants.LabelsFor(SegmentBackupJobName, SegmentBackupCronJobName, instance.Name)
-	labels["app.kubernetes.io/instance-namespace"] = instance.Namespace
+	labels[InstanceNamespaceLabel] = instance.Namespace
+	sa := utils.ResourceName(instance, SegmentRBACName)
 
 	serviceAccount := &v1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      SegmentRBACName,
+			Name:      sa,
 			Namespace: instance.Namespace,
 			Labels:    labels,
 		},
@@ -81,7 +83,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 
 	openshiftMonitoringSBJRole := kubernetes.CreateRole(
 		OpenshiftMonitoringNS,
-		fmt.Sprintf(namespacedNamePattern, instance.Namespace),
+		fmt.Sprintf(namespacedNamePattern, sa, instance.Namespace),
 		labels,
 		[]rbacv1.PolicyRule{
 			{
@@ -114,15 +116,15 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 
 	openshiftMonitoringSBJRoleBinding := kubernetes.CreateRoleBinding(
 		OpenshiftMonitoringNS,
-		fmt.Sprintf(namespacedNamePattern, instance.Namespace),
+		fmt.Sprintf(namespacedNamePattern, sa, instance.Namespace),
 		labels,
 		rbacv1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
 			Kind:     "Role",
-			Name:     fmt.Sprintf(namespacedNamePattern, instance.Namespace),
+			Name:     fmt.Sprintf(namespacedNamePattern, sa, instance.Namespace),
 		},
 		[]rbacv1.Subject{
-			{Kind: "ServiceAccount", Name: SegmentRBACName, Namespace: instance.Namespace},
+			{Kind: "ServiceAccount", Name: sa, Namespace: instance.Namespace},
 		})
 	if _, err = i.Ensure(ctx, openshiftMonitoringSBJRoleBinding); err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
@@ -141,7 +143,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 	}
 
 	openshiftMonitoringClusterRoleBinding := kubernetes.CreateClusterRoleBinding(
-		fmt.Sprintf(clusterWideNamePattern, instance.Namespace, "clusterMonitoringRoleBinding"),
+		fmt.Sprintf(clusterWideNamePattern, sa, instance.Namespace, "clusterMonitoringRoleBinding"),
 		labels,
 		rbacv1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
@@ -149,7 +151,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 			Name:     "cluster-monitoring-view",
 		},
 		[]rbacv1.Subject{
-			{Kind: "ServiceAccount", Name: SegmentRBACName, Namespace: instance.Namespace},
+			{Kind: "ServiceAccount", Name: sa, Namespace: instance.Namespace},
 		})
 	if _, err = i.Ensure(ctx, openshiftMonitoringClusterRoleBinding); err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
@@ -168,7 +170,7 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 	}
 
 	openshiftConsoleSBJRole := kubernetes.CreateClusterRole(
-		fmt.Sprintf(clusterWideNamePattern, instance.Namespace, "clusterRole"),
+		fmt.Sprintf(clusterWideNamePattern, sa, instance.Namespace, "clusterRole"),
 		labels,
 		[]rbacv1.PolicyRule{
 			{
@@ -201,15 +203,15 @@ func (i rbacAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesi
 	}
 
 	openshiftConsoleSBJRolebinding := kubernetes.CreateClusterRoleBinding(
-		fmt.Sprintf(clusterWideNamePattern, instance.Namespace, "clusterRoleBinding"),
+		fmt.Sprintf(clusterWideNamePattern, sa, instance.Namespace, "clusterRoleBinding"),
 		labels,
 		rbacv1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
 			Kind:     "ClusterRole",
-			Name:     fmt.Sprintf(clusterWideNamePattern, instance.Namespace, "clusterRole"),
+			Name:     fmt.Sprintf(clusterWideNamePattern, sa, instance.Namespace, "clusterRole"),
 		},
 		[]rbacv1.Subject{
-			{Kind: "ServiceAccount", Name: SegmentRBACName, Namespace: instance.Namespace},
+			{Kind: "ServiceAccount", Name: sa, Namespace: instance.Namespace},
 		})
 	if _, err = i.Ensure(ctx, openshiftConsoleSBJRolebinding); err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
@@ -19,6 +19,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 
 	"github.com/operator-framework/operator-lib/proxy"
 )
@@ -59,7 +60,7 @@ func (i segmentBackupCronJob) Handle(ctx context.Context, instance *rhtasv1alpha
 
 	segmentBackupCronJob := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      SegmentBackupCronJobName,
+			Name:      utils.ResourceName(instance, SegmentBackupCronJobName),
 			Namespace: instance.Namespace,
 			Labels:    labels,
 		},
@@ -69,7 +70,7 @@ func (i segmentBackupCronJob) Handle(ctx context.Context, instance *rhtasv1alpha
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
 						Spec: corev1.PodSpec{
-							ServiceAccountName: SegmentRBACName,
+							ServiceAccountName: utils.ResourceName(instance, SegmentRBACName),
 							RestartPolicy:      "OnFailure",
 							Containers: []corev1.Container{
 								{
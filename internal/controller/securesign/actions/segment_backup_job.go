@@ -11,6 +11,7 @@ import (
 	"context"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	corev1 "k8s.io/api/core/v1"
@@ -86,7 +87,7 @@ func (i segmentBackupJob) Handle(ctx context.Context, instance *rhtasv1alpha1.Se
 	env = append(env, proxy.ReadProxyVarsFromEnv()...)
 
 	// Logic to delete old SBJ to avoid SECURESIGN-1207, can be removed after next release
-	if sbj, err := kubernetes.GetJob(ctx, i.Client, instance.Namespace, SegmentBackupJobName); sbj != nil {
+	if sbj, err := kubernetes.GetJob(ctx, i.Client, instance.Namespace, utils.ResourceName(instance, SegmentBackupJobName)); sbj != nil {
 		if err = i.Client.Delete(ctx, sbj); err != nil {
 			i.Logger.Error(err, "problem with removing SBJ resources", "namespace", instance.Namespace, "name", SegmentBackupJobName)
 		}
@@ -94,7 +95,7 @@ func (i segmentBackupJob) Handle(ctx context.Context, instance *rhtasv1alpha1.Se
 		i.Logger.Error(err, "unable to retrieve SBJ resource", "namespace", instance.Namespace, "name", SegmentBackupJobName)
 	}
 
-	job := kubernetes.CreateJob(instance.Namespace, SegmentBackupJobName, labels, constants.SegmentBackupImage, SegmentRBACName, parallelism, completions, activeDeadlineSeconds, backoffLimit, command, env)
+	job := kubernetes.CreateJob(instance.Namespace, utils.ResourceName(instance, SegmentBackupJobName), labels, constants.SegmentBackupImage, utils.ResourceName(instance, SegmentRBACName), parallelism, completions, activeDeadlineSeconds, backoffLimit, command, env)
 	if err = ctrl.SetControllerReference(instance, job, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for Job: %w", err))
 	}
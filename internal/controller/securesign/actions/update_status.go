@@ -2,6 +2,7 @@ package actions
 
 import (
 	"context"
+	"slices"
 	"sort"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
@@ -28,13 +29,13 @@ func (i updateStatusAction) CanHandle(ctx context.Context, instance *rhtasv1alph
 }
 
 func (i updateStatusAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Securesign) *action.Result {
-	sorted := sortByStatus(instance.Status.Conditions)
+	sorted := sortByStatus(instance.Status.Conditions, managedConditions(instance))
 
-	if !meta.IsStatusConditionTrue(instance.Status.Conditions, sorted[0]) {
+	if len(sorted) > 0 && !meta.IsStatusConditionTrue(instance.Status.Conditions, sorted[0]) {
 		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
 			Type:   constants.Ready,
 			Status: v1.ConditionFalse,
-			Reason: meta.FindStatusCondition(instance.Status.Conditions, sorted[0]).Reason,
+			Reason: findCondition(instance.Status.Conditions, sorted[0]).Reason,
 		})
 		return i.StatusUpdate(ctx, instance)
 	}
@@ -49,21 +50,35 @@ func (i updateStatusAction) Handle(ctx context.Context, instance *rhtasv1alpha1.
 	return i.Continue()
 }
 
-func sortByStatus(conditions []v1.Condition) []string {
-	sorted := []string{TrillianCondition, FulcioCondition, RekorCondition, CTlogCondition, TufCondition}
+// sortByStatus sorts the conditions of the components from the least to the most ready one
+func sortByStatus(conditions []v1.Condition, components []string) []string {
+	sorted := slices.Clone(components)
 	sort.SliceStable(sorted, func(i, j int) bool {
-		iCondition := meta.FindStatusCondition(conditions, sorted[i])
-		jCondition := meta.FindStatusCondition(conditions, sorted[j])
-		switch iCondition.Reason {
-		case constants.Initialize:
-			return jCondition.Reason == constants.Ready
-		case constants.Creating:
-			return jCondition.Reason == constants.Ready || jCondition.Reason == constants.Initialize
-		case constants.Pending:
-			return jCondition.Reason == constants.Ready || jCondition.Reason == constants.Initialize || jCondition.Reason == constants.Creating
-		default:
-			return true
-		}
+		return readiness(findCondition(conditions, sorted[i])) < readiness(findCondition(conditions, sorted[j]))
 	})
 	return sorted
 }
+
+// readiness ranks the reason of the condition, failures and unknown reasons rank the lowest
+func readiness(condition v1.Condition) int {
+	switch condition.Reason {
+	case constants.Pending:
+		return 1
+	case constants.Creating:
+		return 2
+	case constants.Initialize:
+		return 3
+	case constants.Ready:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// findCondition returns the condition of the component, a pending one when it is not set yet
+func findCondition(conditions []v1.Condition, conditionType string) v1.Condition {
+	if c := meta.FindStatusCondition(conditions, conditionType); c != nil {
+		return *c
+	}
+	return v1.Condition{Type: conditionType, Status: v1.ConditionUnknown, Reason: constants.Pending}
+}
@@ -22,18 +22,24 @@ import (
 	v12 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
-	"github.com/operator-framework/operator-lib/predicate"
+	olpredicate "github.com/operator-framework/operator-lib/predicate"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/annotations"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/action/transitions"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/securesign/actions"
 	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -44,7 +50,8 @@ const (
 // SecuresignReconciler reconciles a Securesign object
 type SecuresignReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=securesigns,verbs=get;list;watch;create;update;patch;delete
@@ -73,7 +80,9 @@ type SecuresignReconciler struct {
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;get;list;watch;update;patch
 //+kubebuilder:rbac:groups="operator.openshift.io",resources=consoles,verbs=get;list
 
-// TODO: rework Securesign controller to watch resources
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The Securesign resource is reconciled again whenever the spec or the status of one of its components changes.
 func (r *SecuresignReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var instance rhtasv1alpha1.Securesign
 	log := ctrllog.FromContext(ctx)
@@ -97,8 +106,12 @@ func (r *SecuresignReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	if instance.DeletionTimestamp != nil {
+		// components in other namespaces are not garbage collected with the Securesign resource
+		if err := actions.DeleteCrossNamespaceComponents(ctx, r.Client, &instance); err != nil {
+			return ctrl.Result{}, err
+		}
 		labels := constants.LabelsFor(actions.SegmentBackupJobName, actions.SegmentBackupCronJobName, instance.Name)
-		labels["app.kubernetes.io/instance-namespace"] = instance.Namespace
+		labels[actions.InstanceNamespaceLabel] = instance.Namespace
 		if err := r.Client.DeleteAllOf(ctx, &v1.ClusterRoleBinding{}, client.MatchingLabels(labels)); err != nil {
 			log.Error(err, "problem with removing clusterRoleBinding resource")
 		}
@@ -123,6 +136,9 @@ func (r *SecuresignReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	acs := []action.Action[*rhtasv1alpha1.Securesign]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.Securesign](func(_ *rhtasv1alpha1.Securesign) client.Object {
+			return &v12.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: actions.SegmentRBACName}}
+		}),
 		actions.NewInitializeStatusAction(),
 		actions.NewTrillianAction(),
 		actions.NewFulcioAction(),
@@ -133,11 +149,13 @@ func (r *SecuresignReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		actions.NewSegmentBackupJobAction(),
 		actions.NewSegmentBackupCronJobAction(),
 		actions.NewUpdateStatusAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.Securesign](),
 	}
 
 	for _, a := range acs {
 		a.InjectClient(r.Client)
 		a.InjectLogger(log.WithName(a.Name()))
+		a.InjectRecorder(r.Recorder)
 
 		if a.CanHandle(ctx, target) {
 			result := a.Handle(ctx, target)
@@ -152,18 +170,26 @@ func (r *SecuresignReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 // SetupWithManager sets up the controller with the Manager.
 func (r *SecuresignReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Filter out with the pause annotation.
-	pause, err := predicate.NewPause(annotations.PausedReconciliation)
+	pause, err := olpredicate.NewPause(annotations.PausedReconciliation)
 	if err != nil {
 		return err
 	}
+	// the status of the components is aggregated as soon as it changes
+	changed := builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, actions.StatusChangedPredicate()))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithEventFilter(pause).
 		For(&rhtasv1alpha1.Securesign{}).
-		Owns(&rhtasv1alpha1.Fulcio{}).
-		Owns(&rhtasv1alpha1.Rekor{}).
-		Owns(&rhtasv1alpha1.Tuf{}).
-		Owns(&rhtasv1alpha1.Trillian{}).
-		Owns(&rhtasv1alpha1.CTlog{}).
+		Owns(&rhtasv1alpha1.Fulcio{}, changed).
+		Owns(&rhtasv1alpha1.Rekor{}, changed).
+		Owns(&rhtasv1alpha1.Tuf{}, changed).
+		Owns(&rhtasv1alpha1.Trillian{}, changed).
+		Owns(&rhtasv1alpha1.CTlog{}, changed).
+		// the components deployed in other namespaces are not owned, they are mapped through their labels
+		Watches(&rhtasv1alpha1.Fulcio{}, handler.EnqueueRequestsFromMapFunc(actions.OwnerRequest), changed).
+		Watches(&rhtasv1alpha1.Rekor{}, handler.EnqueueRequestsFromMapFunc(actions.OwnerRequest), changed).
+		Watches(&rhtasv1alpha1.Tuf{}, handler.EnqueueRequestsFromMapFunc(actions.OwnerRequest), changed).
+		Watches(&rhtasv1alpha1.Trillian{}, handler.EnqueueRequestsFromMapFunc(actions.OwnerRequest), changed).
+		Watches(&rhtasv1alpha1.CTlog{}, handler.EnqueueRequestsFromMapFunc(actions.OwnerRequest), changed).
 		Complete(r)
 }
@@ -5,21 +5,35 @@ const (
 	DbPvcName               = "trillian-mysql"
 	LogserverDeploymentName = "trillian-logserver"
 	LogsignerDeploymentName = "trillian-logsigner"
+	EtcdDeploymentName      = "trillian-etcd"
+	DbBackupCronJobName     = "trillian-db-backup"
+	DbRestoreJobName        = "trillian-db-restore"
+	DbUpgradeBackupJobName  = "trillian-db-pre-upgrade"
+	DbUpgradeBackupPvcName  = "trillian-db-pre-upgrade"
+	DbMigrationJobName      = "trillian-db-migration"
 
 	DbComponentName         = "trillian-db"
 	LogServerComponentName  = "trillian-logserver"
 	LogServerMonitoringName = "prometheus-k8s-logserver"
 	LogSignerComponentName  = "trillian-logsigner"
 	LogSignerMonitoringName = "prometheus-k8s-logsigner"
+	EtcdComponentName       = "trillian-etcd"
+	DbBackupComponentName   = "trillian-db-backup"
 
 	RBACName = "trillian"
 
 	DbCondition     = "DBAvailable"
 	ServerCondition = "LogServerAvailable"
 	SignerCondition = "LogSignerAvailable"
+	// ElectionCondition reports the managed etcd backing the Logsigner master election and the etcd quotas
+	ElectionCondition = "ElectionAvailable"
+	// RestoreCondition reports the result of the database restore triggered by the restore annotation
+	RestoreCondition = "BackupRestored"
 
 	ServerPort      = 8091
 	ServerPortName  = "grpc"
 	MetricsPort     = 8090
 	MetricsPortName = "metrics"
+	EtcdPort        = 2379
+	EtcdPortName    = "etcd"
 )
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robfig/cron/v3"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewBackupAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &backupAction{}
+}
+
+type backupAction struct {
+	action.BaseAction
+}
+
+func (i backupAction) Name() string {
+	return "backup"
+}
+
+func (i backupAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return (c.Reason == constants.Creating || c.Reason == constants.Ready) &&
+		(instance.Spec.Db.Backup != nil || instance.Status.Backup != nil)
+}
+
+func (i backupAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var (
+		err     error
+		updated bool
+	)
+
+	backup := instance.Spec.Db.Backup
+	if backup == nil || !utils.OptionalBool(instance.Spec.Db.Create) {
+		return i.cleanup(ctx, instance)
+	}
+
+	if _, err = cron.ParseStandard(backup.Schedule); err != nil {
+		return i.Failed(fmt.Errorf("could not create database backup cron job: %w", err))
+	}
+
+	labels := constants.LabelsFor(actions.DbBackupComponentName, actions.DbBackupCronJobName, instance.Name)
+
+	if backup.S3 == nil {
+		var created bool
+		if created, err = ensureBackupPvc(ctx, i.Client, instance, backup, labels); err != nil {
+			return i.Failed(err)
+		}
+		if created {
+			i.Recorder.Event(instance, v1.EventTypeNormal, "PersistentVolumeCreated", "New PersistentVolume for database backups created")
+		}
+	}
+
+	db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+	if err != nil {
+		return i.Failed(err)
+	}
+	cronJob, err := trillianUtils.CreateBackupCronJob(instance, utils.ResourceName(instance, actions.DbBackupCronJobName), utils.ResourceName(instance, actions.RBACName), labels, db)
+	if err != nil {
+		return i.Failed(err)
+	}
+
+	if err = controllerutil.SetControllerReference(instance, cronJob, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for database backup cron job: %w", err))
+	}
+
+	if updated, err = i.Ensure(ctx, cronJob); err != nil {
+		return i.Failed(fmt.Errorf("could not create database backup cron job: %w", err))
+	}
+
+	if updated || instance.Status.Backup == nil {
+		i.Recorder.Event(instance, v1.EventTypeNormal, "BackupScheduled", "Database backup cron job scheduled")
+		if instance.Status.Backup == nil {
+			instance.Status.Backup = &rhtasv1alpha1.TrillianDBBackupStatus{}
+		}
+		return i.StatusUpdate(ctx, instance)
+	}
+	return i.Continue()
+}
+
+// ensureBackupPvc creates the PVC the backups are stored to when it does not exist
+func ensureBackupPvc(ctx context.Context, c client.Client, instance *rhtasv1alpha1.Trillian, backup *rhtasv1alpha1.TrillianDBBackup, labels map[string]string) (bool, error) {
+	name := trillianUtils.BackupPvcName(instance, backup)
+	err := c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: name}, &v1.PersistentVolumeClaim{})
+	if err == nil {
+		return false, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("could not read backup PVC: %w", err)
+	}
+	if backup.Pvc == nil || backup.Pvc.Size == nil {
+		return false, fmt.Errorf("backup PVC size is not set")
+	}
+
+	pvc := k8sutils.CreatePVC(instance.Namespace, name, *backup.Pvc.Size, backup.Pvc.StorageClass, labels)
+	if !utils.OptionalBool(backup.Pvc.Retain) {
+		if err = controllerutil.SetControllerReference(instance, pvc, c.Scheme()); err != nil {
+			return false, fmt.Errorf("could not set controller reference for backup PVC: %w", err)
+		}
+	}
+	if err = c.Create(ctx, pvc); err != nil {
+		return false, fmt.Errorf("could not create backup PVC: %w", err)
+	}
+	return true, nil
+}
+
+// cleanup removes the backup cron job once the backup is disabled, the stored backups are kept
+func (i backupAction) cleanup(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	cronJob := &batchv1.CronJob{}
+	cronJob.SetName(utils.ResourceName(instance, actions.DbBackupCronJobName))
+	cronJob.SetNamespace(instance.Namespace)
+	if err := i.Client.Delete(ctx, cronJob, client.PropagationPolicy("Background")); client.IgnoreNotFound(err) != nil {
+		return i.Failed(fmt.Errorf("could not remove database backup cron job: %w", err))
+	}
+	instance.Status.Backup = nil
+	return i.StatusUpdate(ctx, instance)
+}
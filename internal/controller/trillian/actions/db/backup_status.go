@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewBackupStatusAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &backupStatusAction{}
+}
+
+type backupStatusAction struct {
+	action.BaseAction
+}
+
+func (i backupStatusAction) Name() string {
+	return "backup status"
+}
+
+func (i backupStatusAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c.Reason == constants.Ready && instance.Spec.Db.Backup != nil && instance.Status.Backup != nil
+}
+
+func (i backupStatusAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	cronJob := &batchv1.CronJob{}
+	if err := i.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.ResourceName(instance, actions.DbBackupCronJobName)}, cronJob); err != nil {
+		if apierrors.IsNotFound(err) {
+			return i.Continue()
+		}
+		return i.Failed(fmt.Errorf("could not read database backup cron job: %w", err))
+	}
+
+	lastTime := cronJob.Status.LastSuccessfulTime
+	if lastTime == nil || (instance.Status.Backup.LastSuccessfulTime != nil && !instance.Status.Backup.LastSuccessfulTime.Before(lastTime)) {
+		return i.Continue()
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := i.Client.List(ctx, jobs, client.InNamespace(instance.Namespace), client.MatchingLabels(cronJob.Spec.JobTemplate.Labels)); err != nil {
+		return i.Failed(fmt.Errorf("could not list database backup jobs: %w", err))
+	}
+	var last *batchv1.Job
+	for j := range jobs.Items {
+		job := &jobs.Items[j]
+		if job.Status.Succeeded == 0 || job.Status.CompletionTime == nil {
+			continue
+		}
+		if last == nil || last.Status.CompletionTime.Before(job.Status.CompletionTime) {
+			last = job
+		}
+	}
+
+	status := rhtasv1alpha1.TrillianDBBackupStatus{LastSuccessfulTime: lastTime}
+	if last != nil {
+		result, err := trillianUtils.GetJobResult(ctx, i.Client, last)
+		if err != nil {
+			return i.Failed(err)
+		}
+		if result != nil {
+			if name, size, err := trillianUtils.ParseBackupResult(result.Message); err != nil {
+				i.Logger.Error(err, "could not read result of database backup", "job", last.Name)
+			} else {
+				status.LastBackup = name
+				status.LastSize = resource.NewQuantity(size, resource.BinarySI)
+			}
+		}
+	}
+	instance.Status.Backup = &status
+	return i.StatusUpdate(ctx, instance)
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
 	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
@@ -40,8 +43,13 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trilli
 	var (
 		err     error
 		updated bool
+		db      *apps.Deployment
 	)
 
+	if instance.Status.DatabaseImage == "" {
+		return i.initVersion(ctx, instance)
+	}
+
 	labels := constants.LabelsFor(actions.DbComponentName, actions.DbDeploymentName, instance.Name)
 	scc, err := kubernetes.GetOpenshiftPodSecurityContextRestricted(ctx, i.Client, instance.Namespace)
 	if err != nil {
@@ -49,7 +57,10 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trilli
 		scc = &v1.PodSecurityContext{FSGroup: utils.Pointer(int64(1001)), FSGroupChangePolicy: utils.Pointer(v1.FSGroupChangeOnRootMismatch)}
 	}
 
-	db, err := trillianUtils.CreateTrillDb(instance, actions.DbDeploymentName, actions.RBACName, scc, labels)
+	database, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+	if err == nil {
+		db, err = trillianUtils.CreateTrillDb(instance, utils.ResourceName(instance, actions.DbDeploymentName), utils.ResourceName(instance, actions.RBACName), scc, labels, database)
+	}
 	if err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    actions.DbCondition,
@@ -98,3 +109,21 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trilli
 	}
 
 }
+
+// initVersion records the image and the schema version of the database.
+// The database deployed by an older operator keeps its image until the upgrade action rolls the new one out.
+func (i deployAction) initVersion(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	current := &apps.Deployment{}
+	err := i.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.ResourceName(instance, actions.DbDeploymentName)}, current)
+	switch {
+	case err == nil && len(current.Spec.Template.Spec.Containers) > 0:
+		instance.Status.DatabaseImage = current.Spec.Template.Spec.Containers[0].Image
+		instance.Status.SchemaVersion = trillianUtils.BaselineSchemaVersion
+	case err == nil || apierrors.IsNotFound(err):
+		instance.Status.DatabaseImage = trillianUtils.DesiredDbImage(instance)
+		instance.Status.SchemaVersion = trillianUtils.TargetSchemaVersion(trillianUtils.Engine(instance.Spec.Db))
+	default:
+		return i.Failed(fmt.Errorf("could not read database deployment: %w", err))
+	}
+	return i.StatusUpdate(ctx, instance)
+}
@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
-
 	"github.com/securesign/operator/internal/controller/common"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	trillian "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -20,10 +20,20 @@ import (
 )
 
 const (
-	port = 3306
-	host = "trillian-mysql"
+	mysqlPort      = 3306
+	mysqlHost      = "trillian-mysql"
+	postgresqlPort = 5432
+	postgresqlHost = "trillian-postgresql"
 )
 
+// hostPort returns the service name and port of the managed database
+func hostPort(instance *rhtasv1alpha1.Trillian, engine rhtasv1alpha1.DatabaseEngine) (string, int) {
+	if engine == rhtasv1alpha1.DatabaseEnginePostgreSQL {
+		return utils.ResourceName(instance, postgresqlHost), postgresqlPort
+	}
+	return utils.ResourceName(instance, mysqlHost), mysqlPort
+}
+
 func NewHandleSecretAction() action.Action[*rhtasv1alpha1.Trillian] {
 	return &handleSecretAction{}
 }
@@ -58,7 +68,7 @@ func (i handleSecretAction) Handle(ctx context.Context, instance *rhtasv1alpha1.
 	)
 	dbLabels := constants.LabelsFor(trillian.DbComponentName, trillian.DbDeploymentName, instance.Name)
 
-	dbSecret := i.createDbSecret(instance.Namespace, dbLabels)
+	dbSecret := i.createDbSecret(instance, trillianUtils.Engine(instance.Spec.Db), dbLabels)
 	if err = controllerutil.SetControllerReference(instance, dbSecret, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for secret: %w", err))
 	}
@@ -85,26 +95,31 @@ func (i handleSecretAction) Handle(ctx context.Context, instance *rhtasv1alpha1.
 	}
 	return i.StatusUpdate(ctx, instance)
 }
-func (i handleSecretAction) createDbSecret(namespace string, labels map[string]string) *corev1.Secret {
+func (i handleSecretAction) createDbSecret(instance *rhtasv1alpha1.Trillian, engine rhtasv1alpha1.DatabaseEngine, labels map[string]string) *corev1.Secret {
 	// Define a new Secret object
 	var rootPass []byte
-	var mysqlPass []byte
+	var dbPass []byte
 	rootPass = common.GeneratePassword(12)
-	mysqlPass = common.GeneratePassword(12)
+	dbPass = common.GeneratePassword(12)
+	user := "mysql"
+	if engine == rhtasv1alpha1.DatabaseEnginePostgreSQL {
+		user = "trillian"
+	}
+	host, port := hostPort(instance, engine)
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "rhtas",
-			Namespace:    namespace,
+			Namespace:    instance.Namespace,
 			Labels:       labels,
 		},
 		Type: "Opaque",
 		Data: map[string][]byte{
-			"mysql-root-password": rootPass,
-			"mysql-password":      mysqlPass,
-			"mysql-database":      []byte("trillian"),
-			"mysql-user":          []byte("mysql"),
-			"mysql-port":          []byte(strconv.Itoa(port)),
-			"mysql-host":          []byte(host),
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretRootPassword): rootPass,
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretPassword):     dbPass,
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretDatabase):     []byte("trillian"),
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretUser):         []byte(user),
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretPort):         []byte(strconv.Itoa(port)),
+			trillianUtils.DBSecretKey(engine, trillianUtils.DBSecretHost):         []byte(host),
 		},
 	}
 }
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewRestoreAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &restoreAction{}
+}
+
+// restoreAction restores the database backup named by the restore annotation.
+// The annotation is removed once the restore job finished.
+type restoreAction struct {
+	action.BaseAction
+}
+
+func (i restoreAction) Name() string {
+	return "restore"
+}
+
+func (i restoreAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	_, ok := instance.GetAnnotations()[annotations.RestoreBackup]
+	return c.Reason == constants.Ready && ok
+}
+
+func (i restoreAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	backupName := instance.GetAnnotations()[annotations.RestoreBackup]
+	if instance.Spec.Db.Backup == nil {
+		return i.finish(ctx, instance, backupName, errors.New("database backup is not configured"))
+	}
+
+	labels := constants.LabelsFor(actions.DbBackupComponentName, actions.DbRestoreJobName, instance.Name)
+	result, err := trillianUtils.RunJob(ctx, i.Client, instance, instance.Namespace, labels, func() (*batchv1.Job, error) {
+		db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+		if err != nil {
+			return nil, err
+		}
+		return trillianUtils.CreateRestoreJob(instance, utils.ResourceName(instance, actions.DbRestoreJobName), utils.ResourceName(instance, actions.RBACName), labels, db, backupName)
+	})
+	if err != nil {
+		return i.Failed(fmt.Errorf("could not restore database backup: %w", err))
+	}
+	if result == nil {
+		// the job is running, its completion triggers the reconcile
+		if c := meta.FindStatusCondition(instance.Status.Conditions, actions.RestoreCondition); c != nil && c.Reason == constants.Creating {
+			return i.Continue()
+		}
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "RestoreStarted", "Restore of database backup %s started", backupName)
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.RestoreCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Creating,
+			Message: fmt.Sprintf("Restoring backup %s", backupName),
+		})
+		return i.StatusUpdate(ctx, instance)
+	}
+	if !result.Succeeded {
+		return i.finish(ctx, instance, backupName, errors.New(result.Message))
+	}
+	// the job reports the resolved name of the latest backup
+	if result.Message != "" {
+		backupName = result.Message
+	}
+	return i.finish(ctx, instance, backupName, nil)
+}
+
+// finish removes the restore annotation and reports the result of the restore
+func (i restoreAction) finish(ctx context.Context, instance *rhtasv1alpha1.Trillian, backupName string, restoreErr error) *action.Result {
+	condition := metav1.Condition{
+		Type:    actions.RestoreCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  constants.Ready,
+		Message: fmt.Sprintf("Backup %s restored", backupName),
+	}
+	if restoreErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = constants.Failure
+		condition.Message = fmt.Sprintf("Restore of backup %s failed: %s", backupName, restoreErr.Error())
+	}
+
+	// the patch refreshes the instance, the status is updated afterwards
+	patch := client.MergeFrom(instance.DeepCopy())
+	delete(instance.Annotations, annotations.RestoreBackup)
+	if err := i.Client.Patch(ctx, instance, patch); err != nil {
+		return i.Failed(fmt.Errorf("could not remove restore annotation: %w", err))
+	}
+
+	if restoreErr != nil {
+		i.Recorder.Event(instance, v1.EventTypeWarning, "RestoreFailed", condition.Message)
+	} else {
+		i.Recorder.Event(instance, v1.EventTypeNormal, "RestoreSucceeded", condition.Message)
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, condition)
+	return i.StatusUpdate(ctx, instance)
+}
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestRestore(t *testing.T) {
+	size := resource.MustParse("1Gi")
+	newInstance := func() *rhtasv1alpha1.Trillian {
+		return &rhtasv1alpha1.Trillian{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "trillian",
+				Namespace:   "default",
+				UID:         "uid",
+				Annotations: map[string]string{annotations.RestoreBackup: "latest"},
+			},
+			Spec: rhtasv1alpha1.TrillianSpec{
+				Db: rhtasv1alpha1.TrillianDB{Create: ptr.To(true), Backup: &rhtasv1alpha1.TrillianDBBackup{
+					Schedule: "@daily", Retention: 7, Pvc: &rhtasv1alpha1.Pvc{Size: &size},
+				}},
+			},
+			Status: rhtasv1alpha1.TrillianStatus{
+				Db:         rhtasv1alpha1.TrillianDB{DatabaseSecretRef: &rhtasv1alpha1.LocalObjectReference{Name: "db"}},
+				Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Ready}},
+			},
+		}
+	}
+	dbSecret := &core.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}}
+	labels := constants.LabelsFor(actions.DbBackupComponentName, actions.DbRestoreJobName, "trillian")
+	restoreJob := func(owner *rhtasv1alpha1.Trillian, status batchv1.JobStatus) *batchv1.Job {
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian-db-restore-abcde", Namespace: "default", Labels: labels},
+			Status:     status,
+		}
+		_ = controllerutil.SetControllerReference(owner, job, testAction.FakeClientBuilder().Build().Scheme())
+		return job
+	}
+	jobPod := func(exitCode int32, message string) *core.Pod {
+		return &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian-db-restore-abcde-x", Namespace: "default", Labels: map[string]string{"job-name": "trillian-db-restore-abcde"}},
+			Status: core.PodStatus{ContainerStatuses: []core.ContainerStatus{{
+				State: core.ContainerState{Terminated: &core.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+			}}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		objects func(*rhtasv1alpha1.Trillian) []client.Object
+		verify  func(Gomega, client.WithWatch, *rhtasv1alpha1.Trillian)
+	}{
+		{
+			name: "create restore job",
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(1))
+				g.Expect(metav1.IsControlledBy(&list.Items[0], instance)).To(BeTrue())
+				g.Expect(meta.FindStatusCondition(instance.Status.Conditions, actions.RestoreCondition)).To(
+					HaveField("Reason", constants.Creating))
+				g.Expect(instance.Annotations).To(HaveKey(annotations.RestoreBackup))
+			},
+		},
+		{
+			name: "restore job is running",
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return []client.Object{restoreJob(owner, batchv1.JobStatus{Active: 1})}
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Annotations).To(HaveKey(annotations.RestoreBackup))
+			},
+		},
+		{
+			name: "restore succeeded",
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return []client.Object{
+					restoreJob(owner, batchv1.JobStatus{Succeeded: 1}),
+					jobPod(0, "trillian-20240101000000.sql.gz"),
+				}
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, actions.RestoreCondition)).To(BeTrue())
+				g.Expect(meta.FindStatusCondition(instance.Status.Conditions, actions.RestoreCondition).Message).To(
+					ContainSubstring("trillian-20240101000000.sql.gz"))
+
+				stored := &rhtasv1alpha1.Trillian{}
+				g.Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(instance), stored)).To(Succeed())
+				g.Expect(stored.Annotations).ToNot(HaveKey(annotations.RestoreBackup))
+
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(BeEmpty())
+			},
+		},
+		{
+			name: "restore failed",
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return []client.Object{
+					restoreJob(owner, batchv1.JobStatus{Failed: 1, Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: core.ConditionTrue}}}),
+					jobPod(1, "backup latest not found"),
+				}
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				condition := meta.FindStatusCondition(instance.Status.Conditions, actions.RestoreCondition)
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Reason).To(Equal(constants.Failure))
+				g.Expect(condition.Message).To(ContainSubstring("backup latest not found"))
+				g.Expect(instance.Annotations).ToNot(HaveKey(annotations.RestoreBackup))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := newInstance()
+			objects := []client.Object{instance, dbSecret}
+			if tt.objects != nil {
+				objects = append(objects, tt.objects(instance)...)
+			}
+			c := testAction.FakeClientBuilder().
+				WithObjects(objects...).
+				WithStatusSubresource(instance, &batchv1.Job{}).
+				Build()
+			a := testAction.PrepareAction(c, NewRestoreAction())
+
+			g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+			result := a.Handle(context.TODO(), instance)
+			g.Expect(testAction.IsFailed(result)).To(BeFalse())
+			tt.verify(g, c, instance)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+// postgresqlSchema is the PostgreSQL schema of Trillian v1.7.1 (storage/postgresql/schema/storage.sql).
+// It must match the Trillian version of TrillianServerImage and TrillianLogSignerImage.
+//
+//go:embed schema/postgresql.sql
+var postgresqlSchema string
+
+func NewCreateSchemaAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &createSchemaAction{}
+}
+
+type createSchemaAction struct {
+	action.BaseAction
+}
+
+func (i createSchemaAction) Name() string {
+	return "create schema"
+}
+
+func (i createSchemaAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	// MySQL schema is initialized by the database image or by the DB administrator
+	return (c.Reason == constants.Creating || c.Reason == constants.Ready) && trillianUtils.Engine(instance.Spec.Db) == rhtasv1alpha1.DatabaseEnginePostgreSQL
+}
+
+func (i createSchemaAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var err error
+
+	labels := constants.LabelsFor(actions.DbComponentName, actions.DbDeploymentName, instance.Name)
+	schema := k8sutils.CreateConfigmap(instance.Namespace, utils.ResourceName(instance, trillianUtils.PostgresqlSchemaConfigMap), labels,
+		map[string]string{trillianUtils.PostgresqlSchemaKey: postgresqlSchema})
+
+	if err = controllerutil.SetControllerReference(instance, schema, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for schema ConfigMap: %w", err))
+	}
+
+	if _, err = i.Ensure(ctx, schema); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create Trillian DB schema: %w", err), instance)
+	}
+
+	// the schema is applied by the Trillian deployments, DB condition is not affected
+	return i.Continue()
+}
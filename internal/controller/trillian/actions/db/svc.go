@@ -4,12 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
-
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -42,13 +42,20 @@ func (i createServiceAction) Handle(ctx context.Context, instance *rhtasv1alpha1
 	)
 
 	labels := constants.LabelsFor(actions.DbComponentName, actions.DbDeploymentName, instance.Name)
-	mysql := k8sutils.CreateService(instance.Namespace, host, host, port, port, labels)
+	engine := trillianUtils.Engine(instance.Spec.Db)
+	host, port := hostPort(instance, engine)
+	// port name is limited to 15 characters
+	portName := mysqlHost
+	if engine == rhtasv1alpha1.DatabaseEnginePostgreSQL {
+		portName = string(engine)
+	}
+	svc := k8sutils.CreateService(instance.Namespace, host, portName, port, int32(port), labels)
 
-	if err = controllerutil.SetControllerReference(instance, mysql, i.Client.Scheme()); err != nil {
+	if err = controllerutil.SetControllerReference(instance, svc, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for DB service: %w", err))
 	}
 
-	if updated, err = i.Ensure(ctx, mysql); err != nil {
+	if updated, err = i.Ensure(ctx, svc); err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
 			Type:    actions.DbCondition,
 			Status:  metav1.ConditionFalse,
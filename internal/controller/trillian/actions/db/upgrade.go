@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+// UpgradeRetryInterval is the time a failed upgrade is retried after
+const UpgradeRetryInterval = 10 * time.Minute
+
+func NewUpgradeAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &upgradeAction{}
+}
+
+// upgradeAction upgrades the managed database to the image and the schema version the operator is shipped with.
+// It takes a pre-upgrade backup, applies the schema migrations and then rolls the new image out.
+type upgradeAction struct {
+	action.BaseAction
+}
+
+func (i upgradeAction) Name() string {
+	return "upgrade"
+}
+
+func (i upgradeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if c.Reason != constants.Ready || !utils.OptionalBool(instance.Spec.Db.Create) || instance.Status.DatabaseImage == "" {
+		return false
+	}
+	upgrade := instance.Status.DatabaseUpgrade
+	return needsUpgrade(instance) || (upgrade != nil && upgrade.Phase == rhtasv1alpha1.TrillianDBUpgradeRollout)
+}
+
+func (i upgradeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	upgrade := instance.Status.DatabaseUpgrade
+	image, version := trillianUtils.DesiredDbImage(instance), trillianUtils.TargetSchemaVersion(trillianUtils.Engine(instance.Spec.Db))
+
+	if upgrade == nil || upgrade.Phase == rhtasv1alpha1.TrillianDBUpgradeCompleted || upgrade.Image != image || upgrade.SchemaVersion != version {
+		instance.Status.DatabaseUpgrade = &rhtasv1alpha1.TrillianDBUpgrade{
+			FromImage:     instance.Status.DatabaseImage,
+			Image:         image,
+			SchemaVersion: version,
+		}
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "DatabaseUpgradeStarted", "Upgrade of the database to schema version %d started", version)
+		return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeBackup, "Taking pre-upgrade backup")
+	}
+
+	switch upgrade.Phase {
+	case rhtasv1alpha1.TrillianDBUpgradeBackup:
+		return i.backup(ctx, instance)
+	case rhtasv1alpha1.TrillianDBUpgradeMigrate:
+		return i.migrate(ctx, instance)
+	case rhtasv1alpha1.TrillianDBUpgradeRollout:
+		return i.rollout(ctx, instance)
+	case rhtasv1alpha1.TrillianDBUpgradeFailed:
+		if wait := time.Until(upgrade.LastTransitionTime.Add(UpgradeRetryInterval)); wait > 0 {
+			return &action.Result{Result: reconcile.Result{RequeueAfter: wait}}
+		}
+		return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeBackup, "Retrying pre-upgrade backup")
+	}
+	return i.Continue()
+}
+
+// backup takes the pre-upgrade backup to the target of the scheduled backups or to a dedicated PVC
+func (i upgradeAction) backup(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	backup := instance.Spec.Db.Backup
+	if backup == nil {
+		backup = &rhtasv1alpha1.TrillianDBBackup{
+			Retention: 1,
+			Pvc: &rhtasv1alpha1.Pvc{
+				Name:         utils.ResourceName(instance, actions.DbUpgradeBackupPvcName),
+				Size:         instance.Spec.Db.Pvc.Size,
+				StorageClass: instance.Spec.Db.Pvc.StorageClass,
+				Retain:       utils.Pointer(true),
+			},
+		}
+	}
+
+	labels := constants.LabelsFor(actions.DbBackupComponentName, actions.DbUpgradeBackupJobName, instance.Name)
+	result, err := trillianUtils.RunJob(ctx, i.Client, instance, instance.Namespace, labels, func() (*batchv1.Job, error) {
+		if backup.S3 == nil {
+			if _, err := ensureBackupPvc(ctx, i.Client, instance, backup, labels); err != nil {
+				return nil, err
+			}
+		}
+		db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+		if err != nil {
+			return nil, err
+		}
+		return trillianUtils.CreateBackupJob(instance, utils.ResourceName(instance, actions.DbUpgradeBackupJobName), utils.ResourceName(instance, actions.RBACName), labels, db, backup), nil
+	})
+	switch {
+	case err != nil:
+		return i.fail(ctx, instance, fmt.Errorf("pre-upgrade backup failed: %w", err))
+	case result == nil:
+		return i.Continue()
+	case !result.Succeeded:
+		return i.fail(ctx, instance, fmt.Errorf("pre-upgrade backup failed: %s", result.Message))
+	}
+
+	name, _, err := trillianUtils.ParseBackupResult(result.Message)
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	instance.Status.DatabaseUpgrade.Backup = name
+	return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeMigrate, fmt.Sprintf("Pre-upgrade backup %s taken, migrating schema", name))
+}
+
+// migrate applies the schema migrations with the client tools of the new image
+func (i upgradeAction) migrate(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	upgrade := instance.Status.DatabaseUpgrade
+	labels := constants.LabelsFor(actions.DbComponentName, actions.DbMigrationJobName, instance.Name)
+
+	migrations := k8sutils.CreateConfigmap(instance.Namespace, utils.ResourceName(instance, trillianUtils.MigrationsConfigMap), labels,
+		trillianUtils.SchemaMigrations(trillianUtils.Engine(instance.Spec.Db)))
+	if err := controllerutil.SetControllerReference(instance, migrations, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for migrations ConfigMap: %w", err))
+	}
+	if _, err := i.Ensure(ctx, migrations); err != nil {
+		return i.Failed(fmt.Errorf("could not create migrations ConfigMap: %w", err))
+	}
+
+	result, err := trillianUtils.RunJob(ctx, i.Client, instance, instance.Namespace, labels, func() (*batchv1.Job, error) {
+		db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+		if err != nil {
+			return nil, err
+		}
+		return trillianUtils.CreateMigrationJob(instance, utils.ResourceName(instance, actions.DbMigrationJobName), utils.ResourceName(instance, actions.RBACName), labels, db, upgrade.Image), nil
+	})
+	switch {
+	case err != nil:
+		return i.fail(ctx, instance, fmt.Errorf("schema migration failed: %w", err))
+	case result == nil:
+		return i.Continue()
+	case !result.Succeeded:
+		return i.fail(ctx, instance, fmt.Errorf("schema migration failed: %s", result.Message))
+	}
+
+	version, err := trillianUtils.ParseSchemaVersion(result.Message)
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	if version < upgrade.SchemaVersion {
+		return i.fail(ctx, instance, fmt.Errorf("schema migrated to version %d, expected %d", version, upgrade.SchemaVersion))
+	}
+	instance.Status.SchemaVersion = version
+	// the deploy action rolls the image out
+	instance.Status.DatabaseImage = upgrade.Image
+	return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeRollout, fmt.Sprintf("Schema migrated to version %d, rolling out the database image", version))
+}
+
+// rollout waits until the database runs the new image
+func (i upgradeAction) rollout(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	dp := &apps.Deployment{}
+	if err := i.Client.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.ResourceName(instance, actions.DbDeploymentName)}, dp); err != nil {
+		return i.Failed(fmt.Errorf("could not read database deployment: %w", err))
+	}
+	if !rolledOut(dp, instance.Status.DatabaseUpgrade.Image) {
+		// the deployment status change triggers the reconcile
+		return i.Continue()
+	}
+
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    actions.DbCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  constants.Ready,
+		Message: "Database upgraded",
+	})
+	i.Recorder.Eventf(instance, v1.EventTypeNormal, "DatabaseUpgraded", "Database upgraded to schema version %d", instance.Status.SchemaVersion)
+	return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeCompleted, "Database upgraded")
+}
+
+func (i upgradeAction) transition(ctx context.Context, instance *rhtasv1alpha1.Trillian, phase rhtasv1alpha1.TrillianDBUpgradePhase, message string) *action.Result {
+	instance.Status.DatabaseUpgrade.Phase = phase
+	instance.Status.DatabaseUpgrade.Message = message
+	instance.Status.DatabaseUpgrade.LastTransitionTime = metav1.Now()
+	return i.StatusUpdate(ctx, instance)
+}
+
+func (i upgradeAction) fail(ctx context.Context, instance *rhtasv1alpha1.Trillian, err error) *action.Result {
+	i.Logger.Error(err, "database upgrade failed")
+	i.Recorder.Event(instance, v1.EventTypeWarning, "DatabaseUpgradeFailed", err.Error())
+	return i.transition(ctx, instance, rhtasv1alpha1.TrillianDBUpgradeFailed, err.Error())
+}
+
+// needsUpgrade returns true when the database runs an older image or schema than the operator is shipped with
+func needsUpgrade(instance *rhtasv1alpha1.Trillian) bool {
+	return instance.Status.DatabaseImage != trillianUtils.DesiredDbImage(instance) ||
+		instance.Status.SchemaVersion < trillianUtils.TargetSchemaVersion(trillianUtils.Engine(instance.Spec.Db))
+}
+
+// rolledOut returns true when all the replicas of the deployment run the image
+func rolledOut(dp *apps.Deployment, image string) bool {
+	if len(dp.Spec.Template.Spec.Containers) == 0 || dp.Spec.Template.Spec.Containers[0].Image != image {
+		return false
+	}
+	replicas := int32(1)
+	if dp.Spec.Replicas != nil {
+		replicas = *dp.Spec.Replicas
+	}
+	return dp.Status.ObservedGeneration >= dp.Generation &&
+		dp.Status.UpdatedReplicas == replicas &&
+		dp.Status.AvailableReplicas == replicas &&
+		dp.Status.Replicas == replicas
+}
@@ -0,0 +1,211 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestUpgrade(t *testing.T) {
+	const oldImage = "trillian-db:old"
+	size := resource.MustParse("5Gi")
+	newInstance := func(upgrade *rhtasv1alpha1.TrillianDBUpgrade) *rhtasv1alpha1.Trillian {
+		return &rhtasv1alpha1.Trillian{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default", UID: "uid"},
+			Spec: rhtasv1alpha1.TrillianSpec{
+				Db: rhtasv1alpha1.TrillianDB{Create: ptr.To(true), Pvc: rhtasv1alpha1.Pvc{Size: &size}},
+			},
+			Status: rhtasv1alpha1.TrillianStatus{
+				Db:              rhtasv1alpha1.TrillianDB{DatabaseSecretRef: &rhtasv1alpha1.LocalObjectReference{Name: "db"}},
+				DatabaseImage:   oldImage,
+				SchemaVersion:   trillianUtils.BaselineSchemaVersion,
+				DatabaseUpgrade: upgrade,
+				Conditions:      []metav1.Condition{{Type: constants.Ready, Reason: constants.Ready}},
+			},
+		}
+	}
+	inPhase := func(phase rhtasv1alpha1.TrillianDBUpgradePhase) *rhtasv1alpha1.TrillianDBUpgrade {
+		return &rhtasv1alpha1.TrillianDBUpgrade{
+			FromImage:          oldImage,
+			Image:              constants.TrillianDbImage,
+			SchemaVersion:      trillianUtils.TargetSchemaVersion(rhtasv1alpha1.DatabaseEngineMySQL),
+			Phase:              phase,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	finishedJob := func(owner *rhtasv1alpha1.Trillian, name string, succeeded bool, message string) []client.Object {
+		labels := constants.LabelsFor(actions.DbBackupComponentName, actions.DbUpgradeBackupJobName, owner.Name)
+		if name == actions.DbMigrationJobName {
+			labels = constants.LabelsFor(actions.DbComponentName, actions.DbMigrationJobName, owner.Name)
+		}
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name + "-abcde", Namespace: "default", Labels: labels}}
+		exitCode := int32(0)
+		if succeeded {
+			job.Status.Succeeded = 1
+		} else {
+			exitCode = 1
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: core.ConditionTrue}}
+		}
+		_ = controllerutil.SetControllerReference(owner, job, testAction.FakeClientBuilder().Build().Scheme())
+		pod := &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-abcde-x", Namespace: "default", Labels: map[string]string{"job-name": job.Name}},
+			Status: core.PodStatus{ContainerStatuses: []core.ContainerStatus{{
+				State: core.ContainerState{Terminated: &core.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+			}}},
+		}
+		return []client.Object{job, pod}
+	}
+	dbDeployment := func(image string, available int32) *apps.Deployment {
+		return &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian-" + actions.DbDeploymentName, Namespace: "default"},
+			Spec: apps.DeploymentSpec{
+				Replicas: ptr.To(int32(1)),
+				Template: core.PodTemplateSpec{Spec: core.PodSpec{Containers: []core.Container{{Name: "db", Image: image}}}},
+			},
+			Status: apps.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: available},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		upgrade *rhtasv1alpha1.TrillianDBUpgrade
+		objects func(*rhtasv1alpha1.Trillian) []client.Object
+		verify  func(Gomega, client.WithWatch, *rhtasv1alpha1.Trillian)
+	}{
+		{
+			name: "start upgrade",
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade).ToNot(BeNil())
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeBackup))
+				g.Expect(instance.Status.DatabaseUpgrade.FromImage).To(Equal(oldImage))
+				g.Expect(instance.Status.DatabaseUpgrade.Image).To(Equal(constants.TrillianDbImage))
+			},
+		},
+		{
+			name:    "create pre-upgrade backup job",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeBackup),
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeBackup))
+
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(1))
+				g.Expect(list.Items[0].Spec.Template.Spec.Containers[0].Image).To(Equal(oldImage))
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "trillian-" + actions.DbUpgradeBackupPvcName}, &core.PersistentVolumeClaim{})).To(Succeed())
+			},
+		},
+		{
+			name:    "pre-upgrade backup taken",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeBackup),
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return finishedJob(owner, actions.DbUpgradeBackupJobName, true, "trillian-20240101000000.sql.gz 1024")
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeMigrate))
+				g.Expect(instance.Status.DatabaseUpgrade.Backup).To(Equal("trillian-20240101000000.sql.gz"))
+			},
+		},
+		{
+			name:    "schema migrated",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeMigrate),
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return finishedJob(owner, actions.DbMigrationJobName, true, "1")
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeRollout))
+				g.Expect(instance.Status.DatabaseImage).To(Equal(constants.TrillianDbImage))
+				g.Expect(instance.Status.SchemaVersion).To(Equal(int32(1)))
+				g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "trillian-" + trillianUtils.MigrationsConfigMap}, &core.ConfigMap{})).To(Succeed())
+			},
+		},
+		{
+			name:    "schema migration failed",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeMigrate),
+			objects: func(owner *rhtasv1alpha1.Trillian) []client.Object {
+				return finishedJob(owner, actions.DbMigrationJobName, false, "ERROR 1050: Table exists")
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeFailed))
+				g.Expect(instance.Status.DatabaseUpgrade.Message).To(ContainSubstring("Table exists"))
+				g.Expect(instance.Status.DatabaseImage).To(Equal(oldImage))
+			},
+		},
+		{
+			name:    "waiting for rollout",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeRollout),
+			objects: func(_ *rhtasv1alpha1.Trillian) []client.Object {
+				return []client.Object{dbDeployment(constants.TrillianDbImage, 0)}
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeRollout))
+			},
+		},
+		{
+			name:    "rolled out",
+			upgrade: inPhase(rhtasv1alpha1.TrillianDBUpgradeRollout),
+			objects: func(_ *rhtasv1alpha1.Trillian) []client.Object {
+				return []client.Object{dbDeployment(constants.TrillianDbImage, 1)}
+			},
+			verify: func(g Gomega, c client.WithWatch, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.DatabaseUpgrade.Phase).To(Equal(rhtasv1alpha1.TrillianDBUpgradeCompleted))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := newInstance(tt.upgrade)
+			if tt.upgrade != nil && tt.upgrade.Phase == rhtasv1alpha1.TrillianDBUpgradeRollout {
+				instance.Status.DatabaseImage = tt.upgrade.Image
+			}
+			objects := []client.Object{instance, &core.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"}}}
+			if tt.objects != nil {
+				objects = append(objects, tt.objects(instance)...)
+			}
+			c := testAction.FakeClientBuilder().
+				WithObjects(objects...).
+				WithStatusSubresource(instance, &batchv1.Job{}).
+				Build()
+			a := testAction.PrepareAction(c, NewUpgradeAction())
+
+			g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+			result := a.Handle(context.TODO(), instance)
+			g.Expect(testAction.IsFailed(result)).To(BeFalse())
+			tt.verify(g, c, instance)
+		})
+	}
+}
+
+func TestUpgrade_CanHandle(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Trillian{
+		Spec: rhtasv1alpha1.TrillianSpec{Db: rhtasv1alpha1.TrillianDB{Create: ptr.To(true)}},
+		Status: rhtasv1alpha1.TrillianStatus{
+			DatabaseImage: constants.TrillianDbImage,
+			SchemaVersion: trillianUtils.TargetSchemaVersion(rhtasv1alpha1.DatabaseEngineMySQL),
+			Conditions:    []metav1.Condition{{Type: constants.Ready, Reason: constants.Ready}},
+		},
+	}
+	a := testAction.PrepareAction(testAction.FakeClientBuilder().Build(), NewUpgradeAction())
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+
+	instance.Status.DatabaseImage = "trillian-db:old"
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+
+	instance.Spec.Db.Create = ptr.To(false)
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+}
@@ -0,0 +1,97 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewDeployAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &deployAction{}
+}
+
+type deployAction struct {
+	action.BaseAction
+}
+
+func (i deployAction) Name() string {
+	return "deploy"
+}
+
+func (i deployAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return (c.Reason == constants.Creating || c.Reason == constants.Ready) &&
+		(trillianUtils.ManagedEtcd(instance) || meta.FindStatusCondition(instance.Status.Conditions, actions.ElectionCondition) != nil)
+}
+
+func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var (
+		err     error
+		updated bool
+	)
+
+	if !trillianUtils.ManagedEtcd(instance) {
+		return i.cleanup(ctx, instance)
+	}
+
+	labels := constants.LabelsFor(actions.EtcdComponentName, actions.EtcdDeploymentName, instance.Name)
+	dp := trillianUtils.CreateEtcdDeployment(instance, utils.ResourceName(instance, actions.EtcdDeploymentName), utils.ResourceName(instance, actions.RBACName), labels)
+
+	if err = controllerutil.SetControllerReference(instance, dp, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for etcd deployment: %w", err))
+	}
+
+	if updated, err = i.Ensure(ctx, dp); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ElectionCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create Trillian etcd deployment: %w", err), instance)
+	}
+
+	if updated {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ElectionCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Creating,
+			Message: "Deployment created",
+		})
+		return i.StatusUpdate(ctx, instance)
+	} else {
+		return i.Continue()
+	}
+}
+
+// cleanup removes the managed etcd once the election is disabled or backed by an external etcd
+func (i deployAction) cleanup(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	for _, obj := range []client.Object{&apps.Deployment{}, &core.Service{}} {
+		obj.SetName(utils.ResourceName(instance, actions.EtcdDeploymentName))
+		obj.SetNamespace(instance.Namespace)
+		if err := i.Client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return i.Failed(fmt.Errorf("could not remove Trillian etcd: %w", err))
+		}
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, actions.ElectionCondition)
+	return i.StatusUpdate(ctx, instance)
+}
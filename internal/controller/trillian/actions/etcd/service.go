@@ -0,0 +1,77 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewCreateServiceAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &createServiceAction{}
+}
+
+type createServiceAction struct {
+	action.BaseAction
+}
+
+func (i createServiceAction) Name() string {
+	return "create service"
+}
+
+func (i createServiceAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return (c.Reason == constants.Creating || c.Reason == constants.Ready) && trillianUtils.ManagedEtcd(instance)
+}
+
+func (i createServiceAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var (
+		err     error
+		updated bool
+	)
+
+	labels := constants.LabelsFor(actions.EtcdComponentName, actions.EtcdDeploymentName, instance.Name)
+	svc := k8sutils.CreateService(instance.Namespace, utils.ResourceName(instance, actions.EtcdDeploymentName), actions.EtcdPortName, actions.EtcdPort, actions.EtcdPort, labels)
+
+	if err = controllerutil.SetControllerReference(instance, svc, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for etcd service: %w", err))
+	}
+
+	if updated, err = i.Ensure(ctx, svc); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ElectionCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create Trillian etcd service: %w", err), instance)
+	}
+
+	if updated {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ElectionCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Creating,
+			Message: "Service created",
+		})
+		return i.StatusUpdate(ctx, instance)
+	} else {
+		return i.Continue()
+	}
+}
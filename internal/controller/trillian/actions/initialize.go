@@ -30,7 +30,9 @@ func (i initializeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.T
 func (i initializeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
 	if meta.IsStatusConditionTrue(instance.Status.Conditions, DbCondition) &&
 		meta.IsStatusConditionTrue(instance.Status.Conditions, SignerCondition) &&
-		meta.IsStatusConditionTrue(instance.Status.Conditions, ServerCondition) {
+		meta.IsStatusConditionTrue(instance.Status.Conditions, ServerCondition) &&
+		// the condition is present only with the managed etcd
+		(meta.FindStatusCondition(instance.Status.Conditions, ElectionCondition) == nil || meta.IsStatusConditionTrue(instance.Status.Conditions, ElectionCondition)) {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{Type: constants.Ready,
 			Status: metav1.ConditionTrue, Reason: constants.Ready})
 		return i.StatusUpdate(ctx, instance)
@@ -4,14 +4,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
-
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
 	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
@@ -41,10 +41,18 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trilli
 	)
 
 	labels := constants.LabelsFor(actions.LogServerComponentName, actions.LogserverDeploymentName, instance.Name)
-	server, err := trillianUtils.CreateTrillDeployment(instance, constants.TrillianServerImage, actions.LogserverDeploymentName, actions.RBACName, labels)
+	db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
 	if err != nil {
 		return i.Failed(err)
 	}
+	server, err := trillianUtils.CreateTrillDeployment(instance, constants.TrillianServerImage, utils.ResourceName(instance, actions.LogserverDeploymentName), utils.ResourceName(instance, actions.RBACName), labels, db)
+	if err != nil {
+		return i.Failed(err)
+	}
+	server.Spec.Replicas = ptr.To(ptr.Deref(instance.Spec.LogServer.Replicas, 1))
+	if instance.Spec.Quota.DryRun {
+		server.Spec.Template.Spec.Containers[0].Args = append(server.Spec.Template.Spec.Containers[0].Args, "--quota_dry_run=true")
+	}
 
 	err = utils.SetTrustedCA(&server.Spec.Template, utils.TrustedCAAnnotationToReference(instance.Annotations))
 	if err != nil {
@@ -0,0 +1,72 @@
+package logserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+)
+
+func NewPodDisruptionBudgetAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &pdbAction{}
+}
+
+type pdbAction struct {
+	action.BaseAction
+}
+
+func (i pdbAction) Name() string {
+	return "pod disruption budget"
+}
+
+func (i pdbAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c.Reason == constants.Creating || c.Reason == constants.Ready
+}
+
+func (i pdbAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	if ptr.Deref(instance.Spec.LogServer.Replicas, 1) < 2 {
+		// a budget would block the eviction of the only replica
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: utils.ResourceName(instance, actions.LogserverDeploymentName), Namespace: instance.Namespace}}
+		if err := i.Client.Delete(ctx, pdb); client.IgnoreNotFound(err) != nil {
+			return i.Failed(fmt.Errorf("could not remove Trillian LogServer pod disruption budget: %w", err))
+		}
+		return i.Continue()
+	}
+
+	labels := constants.LabelsFor(actions.LogServerComponentName, actions.LogserverDeploymentName, instance.Name)
+	pdb := k8sutils.CreatePodDisruptionBudget(instance.Namespace, utils.ResourceName(instance, actions.LogserverDeploymentName), labels, 1)
+
+	if err := controllerutil.SetControllerReference(instance, pdb, i.Client.Scheme()); err != nil {
+		return i.Failed(fmt.Errorf("could not set controller reference for LogServer pod disruption budget: %w", err))
+	}
+
+	if _, err := i.Ensure(ctx, pdb); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    actions.ServerCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create Trillian LogServer pod disruption budget: %w", err), instance)
+	}
+	return i.Continue()
+}
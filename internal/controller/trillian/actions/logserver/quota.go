@@ -0,0 +1,117 @@
+package logserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian/quota/etcd/quotapb"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+func NewTreeQuotaAction(opts ...func(*treeQuotaAction)) action.Action[*rhtasv1alpha1.Trillian] {
+	a := &treeQuotaAction{
+		newClient: common.NewTrillianClient,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// treeQuotaAction applies the tree write quota to every tree of the Trillian backend.
+// Quotas of trees are left in place once the tree write quota is unset.
+type treeQuotaAction struct {
+	action.BaseAction
+	newClient common.NewTrillianClientFunc
+}
+
+func (i treeQuotaAction) Name() string {
+	return "tree quota"
+}
+
+func (i treeQuotaAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c.Reason == constants.Ready && instance.Spec.Quota.System == rhtasv1alpha1.TrillianQuotaEtcd && instance.Spec.Quota.TreeWrite != nil
+}
+
+func (i treeQuotaAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var caCert []byte
+	if instance.Status.TLS.Enabled && instance.Status.TLS.CACertRef != nil {
+		var err error
+		if caCert, err = k8sutils.GetSecretData(i.Client, instance.Namespace, instance.Status.TLS.CACertRef); err != nil {
+			return i.Failed(fmt.Errorf("could not read Trillian CA certificate: %w", err))
+		}
+	}
+
+	trillianClient, err := i.newClient(fmt.Sprintf("%s.%s.svc:%d", utils.ResourceName(instance, actions.LogserverDeploymentName), instance.Namespace, actions.ServerPort), caCert)
+	if err != nil {
+		return i.Failed(err)
+	}
+	defer func() { _ = trillianClient.Close() }()
+
+	trees, err := trillianClient.ListTrees(ctx)
+	if err != nil {
+		return i.Failed(fmt.Errorf("could not list Trillian trees: %w", err))
+	}
+	for _, tree := range trees {
+		if err = i.ensureQuota(ctx, trillianClient, treeWriteQuota(tree.TreeId, instance.Spec.Quota.TreeWrite)); err != nil {
+			return i.Failed(fmt.Errorf("could not apply quota of tree %d: %w", tree.TreeId, err))
+		}
+	}
+	return i.Continue()
+}
+
+func (i treeQuotaAction) ensureQuota(ctx context.Context, c common.TrillianClient, expected *quotapb.Config) error {
+	current, err := c.GetQuota(ctx, expected.Name)
+	if status.Code(err) == codes.NotFound {
+		i.Logger.Info("Creating quota", "name", expected.Name)
+		_, err = c.CreateQuota(ctx, expected)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	current = proto.Clone(current).(*quotapb.Config)
+	current.CurrentTokens = 0
+	if proto.Equal(current, expected) {
+		return nil
+	}
+	i.Logger.Info("Updating quota", "name", expected.Name)
+	strategy := "sequencing_based"
+	if expected.GetTimeBased() != nil {
+		strategy = "time_based"
+	}
+	_, err = c.UpdateQuota(ctx, expected, "state", "max_tokens", strategy)
+	return err
+}
+
+// treeWriteQuota returns the write quota config of the tree
+func treeWriteQuota(treeID int64, quota *rhtasv1alpha1.TrillianTreeQuota) *quotapb.Config {
+	config := &quotapb.Config{
+		Name:      fmt.Sprintf("quotas/trees/%d/write/config", treeID),
+		State:     quotapb.Config_ENABLED,
+		MaxTokens: quota.MaxTokens,
+	}
+	if quota.TokensToReplenish != nil && quota.ReplenishInterval != nil {
+		config.ReplenishmentStrategy = &quotapb.Config_TimeBased{TimeBased: &quotapb.TimeBasedStrategy{
+			TokensToReplenish:        *quota.TokensToReplenish,
+			ReplenishIntervalSeconds: int64(quota.ReplenishInterval.Duration / time.Second),
+		}}
+	} else {
+		config.ReplenishmentStrategy = &quotapb.Config_SequencingBased{SequencingBased: &quotapb.SequencingBasedStrategy{}}
+	}
+	return config
+}
@@ -0,0 +1,101 @@
+package logserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota/etcd/quotapb"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	testTrillian "github.com/securesign/operator/internal/testing/trillian"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTreeQuota(t *testing.T) {
+	sequencingBased := &quotapb.Config_SequencingBased{SequencingBased: &quotapb.SequencingBasedStrategy{}}
+	tests := []struct {
+		name    string
+		quota   rhtasv1alpha1.TrillianTreeQuota
+		current map[string]*quotapb.Config
+		want    map[string]*quotapb.Config
+		updated []string
+	}{
+		{
+			name:  "create quotas of trees",
+			quota: rhtasv1alpha1.TrillianTreeQuota{MaxTokens: 100},
+			want: map[string]*quotapb.Config{
+				"quotas/trees/1/write/config": {Name: "quotas/trees/1/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased},
+				"quotas/trees/2/write/config": {Name: "quotas/trees/2/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased},
+			},
+		},
+		{
+			name: "update time based quota",
+			quota: rhtasv1alpha1.TrillianTreeQuota{
+				MaxTokens:         50,
+				TokensToReplenish: ptr.To(int64(10)),
+				ReplenishInterval: &metav1.Duration{Duration: time.Minute},
+			},
+			current: map[string]*quotapb.Config{
+				"quotas/trees/1/write/config": {Name: "quotas/trees/1/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased, CurrentTokens: 80},
+			},
+			want: map[string]*quotapb.Config{
+				"quotas/trees/1/write/config": {Name: "quotas/trees/1/write/config", State: quotapb.Config_ENABLED, MaxTokens: 50, ReplenishmentStrategy: &quotapb.Config_TimeBased{
+					TimeBased: &quotapb.TimeBasedStrategy{TokensToReplenish: 10, ReplenishIntervalSeconds: 60},
+				}},
+				"quotas/trees/2/write/config": {Name: "quotas/trees/2/write/config", State: quotapb.Config_ENABLED, MaxTokens: 50, ReplenishmentStrategy: &quotapb.Config_TimeBased{
+					TimeBased: &quotapb.TimeBasedStrategy{TokensToReplenish: 10, ReplenishIntervalSeconds: 60},
+				}},
+			},
+			updated: []string{"state", "max_tokens", "time_based"},
+		},
+		{
+			name:  "keep quota in sync",
+			quota: rhtasv1alpha1.TrillianTreeQuota{MaxTokens: 100},
+			current: map[string]*quotapb.Config{
+				"quotas/trees/1/write/config": {Name: "quotas/trees/1/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased, CurrentTokens: 10},
+				"quotas/trees/2/write/config": {Name: "quotas/trees/2/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased, CurrentTokens: 20},
+			},
+			want: map[string]*quotapb.Config{
+				"quotas/trees/1/write/config": {Name: "quotas/trees/1/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased, CurrentTokens: 10},
+				"quotas/trees/2/write/config": {Name: "quotas/trees/2/write/config", State: quotapb.Config_ENABLED, MaxTokens: 100, ReplenishmentStrategy: sequencingBased, CurrentTokens: 20},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fake := testTrillian.NewFakeClient(&trillian.Tree{TreeId: 1}, &trillian.Tree{TreeId: 2})
+			for name, config := range tt.current {
+				fake.Quotas[name] = config
+			}
+			instance := &rhtasv1alpha1.Trillian{
+				ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+				Spec: rhtasv1alpha1.TrillianSpec{
+					Quota: rhtasv1alpha1.TrillianQuota{System: rhtasv1alpha1.TrillianQuotaEtcd, TreeWrite: &tt.quota},
+				},
+				Status: rhtasv1alpha1.TrillianStatus{
+					Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Ready}},
+				},
+			}
+			c := testAction.FakeClientBuilder().WithObjects(instance).Build()
+			a := testAction.PrepareAction(c, NewTreeQuotaAction(func(a *treeQuotaAction) {
+				a.newClient = fake.NewClient
+			}))
+
+			g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+			g.Expect(a.Handle(context.TODO(), instance)).To(Equal(testAction.Continue()))
+			g.Expect(fake.URL).To(Equal("trillian-trillian-logserver.default.svc:8091"))
+			g.Expect(fake.Quotas).To(HaveLen(len(tt.want)))
+			for name, config := range tt.want {
+				g.Expect(fake.Quotas).To(HaveKeyWithValue(name, Satisfy(func(c *quotapb.Config) bool { return proto.Equal(c, config) })))
+			}
+			g.Expect(fake.QuotaUpdated).To(Equal(tt.updated))
+		})
+	}
+}
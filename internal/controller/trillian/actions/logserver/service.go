@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
@@ -42,7 +43,7 @@ func (i createServiceAction) Handle(ctx context.Context, instance *rhtasv1alpha1
 	)
 
 	labels := constants.LabelsFor(actions.LogServerComponentName, actions.LogserverDeploymentName, instance.Name)
-	logserverService := k8sutils.CreateService(instance.Namespace, actions.LogserverDeploymentName, actions.ServerPortName, actions.ServerPort, actions.ServerPort, labels)
+	logserverService := k8sutils.CreateService(instance.Namespace, utils.ResourceName(instance, actions.LogserverDeploymentName), actions.ServerPortName, actions.ServerPort, actions.ServerPort, labels)
 
 	if instance.Spec.Monitoring.Enabled {
 		logserverService.Spec.Ports = append(logserverService.Spec.Ports, corev1.ServicePort{
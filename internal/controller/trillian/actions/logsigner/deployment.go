@@ -4,9 +4,8 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/securesign/operator/internal/controller/common/utils"
-
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
 	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
@@ -41,12 +40,17 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trilli
 	)
 
 	labels := constants.LabelsFor(actions.LogSignerComponentName, actions.LogsignerDeploymentName, instance.Name)
-	signer, err := trillianUtils.CreateTrillDeployment(instance, constants.TrillianLogSignerImage, actions.LogsignerDeploymentName, actions.RBACName, labels)
+	db, err := trillianUtils.GetDatabase(ctx, i.Client, instance)
+	if err != nil {
+		return i.Failed(err)
+	}
+	signer, err := trillianUtils.CreateTrillDeployment(instance, constants.TrillianLogSignerImage, utils.ResourceName(instance, actions.LogsignerDeploymentName), utils.ResourceName(instance, actions.RBACName), labels, db)
 	if err != nil {
 		return i.Failed(err)
 	}
 
-	signer.Spec.Template.Spec.Containers[0].Args = append(signer.Spec.Template.Spec.Containers[0].Args, "--force_master=true")
+	trillianUtils.SetElection(signer, instance)
+	trillianUtils.SetSequencer(signer, instance.Spec.LogSigner)
 	err = utils.SetTrustedCA(&signer.Spec.Template, utils.TrustedCAAnnotationToReference(instance.Annotations))
 	if err != nil {
 		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
@@ -0,0 +1,129 @@
+package logsigner
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// masterFor returns IDs of trees the Logsigner at the metrics URL is master for
+type masterFor func(ctx context.Context, metricsURL string, tlsConfig *tls.Config) ([]int64, error)
+
+func NewElectionStatusAction(opts ...func(*electionStatusAction)) action.Action[*rhtasv1alpha1.Trillian] {
+	a := &electionStatusAction{masterFor: scrapeMastership}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type electionStatusAction struct {
+	action.BaseAction
+	masterFor masterFor
+}
+
+func (i electionStatusAction) Name() string {
+	return "election status"
+}
+
+func (i electionStatusAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c.Reason == constants.Ready && (trillianUtils.ElectionEnabled(instance) || len(instance.Status.ElectedSigners) > 0)
+}
+
+func (i electionStatusAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var masters []rhtasv1alpha1.TrillianSignerMaster
+	if trillianUtils.ElectionEnabled(instance) {
+		var err error
+		if masters, err = i.electedSigners(ctx, instance); err != nil {
+			return i.Failed(err)
+		}
+	}
+
+	if !reflect.DeepEqual(masters, instance.Status.ElectedSigners) {
+		instance.Status.ElectedSigners = masters
+		return i.StatusUpdate(ctx, instance)
+	}
+	return i.Continue()
+}
+
+// electedSigners asks each running Logsigner pod for the trees it is master for
+func (i electionStatusAction) electedSigners(ctx context.Context, instance *rhtasv1alpha1.Trillian) ([]rhtasv1alpha1.TrillianSignerMaster, error) {
+	pods := &core.PodList{}
+	if err := i.Client.List(ctx, pods, client.InNamespace(instance.Namespace), client.MatchingLabels(constants.LabelsForComponent(actions.LogSignerComponentName, instance.Name))); err != nil {
+		return nil, fmt.Errorf("could not list Logsigner pods: %w", err)
+	}
+
+	scheme := "http"
+	var tlsConfig *tls.Config
+	if instance.Status.TLS.Enabled && instance.Status.TLS.CACertRef != nil {
+		caCert, err := k8sutils.GetSecretData(i.Client, instance.Namespace, instance.Status.TLS.CACertRef)
+		if err != nil {
+			return nil, fmt.Errorf("could not read Trillian CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse Trillian CA certificate")
+		}
+		scheme = "https"
+		tlsConfig = &tls.Config{
+			RootCAs:    pool,
+			ServerName: fmt.Sprintf("%s.%s.svc", utils.ResourceName(instance, actions.LogsignerDeploymentName), instance.Namespace),
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	var masters []rhtasv1alpha1.TrillianSignerMaster
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != core.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		ids, err := i.masterFor(ctx, fmt.Sprintf("%s://%s:%d/metrics", scheme, pod.Status.PodIP, actions.MetricsPort), tlsConfig)
+		if err != nil {
+			// the pod may be restarting or the operator runs outside of the cluster
+			i.Logger.V(1).Info("could not read Logsigner mastership", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		for _, id := range ids {
+			masters = append(masters, rhtasv1alpha1.TrillianSignerMaster{TreeID: id, Pod: pod.Name})
+		}
+	}
+	sort.Slice(masters, func(a, b int) bool { return masters[a].TreeID < masters[b].TreeID })
+	return masters, nil
+}
+
+func scrapeMastership(ctx context.Context, metricsURL string, tlsConfig *tls.Config) ([]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return trillianUtils.ParseMastership(resp.Body)
+}
@@ -0,0 +1,105 @@
+package logsigner
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestElectionStatus(t *testing.T) {
+	signerPod := func(name, ip string) *core.Pod {
+		return &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: constants.LabelsForComponent(actions.LogSignerComponentName, "trillian")},
+			Status:     core.PodStatus{Phase: core.PodRunning, PodIP: ip},
+		}
+	}
+	masters := map[string][]int64{
+		"http://10.0.0.1:8090/metrics": {2},
+		"http://10.0.0.2:8090/metrics": {1},
+	}
+	masterFor := func(_ context.Context, url string, _ *tls.Config) ([]int64, error) {
+		if ids, ok := masters[url]; ok {
+			return ids, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	tests := []struct {
+		name     string
+		replicas int32
+		status   []rhtasv1alpha1.TrillianSignerMaster
+		objects  []client.Object
+		want     *action.Result
+		verify   func(Gomega, *rhtasv1alpha1.Trillian)
+	}{
+		{
+			name:     "report elected pods",
+			replicas: 3,
+			objects:  []client.Object{signerPod("signer-a", "10.0.0.1"), signerPod("signer-b", "10.0.0.2"), signerPod("signer-c", "10.0.0.3")},
+			want:     testAction.StatusUpdate(),
+			verify: func(g Gomega, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.ElectedSigners).To(Equal([]rhtasv1alpha1.TrillianSignerMaster{
+					{TreeID: 1, Pod: "signer-b"},
+					{TreeID: 2, Pod: "signer-a"},
+				}))
+			},
+		},
+		{
+			name:     "refresh unchanged status",
+			replicas: 2,
+			status:   []rhtasv1alpha1.TrillianSignerMaster{{TreeID: 2, Pod: "signer-a"}},
+			objects:  []client.Object{signerPod("signer-a", "10.0.0.1")},
+			want:     testAction.Continue(),
+		},
+		{
+			name:     "clear status when election is disabled",
+			replicas: 1,
+			status:   []rhtasv1alpha1.TrillianSignerMaster{{TreeID: 2, Pod: "signer-a"}},
+			want:     testAction.StatusUpdate(),
+			verify: func(g Gomega, instance *rhtasv1alpha1.Trillian) {
+				g.Expect(instance.Status.ElectedSigners).To(BeEmpty())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &rhtasv1alpha1.Trillian{
+				ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+				Spec: rhtasv1alpha1.TrillianSpec{
+					LogSigner: rhtasv1alpha1.TrillianLogSigner{Replicas: ptr.To(tt.replicas)},
+				},
+				Status: rhtasv1alpha1.TrillianStatus{
+					ElectedSigners: tt.status,
+					Conditions:     []metav1.Condition{{Type: constants.Ready, Reason: constants.Ready}},
+				},
+			}
+			c := testAction.FakeClientBuilder().
+				WithObjects(instance).
+				WithStatusSubresource(instance).
+				WithObjects(tt.objects...).
+				Build()
+			a := testAction.PrepareAction(c, NewElectionStatusAction(func(a *electionStatusAction) {
+				a.masterFor = masterFor
+			}))
+
+			g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+			g.Expect(a.Handle(context.TODO(), instance)).To(Equal(tt.want))
+			if tt.verify != nil {
+				tt.verify(g, instance)
+			}
+		})
+	}
+}
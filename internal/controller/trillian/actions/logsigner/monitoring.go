@@ -7,6 +7,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
@@ -41,7 +42,7 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tr
 	monitoringLabels := constants.LabelsFor(actions.LogSignerComponentName, actions.LogSignerMonitoringName, instance.Name)
 	role := kubernetes.CreateRole(
 		instance.Namespace,
-		actions.LogSignerMonitoringName,
+		utils.ResourceName(instance, actions.LogSignerMonitoringName),
 		monitoringLabels,
 		[]v1.PolicyRule{
 			{
@@ -74,12 +75,12 @@ func (i monitoringAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tr
 
 	roleBinding := kubernetes.CreateRoleBinding(
 		instance.Namespace,
-		actions.LogSignerMonitoringName,
+		utils.ResourceName(instance, actions.LogSignerMonitoringName),
 		monitoringLabels,
 		v1.RoleRef{
 			APIGroup: v1.SchemeGroupVersion.Group,
 			Kind:     "Role",
-			Name:     actions.LogSignerMonitoringName,
+			Name:     utils.ResourceName(instance, actions.LogSignerMonitoringName),
 		},
 		[]v1.Subject{
 			{Kind: "ServiceAccount", Name: "prometheus-k8s", Namespace: "openshift-monitoring"},
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"github.com/securesign/operator/internal/controller/trillian/actions"
@@ -42,7 +43,7 @@ func (i createServiceAction) Handle(ctx context.Context, instance *rhtasv1alpha1
 	)
 
 	labels := constants.LabelsFor(actions.LogSignerComponentName, actions.LogsignerDeploymentName, instance.Name)
-	logsignerService := k8sutils.CreateService(instance.Namespace, actions.LogsignerDeploymentName, actions.ServerPortName, actions.ServerPort, actions.ServerPort, labels)
+	logsignerService := k8sutils.CreateService(instance.Namespace, utils.ResourceName(instance, actions.LogsignerDeploymentName), actions.ServerPortName, actions.ServerPort, actions.ServerPort, labels)
 	if instance.Spec.Monitoring.Enabled {
 		logsignerService.Spec.Ports = append(logsignerService.Spec.Ports, v1.ServicePort{
 			Name:       actions.MetricsPortName,
@@ -0,0 +1,56 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RefreshInterval is the period the state kept outside of the cluster is observed at:
+// the elected Logsigner pods and the trees the quotas apply to
+const RefreshInterval = time.Minute
+
+func NewRefreshAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &refreshAction{}
+}
+
+// refreshAction requeues the instance to observe the state that doesn't trigger any event:
+// the state kept outside of the cluster and the expiration of the generated TLS certificates
+type refreshAction struct {
+	action.BaseAction
+}
+
+func (i refreshAction) Name() string {
+	return "refresh"
+}
+
+func (i refreshAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if c == nil || c.Reason != constants.Ready {
+		return false
+	}
+	return refreshExternalState(instance) || generatedTLS(instance)
+}
+
+func (i refreshAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	var after time.Duration
+	if refreshExternalState(instance) {
+		after = RefreshInterval
+	}
+	// the rotation of the generated certificates starts at the deadline
+	if next, ok := NextTLSCheck(ctx, i.Client, instance); ok && (after == 0 || next < after) {
+		after = max(next, time.Second)
+	}
+	return &action.Result{Result: reconcile.Result{RequeueAfter: after}}
+}
+
+func refreshExternalState(instance *rhtasv1alpha1.Trillian) bool {
+	signer := instance.Spec.LogSigner
+	return (signer.Replicas != nil && *signer.Replicas > 1) || len(signer.Election.EtcdServers) > 0 ||
+		(instance.Spec.Quota.System == rhtasv1alpha1.TrillianQuotaEtcd && instance.Spec.Quota.TreeWrite != nil)
+}
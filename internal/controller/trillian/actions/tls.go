@@ -0,0 +1,442 @@
+package actions
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	TLSCertKey       = "cert"
+	TLSPrivateKeyKey = "private"
+	// TLSCABundleKey holds the CA certificates trusted by the clients, the current CA and the CA it replaces
+	TLSCABundleKey = "ca-bundle"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	certValidity = 365 * 24 * time.Hour
+
+	// TLSRolloutInterval is the period the pods are checked at while a rotation waits for them
+	TLSRolloutInterval = 30 * time.Second
+)
+
+// tlsStep is the next step of the generated certificate lifecycle.
+//
+// The CA is rotated in steps keeping the connections of the clients working:
+// the new CA is published to the clients together with the current one, the server certificate
+// issued by the new CA is served once no client pod uses the previous CA secret, and the previous CA
+// is removed from the trust bundle once no Trillian pod serves the previous certificate.
+type tlsStep int
+
+const (
+	tlsUpToDate tlsStep = iota
+	// tlsWaiting waits for the pods to use the current secrets
+	tlsWaiting
+	// tlsGenerate creates a new CA and certificate, there is nothing to keep
+	tlsGenerate
+	// tlsRotateCA publishes a new CA together with the current one
+	tlsRotateCA
+	// tlsIssueCert issues a server certificate by the current CA
+	tlsIssueCert
+	// tlsDropPreviousCA removes the replaced CA from the trust bundle
+	tlsDropPreviousCA
+)
+
+func NewHandleTLSAction() action.Action[*rhtasv1alpha1.Trillian] {
+	return &handleTLS{}
+}
+
+type handleTLS struct {
+	action.BaseAction
+}
+
+func (i handleTLS) Name() string {
+	return "handle TLS"
+}
+
+func (i handleTLS) CanHandle(ctx context.Context, instance *rhtasv1alpha1.Trillian) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	switch {
+	case c == nil:
+		return false
+	case c.Reason != constants.Creating && c.Reason != constants.Ready:
+		return false
+	case !instance.Spec.TLS.Enabled && instance.Status.TLS.Enabled:
+		return true
+	case instance.Spec.TLS.Enabled && instance.Spec.TLS.CertRef != nil && !equality.Semantic.DeepDerivative(instance.Spec.TLS, instance.Status.TLS):
+		return true
+	}
+
+	// generated certificate is checked on every resync and rotated before it expires
+	state, err := observeTLS(ctx, i.Client, instance)
+	if err != nil {
+		return true
+	}
+	if generatedTLS(instance) {
+		if step := state.plan(instance, time.Now()); step != tlsUpToDate && step != tlsWaiting {
+			return true
+		}
+	}
+	return len(state.stale) > 0
+}
+
+func (i handleTLS) Handle(ctx context.Context, instance *rhtasv1alpha1.Trillian) *action.Result {
+	switch {
+	case !instance.Spec.TLS.Enabled:
+		if instance.Status.TLS.Enabled {
+			instance.Status.TLS = rhtasv1alpha1.TLS{}
+			i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSDisabled", "Trillian gRPC TLS disabled")
+			return i.StatusUpdate(ctx, instance)
+		}
+	case instance.Spec.TLS.CertRef != nil:
+		if !equality.Semantic.DeepDerivative(instance.Spec.TLS, instance.Status.TLS) {
+			instance.Status.TLS = *instance.Spec.TLS.DeepCopy()
+			if instance.Status.TLS.CACertRef == nil {
+				instance.Status.TLS.CACertRef = instance.Spec.TLS.CertRef
+			}
+			i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSUpdated", "Trillian gRPC TLS certificate updated")
+			return i.StatusUpdate(ctx, instance)
+		}
+	}
+
+	state, err := observeTLS(ctx, i.Client, instance)
+	if err != nil {
+		return i.failed(ctx, instance, err)
+	}
+
+	if generatedTLS(instance) {
+		if result := i.generate(ctx, instance, state); result != nil {
+			return result
+		}
+	}
+
+	// the secrets are removed once no pod uses them
+	for _, name := range state.stale {
+		if err = i.Client.Delete(ctx, &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.Namespace}}); client.IgnoreNotFound(err) != nil {
+			return i.Failed(fmt.Errorf("could not remove previous TLS secret %s: %w", name, err))
+		}
+		i.Logger.Info("removed previous TLS secret", "name", name)
+	}
+	return i.Continue()
+}
+
+// generate executes the next step of the generated certificate lifecycle, nil is returned when there is nothing to do
+func (i handleTLS) generate(ctx context.Context, instance *rhtasv1alpha1.Trillian, state *tlsState) *action.Result {
+	var err error
+	step := state.plan(instance, time.Now())
+	switch step {
+	case tlsGenerate:
+		var caCert, caKey []byte
+		if caCert, caKey, err = utils.CreateCACertificate(fmt.Sprintf("trillian-%s-ca", instance.Name), caValidity); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		if err = i.createCA(ctx, instance, caCert, caKey, caCert); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		if err = i.issueCert(ctx, instance, caCert, caKey); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		instance.Status.TLS.Enabled = true
+		i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSUpdated", "Trillian gRPC TLS certificate generated")
+	case tlsRotateCA:
+		var caCert, caKey []byte
+		if caCert, caKey, err = utils.CreateCACertificate(fmt.Sprintf("trillian-%s-ca", instance.Name), caValidity); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		if err = i.createCA(ctx, instance, caCert, caKey, append(append([]byte{}, caCert...), state.caPEM...)); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSCARotated",
+			"Trillian gRPC TLS CA rotated, the certificate is replaced once the clients trust the new CA")
+	case tlsIssueCert:
+		if err = i.issueCert(ctx, instance, state.caPEM, state.caKey); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSUpdated", "Trillian gRPC TLS certificate rotated")
+	case tlsDropPreviousCA:
+		if err = i.createCA(ctx, instance, state.caPEM, state.caKey, state.caPEM); err != nil {
+			return i.failed(ctx, instance, err)
+		}
+		i.Recorder.Event(instance, v1.EventTypeNormal, "TrillianTLSUpdated", "Trillian gRPC TLS previous CA removed")
+	default:
+		return nil
+	}
+	return i.StatusUpdate(ctx, instance)
+}
+
+// createCA stores the CA with the bundle trusted by the clients and publishes it in the status
+func (i handleTLS) createCA(ctx context.Context, instance *rhtasv1alpha1.Trillian, caCert, caKey, bundle []byte) error {
+	labels := constants.LabelsFor(LogServerComponentName, LogserverDeploymentName, instance.Name)
+	caSecret := k8sutils.CreateImmutableSecret(caSecretPrefix(instance), instance.Namespace,
+		map[string][]byte{TLSCertKey: caCert, TLSPrivateKeyKey: caKey, TLSCABundleKey: bundle}, labels)
+	if err := controllerutil.SetControllerReference(instance, caSecret, i.Client.Scheme()); err != nil {
+		return fmt.Errorf("could not set controller reference for Secret: %w", err)
+	}
+	if _, err := i.Ensure(ctx, caSecret); err != nil {
+		return err
+	}
+	instance.Status.TLS.CACertRef = &rhtasv1alpha1.SecretKeySelector{
+		LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: caSecret.Name},
+		Key:                  TLSCABundleKey,
+	}
+	return nil
+}
+
+// issueCert creates the server certificate issued by the CA and publishes it in the status
+func (i handleTLS) issueCert(ctx context.Context, instance *rhtasv1alpha1.Trillian, caCert, caKey []byte) error {
+	cert, key, err := utils.CreateServerCertificate(caCert, caKey, dnsNames(instance), certValidity)
+	if err != nil {
+		return err
+	}
+	labels := constants.LabelsFor(LogServerComponentName, LogserverDeploymentName, instance.Name)
+	certSecret := k8sutils.CreateImmutableSecret(certSecretPrefix(instance), instance.Namespace,
+		map[string][]byte{TLSCertKey: cert, TLSPrivateKeyKey: key}, labels)
+	if err = controllerutil.SetControllerReference(instance, certSecret, i.Client.Scheme()); err != nil {
+		return fmt.Errorf("could not set controller reference for Secret: %w", err)
+	}
+	if _, err = i.Ensure(ctx, certSecret); err != nil {
+		return err
+	}
+	instance.Status.TLS.CertRef = &rhtasv1alpha1.SecretKeySelector{
+		LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: certSecret.Name},
+		Key:                  TLSCertKey,
+	}
+	instance.Status.TLS.PrivateKeyRef = &rhtasv1alpha1.SecretKeySelector{
+		LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: certSecret.Name},
+		Key:                  TLSPrivateKeyKey,
+	}
+	return nil
+}
+
+func (i handleTLS) failed(ctx context.Context, instance *rhtasv1alpha1.Trillian, err error) *action.Result {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    constants.Ready,
+		Status:  metav1.ConditionFalse,
+		Reason:  constants.Failure,
+		Message: err.Error(),
+	})
+	return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create TLS certificate: %w", err), instance)
+}
+
+// tlsState is the observed state of the generated TLS secrets
+type tlsState struct {
+	// caPEM and caKey are the current CA, nil when it doesn't exist or it is not valid
+	caPEM, caKey []byte
+	ca           *x509.Certificate
+	// trusted lists the CA certificates of the bundle published to the clients
+	trusted []*x509.Certificate
+	// cert is the served certificate, nil when it doesn't exist or it is not valid
+	cert *x509.Certificate
+	// mounted lists the names of the secrets mounted by the pods of the namespace
+	mounted map[string]bool
+	// stale lists the generated secrets that are not referenced by the status and not mounted anymore
+	stale []string
+}
+
+// observeTLS reads the generated TLS secrets of the instance and the secrets used by the pods of its namespace
+func observeTLS(ctx context.Context, c client.Client, instance *rhtasv1alpha1.Trillian) (*tlsState, error) {
+	state := &tlsState{mounted: map[string]bool{}}
+
+	pods := &v1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, fmt.Errorf("could not list pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		for _, name := range mountedSecrets(pod.Spec.Volumes) {
+			state.mounted[name] = true
+		}
+	}
+
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := c.List(ctx, secrets, client.InNamespace(instance.Namespace),
+		client.MatchingLabels(constants.LabelsFor(LogServerComponentName, LogserverDeploymentName, instance.Name))); err != nil {
+		return nil, fmt.Errorf("could not list TLS secrets: %w", err)
+	}
+	referenced := map[string]bool{}
+	for _, ref := range []*rhtasv1alpha1.SecretKeySelector{instance.Status.TLS.CACertRef, instance.Status.TLS.CertRef, instance.Status.TLS.PrivateKeyRef} {
+		if ref != nil {
+			referenced[ref.Name] = true
+		}
+	}
+	for _, secret := range secrets.Items {
+		if generated(instance, &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: secret.Name}}) &&
+			metav1.IsControlledBy(&secret, instance) && !referenced[secret.Name] && !state.mounted[secret.Name] {
+			state.stale = append(state.stale, secret.Name)
+		}
+	}
+
+	if !generatedTLS(instance) {
+		return state, nil
+	}
+
+	if ref := instance.Status.TLS.CACertRef; generated(instance, ref) {
+		data, err := secretData(ctx, c, instance.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if ca, err := utils.ParseCertificate(data[TLSCertKey]); err == nil && len(data[TLSPrivateKeyKey]) > 0 {
+			state.caPEM, state.caKey, state.ca = data[TLSCertKey], data[TLSPrivateKeyKey], ca
+			// the CAs generated before the trust bundle was introduced are referenced by their certificate
+			if state.trusted, err = utils.ParseCertificates(data[ref.Key]); err != nil {
+				state.trusted = []*x509.Certificate{ca}
+			}
+		}
+	}
+	if ref := instance.Status.TLS.CertRef; generated(instance, ref) {
+		data, err := secretData(ctx, c, instance.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if cert, err := utils.ParseCertificate(data[ref.Key]); err == nil {
+			state.cert = cert
+		}
+	}
+	return state, nil
+}
+
+// plan returns the next step of the generated certificate lifecycle
+func (s *tlsState) plan(instance *rhtasv1alpha1.Trillian, now time.Time) tlsStep {
+	switch {
+	case s.ca == nil:
+		return tlsGenerate
+	case s.cert == nil:
+		return tlsIssueCert
+	case now.After(utils.CertificateRotationTime(s.ca)) && len(s.trusted) == 1:
+		return tlsRotateCA
+	case s.cert.CheckSignatureFrom(s.ca) != nil:
+		// the clients have to trust the new CA before its certificate is served
+		if s.mountsPrevious(caSecretPrefix(instance), instance.Status.TLS.CACertRef) {
+			return tlsWaiting
+		}
+		return tlsIssueCert
+	case now.After(utils.CertificateRotationTime(s.cert)):
+		return tlsIssueCert
+	case len(s.trusted) > 1:
+		// the previous CA is trusted until no Trillian pod serves the certificate it issued
+		if s.mountsPrevious(certSecretPrefix(instance), instance.Status.TLS.CertRef) {
+			return tlsWaiting
+		}
+		return tlsDropPreviousCA
+	default:
+		return tlsUpToDate
+	}
+}
+
+// rotationTime returns the time the next rotation of the generated certificates starts at
+func (s *tlsState) rotationTime() time.Time {
+	if s.ca == nil || s.cert == nil {
+		return time.Now()
+	}
+	next := utils.CertificateRotationTime(s.cert)
+	if ca := utils.CertificateRotationTime(s.ca); ca.Before(next) {
+		next = ca
+	}
+	return next
+}
+
+// mountsPrevious returns true when a pod mounts a generated secret with the prefix other than the referenced one
+func (s *tlsState) mountsPrevious(prefix string, current *rhtasv1alpha1.SecretKeySelector) bool {
+	for name := range s.mounted {
+		if strings.HasPrefix(name, prefix) && (current == nil || name != current.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextTLSCheck returns the duration after which the generated TLS certificates of the instance are checked again.
+// False is returned when the instance does not use generated certificates.
+func NextTLSCheck(ctx context.Context, c client.Client, instance *rhtasv1alpha1.Trillian) (time.Duration, bool) {
+	if !generatedTLS(instance) {
+		return 0, false
+	}
+	state, err := observeTLS(ctx, c, instance)
+	if err != nil {
+		return TLSRolloutInterval, true
+	}
+	if state.plan(instance, time.Now()) != tlsUpToDate {
+		return TLSRolloutInterval, true
+	}
+	return time.Until(state.rotationTime()), true
+}
+
+// mountedSecrets returns the names of the secrets mounted by the volumes
+func mountedSecrets(volumes []v1.Volume) []string {
+	var names []string
+	for _, volume := range volumes {
+		if volume.Secret != nil {
+			names = append(names, volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					names = append(names, source.Secret.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+func secretData(ctx context.Context, c client.Client, namespace, name string) (map[string][]byte, error) {
+	secret := &v1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// generatedTLS returns true when the operator generates the TLS certificate of the instance
+func generatedTLS(instance *rhtasv1alpha1.Trillian) bool {
+	return instance.Spec.TLS.Enabled && instance.Spec.TLS.CertRef == nil
+}
+
+func caSecretPrefix(instance *rhtasv1alpha1.Trillian) string {
+	return fmt.Sprintf("trillian-tls-ca-%s-", instance.Name)
+}
+
+func certSecretPrefix(instance *rhtasv1alpha1.Trillian) string {
+	return fmt.Sprintf("trillian-tls-%s-", instance.Name)
+}
+
+// generated returns true if the referenced secret was created by the operator
+func generated(instance *rhtasv1alpha1.Trillian, ref *rhtasv1alpha1.SecretKeySelector) bool {
+	if ref == nil {
+		return false
+	}
+	return strings.HasPrefix(ref.Name, caSecretPrefix(instance)) || strings.HasPrefix(ref.Name, certSecretPrefix(instance))
+}
+
+// dnsNames lists the names the log server and log signer are reachable on
+func dnsNames(instance *rhtasv1alpha1.Trillian) []string {
+	namespace := instance.Namespace
+	names := make([]string, 0, 9)
+	for _, svc := range []string{utils.ResourceName(instance, LogserverDeploymentName), utils.ResourceName(instance, LogsignerDeploymentName)} {
+		names = append(names,
+			svc,
+			fmt.Sprintf("%s.%s", svc, namespace),
+			fmt.Sprintf("%s.%s.svc", svc, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", svc, namespace),
+		)
+	}
+	// operator running outside the cluster uses port-forward
+	return append(names, "localhost")
+}
@@ -0,0 +1,259 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHandleTLS_CanHandle(t *testing.T) {
+	userRef := &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "user"}, Key: "cert"}
+	tests := []struct {
+		name      string
+		phase     string
+		spec      rhtasv1alpha1.TLS
+		status    rhtasv1alpha1.TLS
+		canHandle bool
+	}{
+		{
+			name:      "disabled",
+			phase:     constants.Creating,
+			canHandle: false,
+		},
+		{
+			name:      "pending phase",
+			phase:     constants.Pending,
+			spec:      rhtasv1alpha1.TLS{Enabled: true},
+			canHandle: false,
+		},
+		{
+			name:      "enable TLS",
+			phase:     constants.Creating,
+			spec:      rhtasv1alpha1.TLS{Enabled: true},
+			canHandle: true,
+		},
+		{
+			name:      "disable TLS",
+			phase:     constants.Ready,
+			status:    rhtasv1alpha1.TLS{Enabled: true, CertRef: userRef, PrivateKeyRef: userRef},
+			canHandle: true,
+		},
+		{
+			name:      "user certificate is resolved",
+			phase:     constants.Ready,
+			spec:      rhtasv1alpha1.TLS{Enabled: true, CertRef: userRef, PrivateKeyRef: userRef},
+			status:    rhtasv1alpha1.TLS{Enabled: true, CertRef: userRef, PrivateKeyRef: userRef, CACertRef: userRef},
+			canHandle: false,
+		},
+		{
+			name:      "user certificate replaces generated one",
+			phase:     constants.Ready,
+			spec:      rhtasv1alpha1.TLS{Enabled: true, CertRef: userRef, PrivateKeyRef: userRef},
+			status:    rhtasv1alpha1.TLS{Enabled: true},
+			canHandle: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testAction.FakeClientBuilder().Build()
+			a := testAction.PrepareAction(c, NewHandleTLSAction())
+			instance := rhtasv1alpha1.Trillian{
+				Spec: rhtasv1alpha1.TrillianSpec{TLS: tt.spec},
+				Status: rhtasv1alpha1.TrillianStatus{
+					TLS: tt.status,
+					Conditions: []metav1.Condition{
+						{Type: constants.Ready, Reason: tt.phase},
+					},
+				},
+			}
+
+			if got := a.CanHandle(context.TODO(), &instance); got != tt.canHandle {
+				t.Errorf("CanHandle() = %v, want %v", got, tt.canHandle)
+			}
+		})
+	}
+}
+
+func TestHandleTLS_Handle(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	instance := &rhtasv1alpha1.Trillian{
+		ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+		Spec:       rhtasv1alpha1.TrillianSpec{TLS: rhtasv1alpha1.TLS{Enabled: true}},
+		Status: rhtasv1alpha1.TrillianStatus{
+			Conditions: []metav1.Condition{
+				{Type: constants.Ready, Reason: constants.Creating},
+			},
+		},
+	}
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testAction.PrepareAction(c, NewHandleTLSAction())
+
+	// generate CA and server certificate
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(instance.Status.TLS.Enabled).To(BeTrue())
+	g.Expect(instance.Status.TLS.CertRef).ToNot(BeNil())
+	g.Expect(instance.Status.TLS.PrivateKeyRef).ToNot(BeNil())
+	g.Expect(instance.Status.TLS.CACertRef).ToNot(BeNil())
+
+	caData, err := kubernetes.GetSecretData(c, instance.Namespace, instance.Status.TLS.CACertRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(caData).ToNot(BeEmpty())
+	certData, err := kubernetes.GetSecretData(c, instance.Namespace, instance.Status.TLS.CertRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	cert, err := utils.ParseCertificate(certData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cert.DNSNames).To(ContainElement("trillian-trillian-logserver.default.svc"))
+	g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+
+	// switch to user provided certificate
+	generated := *instance.Status.TLS.DeepCopy()
+	userRef := &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "user"}, Key: "tls.crt"}
+	instance.Spec.TLS = rhtasv1alpha1.TLS{Enabled: true, CertRef: userRef, PrivateKeyRef: userRef}
+	g.Expect(c.Update(ctx, instance)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(instance.Status.TLS.CertRef).To(Equal(userRef))
+	g.Expect(instance.Status.TLS.CACertRef).To(Equal(userRef))
+
+	// generated secrets are kept while a pod mounts them
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "logserver", Namespace: instance.Namespace},
+		Spec: v1.PodSpec{Volumes: []v1.Volume{
+			{Name: "tls", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: generated.CertRef.Name}}},
+		}},
+	}
+	g.Expect(c.Create(ctx, pod)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(BeNil())
+	err = c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: generated.CACertRef.Name}, &v1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: generated.CertRef.Name}, &v1.Secret{})).To(Succeed())
+
+	// and removed once the pods are rolled out
+	g.Expect(c.Delete(ctx, pod)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(BeNil())
+	err = c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: generated.CertRef.Name}, &v1.Secret{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+
+	// disable TLS, user secret is not touched
+	instance.Spec.TLS = rhtasv1alpha1.TLS{}
+	g.Expect(c.Update(ctx, instance)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(instance.Status.TLS).To(Equal(rhtasv1alpha1.TLS{}))
+}
+
+func TestHandleTLS_Rotation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	instance := &rhtasv1alpha1.Trillian{
+		ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+		Spec:       rhtasv1alpha1.TrillianSpec{TLS: rhtasv1alpha1.TLS{Enabled: true}},
+		Status: rhtasv1alpha1.TrillianStatus{
+			Conditions: []metav1.Condition{
+				{Type: constants.Ready, Reason: constants.Ready},
+			},
+		},
+	}
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testAction.PrepareAction(c, NewHandleTLSAction())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	previous := *instance.Status.TLS.DeepCopy()
+
+	// the pods use the current secrets
+	pod := func(name string, secrets ...string) *v1.Pod {
+		p := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: instance.Namespace}}
+		for _, secret := range secrets {
+			p.Spec.Volumes = append(p.Spec.Volumes, v1.Volume{Name: secret, VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+					{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: secret}}},
+				}},
+			}})
+		}
+		return p
+	}
+	server := pod("logserver", previous.CertRef.Name)
+	rekor := pod("rekor", previous.CACertRef.Name)
+	g.Expect(c.Create(ctx, server)).To(Succeed())
+	g.Expect(c.Create(ctx, rekor)).To(Succeed())
+
+	state, err := observeTLS(ctx, c, instance)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(state.plan(instance, time.Now())).To(Equal(tlsUpToDate))
+	g.Expect(state.plan(instance, utils.CertificateRotationTime(state.cert).Add(time.Minute))).To(Equal(tlsIssueCert))
+	g.Expect(state.plan(instance, utils.CertificateRotationTime(state.ca).Add(time.Minute))).To(Equal(tlsRotateCA))
+
+	// the CA is rotated, the clients trust both CAs
+	caCert, caKey, err := utils.CreateCACertificate("trillian-rotated-ca", caValidity)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(a.(*handleTLS).createCA(ctx, instance, caCert, caKey, append(append([]byte{}, caCert...), state.caPEM...))).To(Succeed())
+	g.Expect(instance.Status.TLS.CertRef).To(Equal(previous.CertRef))
+	bundle, err := kubernetes.GetSecretData(c, instance.Namespace, instance.Status.TLS.CACertRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	trusted, err := utils.ParseCertificates(bundle)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(trusted).To(HaveLen(2))
+
+	// the certificate issued by the new CA waits for the clients
+	g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+	next, ok := NextTLSCheck(ctx, c, instance)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(next).To(Equal(TLSRolloutInterval))
+
+	// the clients are rolled out, the certificate is issued by the new CA
+	rekor.Spec.Volumes[0].Projected.Sources[0].Secret.Name = instance.Status.TLS.CACertRef.Name
+	g.Expect(c.Update(ctx, rekor)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(instance.Status.TLS.CertRef).ToNot(Equal(previous.CertRef))
+	certData, err := kubernetes.GetSecretData(c, instance.Namespace, instance.Status.TLS.CertRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	cert, err := utils.ParseCertificate(certData)
+	g.Expect(err).ToNot(HaveOccurred())
+	ca, err := utils.ParseCertificate(caCert)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cert.CheckSignatureFrom(ca)).To(Succeed())
+
+	// the previous CA is trusted while the server uses the previous certificate
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(BeNil())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: previous.CACertRef.Name}, &v1.Secret{})).
+		ToNot(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: previous.CertRef.Name}, &v1.Secret{})).
+		To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+
+	// the server is rolled out, the previous CA is removed from the trust bundle
+	server.Spec.Volumes[0].Projected.Sources[0].Secret.Name = instance.Status.TLS.CertRef.Name
+	g.Expect(c.Update(ctx, server)).To(Succeed())
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	bundle, err = kubernetes.GetSecretData(c, instance.Namespace, instance.Status.TLS.CACertRef)
+	g.Expect(err).ToNot(HaveOccurred())
+	trusted, err = utils.ParseCertificates(bundle)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(trusted).To(ConsistOf(ca))
+
+	state, err = observeTLS(ctx, c, instance)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(state.plan(instance, time.Now())).To(Equal(tlsUpToDate))
+	next, _ = NextTLSCheck(ctx, c, instance)
+	g.Expect(next).To(BeNumerically("~", time.Until(utils.CertificateRotationTime(cert)), time.Minute))
+}
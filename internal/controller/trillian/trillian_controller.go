@@ -28,12 +28,16 @@ import (
 	"github.com/securesign/operator/internal/controller/common/action"
 	actions2 "github.com/securesign/operator/internal/controller/trillian/actions"
 	"github.com/securesign/operator/internal/controller/trillian/actions/db"
+	"github.com/securesign/operator/internal/controller/trillian/actions/etcd"
 	"github.com/securesign/operator/internal/controller/trillian/actions/logserver"
 	"github.com/securesign/operator/internal/controller/trillian/actions/logsigner"
+	batchv1 "k8s.io/api/batch/v1"
 	v12 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/client-go/tools/record"
 
 	v1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,6 +57,10 @@ type TrillianReconciler struct {
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trillians,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trillians/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trillians/finalizers,verbs=update
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="batch",resources=cronjobs,verbs=create;get;list;watch;update;patch;delete
+//+kubebuilder:rbac:groups="batch",resources=jobs,verbs=create;get;list;watch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -86,6 +94,9 @@ func (r *TrillianReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	target := instance.DeepCopy()
 	actions := []action.Action[*rhtasv1alpha1.Trillian]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.Trillian](func(_ *rhtasv1alpha1.Trillian) client.Object {
+			return &v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: actions2.LogserverDeploymentName}}
+		}),
 		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.Trillian](func(_ *rhtasv1alpha1.Trillian) []string {
 			return nil
 		}),
@@ -96,10 +107,18 @@ func (r *TrillianReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		db.NewCreatePvcAction(),
 		db.NewDeployAction(),
 		db.NewCreateServiceAction(),
+		db.NewCreateSchemaAction(),
+		db.NewBackupAction(),
+
+		actions2.NewHandleTLSAction(),
 
 		logserver.NewDeployAction(),
 		logserver.NewCreateServiceAction(),
 		logserver.NewCreateMonitorAction(),
+		logserver.NewPodDisruptionBudgetAction(),
+
+		etcd.NewDeployAction(),
+		etcd.NewCreateServiceAction(),
 
 		logsigner.NewDeployAction(),
 		logsigner.NewCreateServiceAction(),
@@ -109,8 +128,17 @@ func (r *TrillianReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 		db.NewInitializeAction(),
 		logserver.NewInitializeAction(),
+		etcd.NewInitializeAction(),
 		logsigner.NewInitializeAction(),
 		actions2.NewInitializeAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.Trillian](),
+
+		db.NewUpgradeAction(),
+		db.NewBackupStatusAction(),
+		db.NewRestoreAction(),
+		logserver.NewTreeQuotaAction(),
+		logsigner.NewElectionStatusAction(),
+		actions2.NewRefreshAction(),
 	}
 
 	for _, a := range actions {
@@ -142,5 +170,8 @@ func (r *TrillianReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&rhtasv1alpha1.Trillian{}).
 		Owns(&v1.Deployment{}).
 		Owns(&v12.Service{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
 		Complete(r)
 }
@@ -137,22 +137,22 @@ var _ = Describe("Trillian controller", func() {
 
 			By("Database Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DbDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.DbDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("LogServer Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.LogserverDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.LogserverDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("LogServerSvc Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.LogserverDeploymentName, Namespace: Namespace}, &corev1.Service{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.LogserverDeploymentName, Namespace: Namespace}, &corev1.Service{})
 			}).Should(Succeed())
 
 			By("LogSigner Deployment created")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.LogsignerDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.LogsignerDeploymentName, Namespace: Namespace}, &appsv1.Deployment{})
 			}).Should(Succeed())
 
 			By("Waiting until Trillian instance is Initialization")
@@ -182,14 +182,14 @@ var _ = Describe("Trillian controller", func() {
 			By("Checking if controller will return deployment to desired state")
 			deployment := &appsv1.Deployment{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.LogserverDeploymentName, Namespace: Namespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.LogserverDeploymentName, Namespace: Namespace}, deployment)
 			}).Should(Succeed())
 			replicas := int32(99)
 			deployment.Spec.Replicas = &replicas
 			Expect(k8sClient.Status().Update(ctx, deployment)).Should(Succeed())
 			Eventually(func(g Gomega) int32 {
 				deployment = &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.LogserverDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: Name + "-" + actions.LogserverDeploymentName, Namespace: Namespace}, deployment)).Should(Succeed())
 				return *deployment.Spec.Replicas
 			}).Should(Equal(int32(1)))
 		})
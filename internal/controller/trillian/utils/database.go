@@ -0,0 +1,365 @@
+package trillianUtils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	core "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Entries of the database secret, the keys are prefixed with the engine name (see DBSecretKey)
+const (
+	DBSecretHost         = "host"
+	DBSecretPort         = "port"
+	DBSecretUser         = "user"
+	DBSecretPassword     = "password"
+	DBSecretDatabase     = "database"
+	DBSecretRootPassword = "root-password"
+
+	// DBSecretTLSMode selects how the connection is secured, see DBTLSMode
+	DBSecretTLSMode = "tls-mode"
+	// DBSecretTLSCA is the PEM encoded CA bundle used to verify the database server certificate
+	DBSecretTLSCA = "tls-ca"
+	// DBSecretTLSServerCert is the PEM encoded certificate served by the managed database server
+	DBSecretTLSServerCert = "tls-server-cert"
+	// DBSecretTLSServerKey is the PEM encoded private key of DBSecretTLSServerCert
+	DBSecretTLSServerKey = "tls-server-key"
+	// DBSecretTLSClientCert and DBSecretTLSClientKey would authenticate the clients with a certificate.
+	// They are rejected: Trillian has no flag presenting a client certificate to the database.
+	DBSecretTLSClientCert = "tls-cert"
+	DBSecretTLSClientKey  = "tls-key"
+
+	// PostgresqlSchemaConfigMap holds the Trillian PostgreSQL schema
+	PostgresqlSchemaConfigMap = "trillian-postgresql-schema"
+	// PostgresqlSchemaKey is the schema entry of PostgresqlSchemaConfigMap
+	PostgresqlSchemaKey = "storage.sql"
+
+	dbTLSVolumeName    = "db-tls"
+	dbTLSPath          = "/var/run/secrets/tas/db-tls"
+	dbSchemaVolumeName = "db-schema"
+	dbSchemaPath       = "/var/run/tas/db-schema"
+)
+
+type DBTLSMode string
+
+const (
+	// DBTLSDisabled uses a plain connection
+	DBTLSDisabled DBTLSMode = "disabled"
+	// DBTLSPreferred uses TLS when the server supports it without verifying the certificate
+	DBTLSPreferred DBTLSMode = "preferred"
+	// DBTLSSkipVerify requires TLS without verifying the certificate
+	DBTLSSkipVerify DBTLSMode = "skip-verify"
+	// DBTLSVerifyFull requires TLS and verifies the certificate chain and the host name
+	DBTLSVerifyFull DBTLSMode = "verify-full"
+)
+
+// Engine returns the configured database engine, MySQL is used when it is not set
+func Engine(db v1alpha1.TrillianDB) v1alpha1.DatabaseEngine {
+	if db.Engine == "" {
+		return v1alpha1.DatabaseEngineMySQL
+	}
+	return db.Engine
+}
+
+// DBSecretKey returns the name of the database secret entry for the engine
+func DBSecretKey(engine v1alpha1.DatabaseEngine, key string) string {
+	return fmt.Sprintf("%s-%s", engine, key)
+}
+
+// Database is the connection configuration resolved from the database secret
+type Database struct {
+	Engine     v1alpha1.DatabaseEngine
+	SecretName string
+	TLSMode    DBTLSMode
+	// CA is true when the secret contains a CA bundle
+	CA bool
+	// ServerCert is true when the secret contains a server certificate and its key
+	ServerCert bool
+}
+
+// ResolveDatabase reads the connection configuration of the engine from the database secret.
+// The TLS mode defaults to verify-full when a CA is provided, otherwise TLS is disabled.
+func ResolveDatabase(secret *core.Secret, engine v1alpha1.DatabaseEngine) (Database, error) {
+	for _, key := range []string{DBSecretKey(engine, DBSecretTLSClientCert), DBSecretKey(engine, DBSecretTLSClientKey)} {
+		if _, ok := secret.Data[key]; ok {
+			return Database{}, fmt.Errorf("database secret %s: %s is not supported, Trillian can't authenticate to the database with a client certificate", secret.Name, key)
+		}
+	}
+	certKey, keyKey := DBSecretKey(engine, DBSecretTLSServerCert), DBSecretKey(engine, DBSecretTLSServerKey)
+	_, hasCert := secret.Data[certKey]
+	_, hasKey := secret.Data[keyKey]
+	if hasCert != hasKey {
+		return Database{}, fmt.Errorf("database secret %s must contain both %s and %s", secret.Name, certKey, keyKey)
+	}
+	_, hasCA := secret.Data[DBSecretKey(engine, DBSecretTLSCA)]
+	db := Database{
+		Engine:     engine,
+		SecretName: secret.Name,
+		TLSMode:    DBTLSMode(secret.Data[DBSecretKey(engine, DBSecretTLSMode)]),
+		CA:         hasCA,
+		ServerCert: hasCert,
+	}
+
+	switch db.TLSMode {
+	case "":
+		db.TLSMode = DBTLSDisabled
+		if db.CA {
+			db.TLSMode = DBTLSVerifyFull
+		}
+	case DBTLSDisabled, DBTLSPreferred, DBTLSSkipVerify, DBTLSVerifyFull:
+	default:
+		return Database{}, fmt.Errorf("database secret %s: unsupported %s %q", secret.Name, DBSecretKey(engine, DBSecretTLSMode), db.TLSMode)
+	}
+	return db, nil
+}
+
+// GetDatabase resolves the connection configuration of the database secret referenced in the Trillian status
+func GetDatabase(ctx context.Context, c client.Client, instance *v1alpha1.Trillian) (Database, error) {
+	if instance.Status.Db.DatabaseSecretRef == nil {
+		return Database{}, errors.New("reference to database secret is not set")
+	}
+	secret := &core.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: instance.Status.Db.DatabaseSecretRef.Name}, secret); err != nil {
+		return Database{}, fmt.Errorf("could not read database secret: %w", err)
+	}
+	return ResolveDatabase(secret, Engine(instance.Spec.Db))
+}
+
+func (d Database) secretEnv(name, key string) core.EnvVar {
+	return core.EnvVar{
+		Name: name,
+		ValueFrom: &core.EnvVarSource{
+			SecretKeyRef: &core.SecretKeySelector{
+				Key: DBSecretKey(d.Engine, key),
+				LocalObjectReference: core.LocalObjectReference{
+					Name: d.SecretName,
+				},
+			},
+		},
+	}
+}
+
+// waitEnv returns the variables used to wait for the database
+func (d Database) waitEnv() []core.EnvVar {
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		return []core.EnvVar{
+			d.secretEnv("PGHOST", DBSecretHost),
+			d.secretEnv("PGPORT", DBSecretPort),
+		}
+	}
+	return []core.EnvVar{
+		d.secretEnv("MYSQL_HOSTNAME", DBSecretHost),
+		d.secretEnv("MYSQL_PORT", DBSecretPort),
+	}
+}
+
+// waitCommand returns the shell command waiting until the database accepts connections
+func (d Database) waitCommand() string {
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		return "until nc -z -v -w30 $PGHOST $PGPORT; do echo \"Waiting for PostgreSQL to start\"; sleep 5; done;"
+	}
+	return "until nc -z -v -w30 $MYSQL_HOSTNAME $MYSQL_PORT; do echo \"Waiting for MySQL to start\"; sleep 5; done;"
+}
+
+// clientEnv returns the variables the Trillian connection arguments refer to
+func (d Database) clientEnv() []core.EnvVar {
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		// libpq variables, they are used by psql as well
+		return []core.EnvVar{
+			d.secretEnv("PGUSER", DBSecretUser),
+			d.secretEnv("PGPASSWORD", DBSecretPassword),
+			d.secretEnv("PGHOST", DBSecretHost),
+			d.secretEnv("PGPORT", DBSecretPort),
+			d.secretEnv("PGDATABASE", DBSecretDatabase),
+		}
+	}
+	return []core.EnvVar{
+		d.secretEnv("MYSQL_USER", DBSecretUser),
+		d.secretEnv("MYSQL_PASSWORD", DBSecretPassword),
+		d.secretEnv("MYSQL_HOSTNAME", DBSecretHost),
+		d.secretEnv("MYSQL_PORT", DBSecretPort),
+		d.secretEnv("MYSQL_DATABASE", DBSecretDatabase),
+	}
+}
+
+// storageArgs returns the Trillian arguments selecting the storage system and the connection to it
+func (d Database) storageArgs() []string {
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		// the password is read from PGPASSWORD by the pgx driver, the URI would break on quotes in it
+		params := append([]string{
+			"host=$(PGHOST)",
+			"port=$(PGPORT)",
+			"user=$(PGUSER)",
+			"dbname=$(PGDATABASE)",
+		}, d.postgresqlSSL()...)
+		return []string{
+			"--storage_system=postgresql",
+			"--postgresql_uri=" + strings.Join(params, " "),
+		}
+	}
+
+	var query string
+	var tlsArgs []string
+	switch d.TLSMode {
+	case DBTLSPreferred, DBTLSSkipVerify:
+		query = "?tls=" + string(d.TLSMode)
+	case DBTLSVerifyFull:
+		if !d.CA {
+			// verify with the system trust store
+			query = "?tls=true"
+			break
+		}
+		// Trillian registers the TLS config of the CA and appends its ?tls=custom parameter to the URI.
+		// The flags are available since Trillian v1.6.1.
+		tlsArgs = []string{
+			"--mysql_tls_ca=" + path.Join(dbTLSPath, "ca.crt"),
+			"--mysql_server_name=$(MYSQL_HOSTNAME)",
+		}
+	}
+	return append([]string{
+		"--storage_system=mysql",
+		"--mysql_uri=$(MYSQL_USER):$(MYSQL_PASSWORD)@tcp($(MYSQL_HOSTNAME):$(MYSQL_PORT))/$(MYSQL_DATABASE)" + query,
+	}, tlsArgs...)
+}
+
+// quotaArgs returns the Trillian arguments selecting the quota system
+func (d Database) quotaArgs(quota v1alpha1.TrillianQuota) []string {
+	switch quota.System {
+	case v1alpha1.TrillianQuotaEtcd, v1alpha1.TrillianQuotaNoop:
+		return []string{"--quota_system=" + string(quota.System)}
+	}
+
+	// the database quota system counts unsequenced rows in the storage
+	system, maxRows := "mysql", "--max_unsequenced_rows"
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		system, maxRows = "postgresql", "--pg_max_unsequenced_rows"
+	}
+	args := []string{"--quota_system=" + system}
+	if quota.MaxUnsequencedRows != nil {
+		args = append(args, fmt.Sprintf("%s=%d", maxRows, *quota.MaxUnsequencedRows))
+	}
+	return args
+}
+
+// postgresqlSSL returns the libpq SSL parameters matching the TLS mode
+func (d Database) postgresqlSSL() []string {
+	switch d.TLSMode {
+	case DBTLSPreferred:
+		return []string{"sslmode=prefer"}
+	case DBTLSSkipVerify:
+		return []string{"sslmode=require"}
+	case DBTLSVerifyFull:
+		if d.CA {
+			return []string{"sslmode=verify-full", "sslrootcert=" + path.Join(dbTLSPath, "ca.crt")}
+		}
+		return []string{"sslmode=verify-full"}
+	default:
+		return []string{"sslmode=disable"}
+	}
+}
+
+// schemaInitContainer returns the container creating the Trillian schema when the database is empty
+func (d Database) schemaInitContainer(image string) *core.Container {
+	if d.Engine != v1alpha1.DatabaseEnginePostgreSQL {
+		// MySQL database image is shipped with the schema
+		return nil
+	}
+	env := d.clientEnv()
+	for _, param := range d.postgresqlSSL() {
+		name, value, _ := strings.Cut(param, "=")
+		env = append(env, core.EnvVar{Name: "PG" + strings.ToUpper(name), Value: value})
+	}
+	return &core.Container{
+		Name:  "init-trillian-schema",
+		Image: image,
+		Env:   env,
+		Command: []string{
+			"sh",
+			"-c",
+			"if [ -z \"$(psql -tAc \"SELECT to_regclass('trees')\")\" ]; then psql -v ON_ERROR_STOP=1 -1 -f " + path.Join(dbSchemaPath, PostgresqlSchemaKey) + "; fi",
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      dbSchemaVolumeName,
+				MountPath: dbSchemaPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
+// schemaVolume returns the volume holding the PostgreSQL schema
+func schemaVolume(instance *v1alpha1.Trillian) core.Volume {
+	return core.Volume{
+		Name: dbSchemaVolumeName,
+		VolumeSource: core.VolumeSource{
+			ConfigMap: &core.ConfigMapVolumeSource{
+				LocalObjectReference: core.LocalObjectReference{Name: utils.ResourceName(instance, PostgresqlSchemaConfigMap)},
+			},
+		},
+	}
+}
+
+// serverArgs returns the container arguments enabling TLS on the managed database
+func (d Database) serverArgs() []string {
+	if !d.ServerCert {
+		return nil
+	}
+	cert, key, ca := path.Join(dbTLSPath, "tls.crt"), path.Join(dbTLSPath, "tls.key"), path.Join(dbTLSPath, "ca.crt")
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		args := []string{"run-postgresql", "-c", "ssl=on", "-c", "ssl_cert_file=" + cert, "-c", "ssl_key_file=" + key}
+		if d.CA {
+			args = append(args, "-c", "ssl_ca_file="+ca)
+		}
+		return args
+	}
+	args := []string{"run-mysqld", "--ssl-cert=" + cert, "--ssl-key=" + key}
+	if d.CA {
+		args = append(args, "--ssl-ca="+ca)
+	}
+	return args
+}
+
+// mountTLS mounts the CA and, for the database server, the server certificate to the container
+func (d Database) mountTLS(template *core.PodTemplateSpec, container *core.Container, server bool) {
+	items := make([]core.KeyToPath, 0, 3)
+	if d.CA {
+		items = append(items, core.KeyToPath{Key: DBSecretKey(d.Engine, DBSecretTLSCA), Path: "ca.crt"})
+	}
+	if server && d.ServerCert {
+		items = append(items,
+			core.KeyToPath{Key: DBSecretKey(d.Engine, DBSecretTLSServerCert), Path: "tls.crt"},
+			core.KeyToPath{Key: DBSecretKey(d.Engine, DBSecretTLSServerKey), Path: "tls.key"},
+		)
+	}
+	if len(items) == 0 {
+		return
+	}
+	container.VolumeMounts = append(container.VolumeMounts, core.VolumeMount{
+		Name:      dbTLSVolumeName,
+		MountPath: dbTLSPath,
+		ReadOnly:  true,
+	})
+	for _, v := range template.Spec.Volumes {
+		if v.Name == dbTLSVolumeName {
+			return
+		}
+	}
+	template.Spec.Volumes = append(template.Spec.Volumes, core.Volume{
+		Name: dbTLSVolumeName,
+		VolumeSource: core.VolumeSource{
+			Secret: &core.SecretVolumeSource{
+				SecretName: d.SecretName,
+				Items:      items,
+				// PostgreSQL refuses private keys readable by others
+				DefaultMode: utils.Pointer(int32(0640)),
+			},
+		},
+	})
+}
@@ -0,0 +1,249 @@
+package trillianUtils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestResolveDatabase(t *testing.T) {
+	tests := []struct {
+		name    string
+		engine  v1alpha1.DatabaseEngine
+		data    map[string][]byte
+		want    Database
+		wantErr bool
+	}{
+		{
+			name:   "no TLS entries",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			data:   map[string][]byte{},
+			want:   Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled},
+		},
+		{
+			name:   "CA defaults to verify-full",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			data:   map[string][]byte{"mysql-tls-ca": []byte("ca")},
+			want:   Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true},
+		},
+		{
+			name:   "explicit mode",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			data:   map[string][]byte{"mysql-tls-ca": []byte("ca"), "mysql-tls-mode": []byte("skip-verify")},
+			want:   Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSSkipVerify, CA: true},
+		},
+		{
+			name:   "server certificate",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			data:   map[string][]byte{"mysql-tls-server-cert": []byte("cert"), "mysql-tls-server-key": []byte("key")},
+			want:   Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled, ServerCert: true},
+		},
+		{
+			name:   "postgresql entries",
+			engine: v1alpha1.DatabaseEnginePostgreSQL,
+			data:   map[string][]byte{"postgresql-tls-ca": []byte("ca"), "mysql-tls-mode": []byte("disabled")},
+			want:   Database{Engine: v1alpha1.DatabaseEnginePostgreSQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true},
+		},
+		{
+			name:    "certificate without key",
+			engine:  v1alpha1.DatabaseEngineMySQL,
+			data:    map[string][]byte{"mysql-tls-server-cert": []byte("cert")},
+			wantErr: true,
+		},
+		{
+			name:    "client certificate",
+			engine:  v1alpha1.DatabaseEngineMySQL,
+			data:    map[string][]byte{"mysql-tls-ca": []byte("ca"), "mysql-tls-cert": []byte("cert"), "mysql-tls-key": []byte("key")},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported mode",
+			engine:  v1alpha1.DatabaseEngineMySQL,
+			data:    map[string][]byte{"mysql-tls-mode": []byte("required")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got, err := ResolveDatabase(&core.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db"}, Data: tt.data}, tt.engine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCreateTrillDeployment_Database(t *testing.T) {
+	instance := &v1alpha1.Trillian{
+		ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+		Status: v1alpha1.TrillianStatus{
+			Db: v1alpha1.TrillianDB{DatabaseSecretRef: &v1alpha1.LocalObjectReference{Name: "db"}},
+		},
+	}
+	const (
+		mysqlURI      = "--mysql_uri=$(MYSQL_USER):$(MYSQL_PASSWORD)@tcp($(MYSQL_HOSTNAME):$(MYSQL_PORT))/$(MYSQL_DATABASE)"
+		postgresqlURI = "--postgresql_uri=host=$(PGHOST) port=$(PGPORT) user=$(PGUSER) dbname=$(PGDATABASE)"
+	)
+
+	tests := []struct {
+		name           string
+		db             Database
+		args           []string
+		initContainers int
+		volumes        int
+	}{
+		{
+			name:           "mysql",
+			db:             Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled},
+			args:           []string{"--storage_system=mysql", "--quota_system=mysql", mysqlURI},
+			initContainers: 1,
+		},
+		{
+			name:           "mysql skip-verify",
+			db:             Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSSkipVerify},
+			args:           []string{mysqlURI + "?tls=skip-verify"},
+			initContainers: 1,
+		},
+		{
+			name:           "mysql verify-full with system roots",
+			db:             Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSVerifyFull},
+			args:           []string{mysqlURI + "?tls=true"},
+			initContainers: 1,
+		},
+		{
+			name:           "mysql verify-full with CA",
+			db:             Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true, ServerCert: true},
+			args:           []string{mysqlURI, "--mysql_tls_ca=/var/run/secrets/tas/db-tls/ca.crt", "--mysql_server_name=$(MYSQL_HOSTNAME)"},
+			initContainers: 1,
+			volumes:        1,
+		},
+		{
+			name:           "postgresql",
+			db:             Database{Engine: v1alpha1.DatabaseEnginePostgreSQL, SecretName: "db", TLSMode: DBTLSDisabled},
+			args:           []string{"--storage_system=postgresql", "--quota_system=postgresql", postgresqlURI + " sslmode=disable"},
+			initContainers: 2,
+			volumes:        1,
+		},
+		{
+			name:           "postgresql verify-full with CA",
+			db:             Database{Engine: v1alpha1.DatabaseEnginePostgreSQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true},
+			args:           []string{postgresqlURI + " sslmode=verify-full sslrootcert=/var/run/secrets/tas/db-tls/ca.crt"},
+			initContainers: 2,
+			volumes:        2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dep, err := CreateTrillDeployment(instance, "image", "trillian-logserver", "sa", map[string]string{}, tt.db)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(dep.Spec.Template.Spec.Containers[0].Args).To(ContainElements(tt.args))
+			g.Expect(dep.Spec.Template.Spec.InitContainers).To(HaveLen(tt.initContainers))
+			g.Expect(dep.Spec.Template.Spec.Volumes).To(HaveLen(tt.volumes))
+			for _, v := range dep.Spec.Template.Spec.Volumes {
+				if v.Secret != nil {
+					// client mounts only the CA
+					g.Expect(v.Secret.Items).To(HaveLen(1))
+				}
+			}
+		})
+	}
+}
+
+func TestCreateTrillDb_Database(t *testing.T) {
+	g := NewWithT(t)
+	instance := &v1alpha1.Trillian{
+		ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+		Spec: v1alpha1.TrillianSpec{
+			Db: v1alpha1.TrillianDB{Create: ptr.To(true)},
+		},
+		Status: v1alpha1.TrillianStatus{
+			Db: v1alpha1.TrillianDB{
+				DatabaseSecretRef: &v1alpha1.LocalObjectReference{Name: "db"},
+				Pvc:               v1alpha1.Pvc{Name: "pvc"},
+			},
+		},
+	}
+
+	dep, err := CreateTrillDb(instance, "trillian-db", "sa", nil, map[string]string{}, Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dep.Spec.Template.Spec.Containers[0].Args).To(BeEmpty())
+	g.Expect(dep.Spec.Template.Spec.Containers[0].Env[2].ValueFrom.SecretKeyRef.Key).To(Equal("mysql-root-password"))
+
+	dep, err = CreateTrillDb(instance, "trillian-db", "sa", nil, map[string]string{}, Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true, ServerCert: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(dep.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
+		"run-mysqld",
+		"--ssl-cert=/var/run/secrets/tas/db-tls/tls.crt",
+		"--ssl-key=/var/run/secrets/tas/db-tls/tls.key",
+		"--ssl-ca=/var/run/secrets/tas/db-tls/ca.crt",
+	}))
+	g.Expect(dep.Spec.Template.Spec.Volumes).To(HaveLen(2))
+
+	dep, err = CreateTrillDb(instance, "trillian-db", "sa", nil, map[string]string{}, Database{Engine: v1alpha1.DatabaseEnginePostgreSQL, SecretName: "db", TLSMode: DBTLSDisabled, ServerCert: true})
+	g.Expect(err).ToNot(HaveOccurred())
+	container := dep.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Ports[0].ContainerPort).To(Equal(int32(5432)))
+	g.Expect(container.Args).To(Equal([]string{
+		"run-postgresql",
+		"-c", "ssl=on",
+		"-c", "ssl_cert_file=/var/run/secrets/tas/db-tls/tls.crt",
+		"-c", "ssl_key_file=/var/run/secrets/tas/db-tls/tls.key",
+	}))
+	for _, env := range container.Env {
+		g.Expect(env.ValueFrom.SecretKeyRef.Key).To(HavePrefix("postgresql-"))
+	}
+}
+
+func TestQuotaArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine v1alpha1.DatabaseEngine
+		quota  v1alpha1.TrillianQuota
+		want   []string
+	}{
+		{
+			name:   "database quota by default",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			want:   []string{"--quota_system=mysql"},
+		},
+		{
+			name:   "mysql max unsequenced rows",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			quota:  v1alpha1.TrillianQuota{System: v1alpha1.TrillianQuotaDatabase, MaxUnsequencedRows: ptr.To(int32(1000))},
+			want:   []string{"--quota_system=mysql", "--max_unsequenced_rows=1000"},
+		},
+		{
+			name:   "postgresql max unsequenced rows",
+			engine: v1alpha1.DatabaseEnginePostgreSQL,
+			quota:  v1alpha1.TrillianQuota{System: v1alpha1.TrillianQuotaDatabase, MaxUnsequencedRows: ptr.To(int32(1000))},
+			want:   []string{"--quota_system=postgresql", "--pg_max_unsequenced_rows=1000"},
+		},
+		{
+			name:   "etcd",
+			engine: v1alpha1.DatabaseEngineMySQL,
+			quota:  v1alpha1.TrillianQuota{System: v1alpha1.TrillianQuotaEtcd},
+			want:   []string{"--quota_system=etcd"},
+		},
+		{
+			name:   "noop",
+			engine: v1alpha1.DatabaseEnginePostgreSQL,
+			quota:  v1alpha1.TrillianQuota{System: v1alpha1.TrillianQuotaNoop},
+			want:   []string{"--quota_system=noop"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Database{Engine: tt.engine}.quotaArgs(tt.quota)).To(Equal(tt.want))
+		})
+	}
+}
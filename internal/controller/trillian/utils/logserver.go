@@ -0,0 +1,62 @@
+package trillianUtils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResolveTrillian returns the Trillian instance serving the owner when no address is configured.
+// The Trillian with the same name as the owner takes precedence, otherwise the only Trillian
+// in the namespace is used. Nil is returned when the namespace has no Trillian.
+func ResolveTrillian(ctx context.Context, c client.Client, owner metav1.Object) (*v1alpha1.Trillian, error) {
+	trillian := &v1alpha1.Trillian{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: owner.GetName()}, trillian)
+	switch {
+	case err == nil:
+		return trillian, nil
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
+
+	list := &v1alpha1.TrillianList{}
+	if err := c.List(ctx, list, client.InNamespace(owner.GetNamespace())); err != nil {
+		return nil, err
+	}
+	switch len(list.Items) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &list.Items[0], nil
+	default:
+		return nil, fmt.Errorf("found %d Trillian instances in namespace %s, set the Trillian address explicitly", len(list.Items), owner.GetNamespace())
+	}
+}
+
+// LogserverService returns the name of the Trillian Log Server service used by the owner when no address is configured.
+// The legacy name is returned when the namespace has no Trillian.
+func LogserverService(ctx context.Context, c client.Client, owner metav1.Object) (string, error) {
+	trillian, err := ResolveTrillian(ctx, c, owner)
+	if err != nil {
+		return "", err
+	}
+	if trillian == nil {
+		return actions.LogserverDeploymentName, nil
+	}
+	return utils.ResourceName(trillian, actions.LogserverDeploymentName), nil
+}
+
+// LogserverAddress returns the in-cluster address of the Trillian Log Server used by the owner when no address is configured
+func LogserverAddress(ctx context.Context, c client.Client, owner metav1.Object) (string, error) {
+	service, err := LogserverService(ctx, c, owner)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%s.svc", service, owner.GetNamespace()), nil
+}
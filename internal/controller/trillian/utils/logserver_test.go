@@ -0,0 +1,69 @@
+package trillianUtils
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestLogserverAddress(t *testing.T) {
+	trillian := func(name string, legacy bool) *v1alpha1.Trillian {
+		instance := &v1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		if legacy {
+			instance.Annotations = map[string]string{annotations.LegacyNames: "true"}
+		}
+		return instance
+	}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no trillian",
+			want: "trillian-logserver.default.svc",
+		},
+		{
+			name:    "trillian with the owner name",
+			objects: []client.Object{trillian("other", false), trillian("rekor", false)},
+			want:    "rekor-trillian-logserver.default.svc",
+		},
+		{
+			name:    "only trillian in the namespace",
+			objects: []client.Object{trillian("other", false)},
+			want:    "other-trillian-logserver.default.svc",
+		},
+		{
+			name:    "trillian with legacy names",
+			objects: []client.Object{trillian("other", true)},
+			want:    "trillian-logserver.default.svc",
+		},
+		{
+			name:    "ambiguous trillian",
+			objects: []client.Object{trillian("a", false), trillian("b", false)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := testAction.FakeClientBuilder().WithObjects(tt.objects...).Build()
+			owner := &v1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default"}}
+
+			address, err := LogserverAddress(context.TODO(), c, owner)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(address).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package trillianUtils
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// CACertVolumeName is the name of the volume holding the Trillian CA certificate in client deployments
+	CACertVolumeName = "trillian-ca"
+	// CACertPath is the path of the Trillian CA certificate in client containers
+	CACertPath = "/var/run/secrets/tas/trillian-ca/ca.crt"
+)
+
+// MountCACert mounts the Trillian CA certificate to the client container
+func MountCACert(template *core.PodTemplateSpec, container *core.Container, ref *v1alpha1.SecretKeySelector) {
+	container.VolumeMounts = append(container.VolumeMounts, core.VolumeMount{
+		Name:      CACertVolumeName,
+		MountPath: path.Dir(CACertPath),
+		ReadOnly:  true,
+	})
+	template.Spec.Volumes = append(template.Spec.Volumes, core.Volume{
+		Name: CACertVolumeName,
+		VolumeSource: core.VolumeSource{
+			Secret: &core.SecretVolumeSource{
+				SecretName: ref.Name,
+				Items: []core.KeyToPath{
+					{
+						Key:  ref.Key,
+						Path: path.Base(CACertPath),
+					},
+				},
+			},
+		},
+	})
+}
+
+// ResolveCACert returns the reference to the CA certificate used to verify the Trillian Log Server, the secret is
+// mounted to the client deployments in the owner namespace. The CA configured on the service takes precedence.
+// Otherwise the CA of the Trillian instance the service points to is used if it enables TLS: the instance serving
+// the owner for the default address or the instance whose Log Server service the address names. An error is returned
+// when that instance is in another namespace, the secret can't be mounted across namespaces.
+// Nil is returned when the connection should not use TLS.
+func ResolveCACert(ctx context.Context, c client.Client, owner metav1.Object, service v1alpha1.TrillianService) (*v1alpha1.SecretKeySelector, error) {
+	ref, namespace, err := resolveCACert(ctx, c, owner, service)
+	if err != nil || ref == nil {
+		return nil, err
+	}
+	if namespace != owner.GetNamespace() {
+		return nil, fmt.Errorf("the CA certificate of Trillian is in namespace %s, set trillian.caCertRef to a secret with the CA certificate in namespace %s", namespace, owner.GetNamespace())
+	}
+	return ref, nil
+}
+
+// resolveCACert returns the reference to the CA certificate used to verify the Trillian Log Server and the namespace
+// of the secret, see ResolveCACert
+func resolveCACert(ctx context.Context, c client.Client, owner metav1.Object, service v1alpha1.TrillianService) (*v1alpha1.SecretKeySelector, string, error) {
+	if service.CACertRef != nil {
+		return service.CACertRef, owner.GetNamespace(), nil
+	}
+
+	var (
+		trillian *v1alpha1.Trillian
+		err      error
+	)
+	if service.Address != "" {
+		trillian, err = trillianForAddress(ctx, c, owner, service.Address)
+	} else {
+		trillian, err = ResolveTrillian(ctx, c, owner)
+	}
+	if err != nil || trillian == nil {
+		return nil, "", err
+	}
+	if trillian.Status.TLS.Enabled && trillian.Status.TLS.CACertRef != nil {
+		return trillian.Status.TLS.CACertRef, trillian.Namespace, nil
+	}
+	return nil, "", nil
+}
+
+// trillianForAddress returns the Trillian instance serving the Log Server on the in-cluster address, the short
+// address names a service in the owner namespace. Nil is returned when the address points elsewhere.
+func trillianForAddress(ctx context.Context, c client.Client, owner metav1.Object, address string) (*v1alpha1.Trillian, error) {
+	labels := strings.Split(strings.TrimSuffix(address, "."), ".")
+	namespace := owner.GetNamespace()
+	if len(labels) > 1 {
+		namespace = labels[1]
+	}
+	if len(labels) > 2 {
+		if domain := strings.Join(labels[2:], "."); domain != "svc" && domain != "svc.cluster.local" {
+			return nil, nil
+		}
+	}
+
+	list := &v1alpha1.TrillianList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if utils.ResourceName(&list.Items[i], actions.LogserverDeploymentName) == labels[0] {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetCACert returns the CA certificate used to verify the Trillian Log Server or nil if TLS is not used.
+// The operator reads the CA certificate of a Trillian instance in another namespace from its namespace.
+func GetCACert(ctx context.Context, c client.Client, owner metav1.Object, service v1alpha1.TrillianService) ([]byte, error) {
+	ref, namespace, err := resolveCACert(ctx, c, owner, service)
+	if err != nil || ref == nil {
+		return nil, err
+	}
+	return k8sutils.GetSecretData(c, namespace, ref)
+}
+
+// TLSChangedPredicate filters Trillian events to those changing the TLS configuration served to the clients
+func TLSChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj, ok := e.ObjectOld.(*v1alpha1.Trillian)
+			if !ok {
+				return false
+			}
+			newObj, ok := e.ObjectNew.(*v1alpha1.Trillian)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldObj.Status.TLS, newObj.Status.TLS)
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
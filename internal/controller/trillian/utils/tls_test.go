@@ -0,0 +1,123 @@
+package trillianUtils
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestResolveCACert(t *testing.T) {
+	ref := func(name string) *v1alpha1.SecretKeySelector {
+		return &v1alpha1.SecretKeySelector{LocalObjectReference: v1alpha1.LocalObjectReference{Name: name}, Key: "ca-bundle"}
+	}
+	trillianIn := func(namespace, name string, tls bool) *v1alpha1.Trillian {
+		instance := &v1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if tls {
+			instance.Status.TLS = v1alpha1.TLS{Enabled: true, CACertRef: ref(name + "-ca")}
+		}
+		return instance
+	}
+	trillian := func(name string, tls bool) *v1alpha1.Trillian {
+		return trillianIn("default", name, tls)
+	}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		service v1alpha1.TrillianService
+		want    *v1alpha1.SecretKeySelector
+		wantErr bool
+	}{
+		{
+			name:    "configured CA",
+			objects: []client.Object{trillian("rekor", true)},
+			service: v1alpha1.TrillianService{CACertRef: ref("user")},
+			want:    ref("user"),
+		},
+		{
+			name:    "default address",
+			objects: []client.Object{trillian("rekor", true)},
+			want:    ref("rekor-ca"),
+		},
+		{
+			name:    "default address without TLS",
+			objects: []client.Object{trillian("rekor", false)},
+		},
+		{
+			name:    "address of trillian in the namespace",
+			objects: []client.Object{trillian("rekor", true), trillian("other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.default.svc"},
+			want:    ref("other-ca"),
+		},
+		{
+			name:    "short address of trillian in the namespace",
+			objects: []client.Object{trillian("other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver"},
+			want:    ref("other-ca"),
+		},
+		{
+			name:    "address of trillian in other namespace",
+			objects: []client.Object{trillianIn("other", "other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.other.svc"},
+			wantErr: true,
+		},
+		{
+			name:    "address of trillian in other namespace without TLS",
+			objects: []client.Object{trillianIn("other", "other", false)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.other.svc"},
+		},
+		{
+			name:    "configured CA for trillian in other namespace",
+			objects: []client.Object{trillianIn("other", "other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.other.svc", CACertRef: ref("user")},
+			want:    ref("user"),
+		},
+		{
+			name:    "address of trillian missing in other namespace",
+			objects: []client.Object{trillian("other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.other.svc"},
+		},
+		{
+			name:    "external address",
+			objects: []client.Object{trillian("other", true)},
+			service: v1alpha1.TrillianService{Address: "other-trillian-logserver.default.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := testAction.FakeClientBuilder().WithObjects(tt.objects...).Build()
+			owner := &v1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default"}}
+
+			got, err := ResolveCACert(context.TODO(), c, owner, tt.service)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetCACert(t *testing.T) {
+	g := NewWithT(t)
+	trillian := &v1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "trillian-system"}}
+	trillian.Status.TLS = v1alpha1.TLS{Enabled: true, CACertRef: &v1alpha1.SecretKeySelector{LocalObjectReference: v1alpha1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"}}
+	secret := &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "trillian-system"},
+		Data:       map[string][]byte{"ca.crt": []byte("CA")},
+	}
+	c := testAction.FakeClientBuilder().WithObjects(trillian, secret).Build()
+	owner := &v1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default"}}
+
+	// the operator reads the CA from the namespace of Trillian
+	ca, err := GetCACert(context.TODO(), c, owner, v1alpha1.TrillianService{Address: "trillian-trillian-logserver.trillian-system.svc"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ca).To(Equal([]byte("CA")))
+}
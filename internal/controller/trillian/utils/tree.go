@@ -0,0 +1,42 @@
+package trillianUtils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// GetTreeID returns the tree ID resolved by the referenced TrillianTree.
+// It returns nil when the tree has not been resolved yet.
+func GetTreeID(ctx context.Context, c client.Client, namespace string, ref *v1alpha1.LocalObjectReference) (*int64, error) {
+	tree := &v1alpha1.TrillianTree{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, tree); err != nil {
+		return nil, fmt.Errorf("could not get TrillianTree %s: %w", ref.Name, err)
+	}
+	return tree.Status.TreeID, nil
+}
+
+// TreeIDChangedPredicate filters TrillianTree events to those changing the resolved tree ID
+func TreeIDChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldObj, ok := e.ObjectOld.(*v1alpha1.TrillianTree)
+			if !ok {
+				return false
+			}
+			newObj, ok := e.ObjectNew.(*v1alpha1.TrillianTree)
+			if !ok {
+				return false
+			}
+			return !equality.Semantic.DeepEqual(oldObj.Status.TreeID, newObj.Status.TreeID)
+		},
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
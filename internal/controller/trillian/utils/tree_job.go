@@ -0,0 +1,185 @@
+package trillianUtils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// CreateTreeJobName is the name prefix of the Job creating a Trillian tree
+const CreateTreeJobName = "create-tree"
+
+// CreateTreeJob returns the Job creating a tree in the Trillian backend for the owner.
+// The tree ID is written to the termination message of the job container.
+func CreateTreeJob(owner common.TreeOwner, displayName, trillianURL string, caCertRef *v1alpha1.SecretKeySelector, serviceAccount string, labels map[string]string) *batchv1.Job {
+	command := []string{
+		"/manager", common.CreateTreeCommand,
+		"--trillian-url=" + trillianURL,
+		"--display-name=" + displayName,
+		"--owner-kind=" + owner.Kind,
+		"--owner-namespace=" + owner.Namespace,
+		"--owner-name=" + owner.Name,
+		"--owner-uid=" + string(owner.UID),
+		fmt.Sprintf("--deadline=%d", constants.CreateTreeDeadline),
+	}
+	if caCertRef != nil {
+		command = append(command, "--ca-cert="+CACertPath)
+	}
+
+	job := k8sutils.CreateJob(owner.Namespace, CreateTreeJobName, labels, constants.CreateTreeImage, serviceAccount,
+		1, 1, constants.CreateTreeDeadline, 3, command, nil)
+	template := &job.Spec.Template
+	container := &template.Spec.Containers[0]
+	container.TerminationMessagePath = common.TerminationLogPath
+	container.TerminationMessagePolicy = core.TerminationMessageReadFile
+	if caCertRef != nil {
+		MountCACert(template, container, caCertRef)
+	}
+	return job
+}
+
+// ResolveTreeWithJob creates the tree for the owner in a Job running in its namespace and returns the tree ID,
+// true is returned when the Job created a new tree. Nil is returned while the Job is running. The finished Job is removed.
+func ResolveTreeWithJob(ctx context.Context, c client.Client, owner client.Object, job *batchv1.Job) (*int64, bool, error) {
+	result, err := RunJob(ctx, c, owner, job.Namespace, job.Labels, func() (*batchv1.Job, error) {
+		return job, nil
+	})
+	if result == nil {
+		return nil, false, err
+	}
+	treeID, created, resultErr := treeJobResult(result)
+	return treeID, created, errors.Join(resultErr, err)
+}
+
+// RunJob runs the Job created by newJob once and returns its result, the Job is identified by its labels and the owner.
+// Nil is returned while the Job is running. The finished Job is removed.
+func RunJob(ctx context.Context, c client.Client, owner client.Object, namespace string, labels map[string]string, newJob func() (*batchv1.Job, error)) (*JobResult, error) {
+	list := &batchv1.JobList{}
+	if err := c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("could not list jobs: %w", err)
+	}
+
+	var current *batchv1.Job
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], owner) {
+			current = &list.Items[i]
+			break
+		}
+	}
+
+	if current == nil {
+		job, err := newJob()
+		if err != nil {
+			return nil, err
+		}
+		if err = controllerutil.SetControllerReference(owner, job, c.Scheme()); err != nil {
+			return nil, fmt.Errorf("could not set controller reference for Job: %w", err)
+		}
+		if err = c.Create(ctx, job); err != nil {
+			return nil, fmt.Errorf("could not create Job: %w", err)
+		}
+		return nil, nil
+	}
+
+	result, err := GetJobResult(ctx, c, current)
+	if result == nil || err != nil {
+		return nil, err
+	}
+	if err = c.Delete(ctx, current, client.PropagationPolicy("Background")); client.IgnoreNotFound(err) != nil {
+		return result, fmt.Errorf("could not remove Job: %w", err)
+	}
+	return result, nil
+}
+
+// treeJobResult returns the tree ID written by the succeeded job or an error when the job failed.
+// True is returned when the job created a new tree.
+func treeJobResult(result *JobResult) (*int64, bool, error) {
+	if !result.Succeeded {
+		if strings.Contains(result.Message, common.ErrAmbiguousTree.Error()) {
+			return nil, false, fmt.Errorf("create tree job failed: %w", jobError{message: result.Message, cause: common.ErrAmbiguousTree})
+		}
+		return nil, false, fmt.Errorf("create tree job failed: %s", result.Message)
+	}
+	fields := strings.Fields(result.Message)
+	if len(fields) == 0 {
+		return nil, false, errors.New("create tree job succeeded but its result is not available")
+	}
+	treeID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("unexpected termination message of create tree job: %w", err)
+	}
+	// the jobs of the previous operator versions wrote the tree ID only
+	created := len(fields) == 1 || fields[1] == common.TreeCreated
+	return &treeID, created, nil
+}
+
+// JobResult is the outcome of a finished Job
+type JobResult struct {
+	Succeeded bool
+	// Message is the termination message of the succeeded container or of the failed one.
+	// The message of the failed Job condition is used when no container reported the failure.
+	Message string
+}
+
+// GetJobResult returns the result of the finished Job, nil is returned while the Job is running
+func GetJobResult(ctx context.Context, c client.Client, job *batchv1.Job) (*JobResult, error) {
+	var failed *batchv1.JobCondition
+	for i, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == core.ConditionTrue {
+			failed = &job.Status.Conditions[i]
+		}
+	}
+	if failed == nil && job.Status.Succeeded == 0 {
+		return nil, nil
+	}
+
+	pods := &core.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		return nil, fmt.Errorf("could not list pods of job %s: %w", job.Name, err)
+	}
+	result := &JobResult{Succeeded: failed == nil}
+	for _, pod := range pods.Items {
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			terminated := status.State.Terminated
+			if terminated == nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated == nil || (terminated.ExitCode == 0) != result.Succeeded {
+				continue
+			}
+			if message := strings.TrimSpace(terminated.Message); message != "" {
+				result.Message = message
+			}
+		}
+	}
+	if !result.Succeeded && result.Message == "" {
+		result.Message = failed.Message
+	}
+	return result, nil
+}
+
+// jobError is the error reported by the job, it matches the error the job failed with
+type jobError struct {
+	message string
+	cause   error
+}
+
+func (e jobError) Error() string {
+	return e.message
+}
+
+func (e jobError) Is(target error) bool {
+	return target == e.cause
+}
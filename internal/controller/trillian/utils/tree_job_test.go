@@ -0,0 +1,168 @@
+package trillianUtils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestCreateTreeJob(t *testing.T) {
+	g := NewWithT(t)
+	owner := common.TreeOwner{Kind: "Rekor", Namespace: "default", Name: "rekor", UID: "uid"}
+
+	job := CreateTreeJob(owner, "rekor-tree", "trillian:8091", &v1alpha1.SecretKeySelector{
+		LocalObjectReference: v1alpha1.LocalObjectReference{Name: "ca"},
+		Key:                  "ca.crt",
+	}, "sa", map[string]string{"app": "test"})
+
+	g.Expect(job.Namespace).To(Equal("default"))
+	g.Expect(job.GenerateName).To(Equal(CreateTreeJobName + "-"))
+	g.Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal("sa"))
+	container := job.Spec.Template.Spec.Containers[0]
+	g.Expect(container.Command).To(ContainElements(
+		common.CreateTreeCommand,
+		"--trillian-url=trillian:8091",
+		"--display-name=rekor-tree",
+		"--owner-kind=Rekor",
+		"--owner-namespace=default",
+		"--owner-name=rekor",
+		"--owner-uid=uid",
+		"--ca-cert="+CACertPath,
+	))
+	g.Expect(container.TerminationMessagePolicy).To(Equal(core.TerminationMessageReadFile))
+	g.Expect(container.VolumeMounts).To(HaveLen(1))
+	g.Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(1))
+}
+
+func TestResolveTreeWithJob(t *testing.T) {
+	owner := &v1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: "default", UID: "uid"}}
+	labels := map[string]string{"app": "create-tree"}
+
+	newJob := func() *batchv1.Job {
+		return CreateTreeJob(common.NewTreeOwner("Rekor", owner), "rekor-tree", "trillian:8091", nil, "sa", labels)
+	}
+	ownedJob := func(status batchv1.JobStatus) *batchv1.Job {
+		job := newJob()
+		job.Name = "create-tree-abcde"
+		job.Status = status
+		_ = controllerutil.SetControllerReference(owner, job, testAction.FakeClientBuilder().Build().Scheme())
+		return job
+	}
+	jobPod := func(exitCode int32, message string) *core.Pod {
+		return &core.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "create-tree-abcde-x", Namespace: "default", Labels: map[string]string{"job-name": "create-tree-abcde"}},
+			Status: core.PodStatus{ContainerStatuses: []core.ContainerStatus{{
+				State: core.ContainerState{Terminated: &core.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+			}}},
+		}
+	}
+	failed := batchv1.JobStatus{Failed: 1, Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: core.ConditionTrue, Message: "BackoffLimitExceeded"}}}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		verify  func(Gomega, client.WithWatch, *int64, bool, error)
+	}{
+		{
+			name: "create job",
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(treeID).To(BeNil())
+
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(1))
+				g.Expect(metav1.IsControlledBy(&list.Items[0], owner)).To(BeTrue())
+			},
+		},
+		{
+			name:    "job is running",
+			objects: []client.Object{ownedJob(batchv1.JobStatus{Active: 1})},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(treeID).To(BeNil())
+
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(1))
+			},
+		},
+		{
+			name:    "job succeeded",
+			objects: []client.Object{ownedJob(batchv1.JobStatus{Succeeded: 1}), jobPod(0, "5432 created\n")},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(treeID).To(Equal(ptr.To(int64(5432))))
+				g.Expect(created).To(BeTrue())
+
+				err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "create-tree-abcde"}, &batchv1.Job{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			},
+		},
+		{
+			name:    "job adopted tree",
+			objects: []client.Object{ownedJob(batchv1.JobStatus{Succeeded: 1}), jobPod(0, "5432 adopted")},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(treeID).To(Equal(ptr.To(int64(5432))))
+				g.Expect(created).To(BeFalse())
+			},
+		},
+		{
+			name:    "job failed",
+			objects: []client.Object{ownedJob(failed), jobPod(1, "connection refused")},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).To(MatchError(ContainSubstring("connection refused")))
+				g.Expect(errors.Is(err, common.ErrAmbiguousTree)).To(BeFalse())
+				g.Expect(treeID).To(BeNil())
+
+				err = c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "create-tree-abcde"}, &batchv1.Job{})
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			},
+		},
+		{
+			name:    "job failed with ambiguous tree",
+			objects: []client.Object{ownedJob(failed), jobPod(1, common.ErrAmbiguousTree.Error()+": 1, 2")},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(errors.Is(err, common.ErrAmbiguousTree)).To(BeTrue())
+				g.Expect(treeID).To(BeNil())
+			},
+		},
+		{
+			name:    "job of other owner is ignored",
+			objects: []client.Object{func() *batchv1.Job { j := newJob(); j.Name = "other"; return j }()},
+			verify: func(g Gomega, c client.WithWatch, treeID *int64, created bool, err error) {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(treeID).To(BeNil())
+
+				list := &batchv1.JobList{}
+				g.Expect(c.List(context.TODO(), list)).To(Succeed())
+				g.Expect(list.Items).To(HaveLen(2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := testAction.FakeClientBuilder().
+				WithObjects(tt.objects...).
+				WithStatusSubresource(&batchv1.Job{}).
+				Build()
+
+			treeID, created, err := ResolveTreeWithJob(context.TODO(), c, owner, newJob())
+			tt.verify(g, c, treeID, created, err)
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package trillianUtils
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	"github.com/securesign/operator/internal/controller/constants"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultBackupPvcName is the name of the PVC created for the backups when the backup spec does not name one
+	DefaultBackupPvcName = "trillian-db-backup"
+	// LatestBackup is the value of the restore annotation selecting the newest backup
+	LatestBackup = "latest"
+
+	// S3 credentials secret entries
+	BackupS3AccessKeyID     = "access-key-id"
+	BackupS3SecretAccessKey = "secret-access-key"
+
+	backupVolumeName = "backup"
+	backupPath       = "/var/run/tas/backup"
+	// backupPattern matches the names of the backups, they sort by the time of the backup
+	backupPattern = `^trillian-[0-9]{14}\.sql\.gz$`
+	// listBackupsPVC and listBackupsS3 print the backups in the backup directory or the bucket, newest first
+	listBackupsPVC = `for f in "$BACKUP_DIR"/*; do f="${f##*/}"; if [[ $f =~ ` + backupPattern + ` ]]; then echo "$f"; fi; done | sort -r`
+	listBackupsS3  = `aws s3 ls "s3://$BACKUP_BUCKET/$BACKUP_PREFIX" | while read -r _ _ _ f; do if [[ $f =~ ` + backupPattern + ` ]]; then echo "$f"; fi; done | sort -r`
+)
+
+// CreateBackupCronJob returns the CronJob dumping the managed database to the PVC or the S3 bucket of the backup spec.
+// The name and the size of the backup are written to the termination message, see ParseBackupResult.
+func CreateBackupCronJob(instance *v1alpha1.Trillian, name string, sa string, labels map[string]string, db Database) (*batchv1.CronJob, error) {
+	backup := instance.Spec.Db.Backup
+	if backup == nil {
+		return nil, errors.New("backup is not configured")
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          backup.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: ptr.To(int32(2)),
+					Template:     *backupTemplate(instance, backup, sa, db),
+				},
+			},
+		},
+	}, nil
+}
+
+// CreateBackupJob returns the Job taking a single backup of the managed database to the target of the backup spec
+func CreateBackupJob(instance *v1alpha1.Trillian, name string, sa string, labels map[string]string, db Database, backup *v1alpha1.TrillianDBBackup) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    instance.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(2)),
+			Template:     *backupTemplate(instance, backup, sa, db),
+		},
+	}
+}
+
+func backupTemplate(instance *v1alpha1.Trillian, backup *v1alpha1.TrillianDBBackup, sa string, db Database) *core.PodTemplateSpec {
+	var template *core.PodTemplateSpec
+	image := DbImage(instance)
+	if backup.S3 != nil {
+		dump := db.dumpContainer("dump", image, "$BACKUP_DIR/$name", `echo -n "$name" > "$BACKUP_DIR/name"`)
+		upload := s3Container("upload", backup.S3, strings.Join([]string{
+			"set -eo pipefail",
+			`name=$(cat "$BACKUP_DIR/name")`,
+			`aws s3 cp --only-show-errors "$BACKUP_DIR/$name" "s3://$BACKUP_BUCKET/$BACKUP_PREFIX$name"`,
+			listBackupsS3 + ` | tail -n +$((BACKUP_RETENTION+1)) | while read -r f; do aws s3 rm --only-show-errors "s3://$BACKUP_BUCKET/$BACKUP_PREFIX$f"; done`,
+			`printf '%s %s' "$name" "$(stat -c %s "$BACKUP_DIR/$name")" > ` + common.TerminationLogPath,
+		}, "\n"))
+		template = backupPodTemplate(sa, []core.Container{*dump}, *upload, emptyDirVolume())
+		db.mountTLS(template, &template.Spec.InitContainers[0], false)
+	} else {
+		dump := db.dumpContainer("backup", image, "$BACKUP_DIR/$name.partial", strings.Join([]string{
+			`mv "$BACKUP_DIR/$name.partial" "$BACKUP_DIR/$name"`,
+			listBackupsPVC + ` | tail -n +$((BACKUP_RETENTION+1)) | while read -r f; do rm -f "$BACKUP_DIR/$f"; done`,
+			`printf '%s %s' "$name" "$(stat -c %s "$BACKUP_DIR/$name")" > ` + common.TerminationLogPath,
+		}, "\n"))
+		template = backupPodTemplate(sa, nil, *dump, pvcVolume(BackupPvcName(instance, backup)))
+		db.mountTLS(template, &template.Spec.Containers[0], false)
+	}
+
+	retention := core.EnvVar{Name: "BACKUP_RETENTION", Value: strconv.Itoa(int(backup.Retention))}
+	for i := range template.Spec.InitContainers {
+		template.Spec.InitContainers[i].Env = append(template.Spec.InitContainers[i].Env, retention)
+	}
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, retention)
+	}
+	return template
+}
+
+// CreateRestoreJob returns the Job restoring the named backup to the managed database, LatestBackup restores the newest backup.
+// The name of the restored backup is written to the termination message.
+func CreateRestoreJob(instance *v1alpha1.Trillian, name string, sa string, labels map[string]string, db Database, backupName string) (*batchv1.Job, error) {
+	backup := instance.Spec.Db.Backup
+	if backup == nil {
+		return nil, errors.New("backup is not configured")
+	}
+
+	resolve := func(list string) string {
+		return strings.Join([]string{
+			`name="$BACKUP_NAME"`,
+			`if [ "$name" = "` + LatestBackup + `" ]; then name=$(` + list + ` | head -n 1); fi`,
+			`if [[ ! $name =~ ` + backupPattern + ` ]]; then echo "backup $BACKUP_NAME not found" | tee ` + common.TerminationLogPath + `; exit 1; fi`,
+		}, "\n")
+	}
+
+	var template *core.PodTemplateSpec
+	if backup.S3 != nil {
+		download := s3Container("download", backup.S3, strings.Join([]string{
+			"set -eo pipefail",
+			resolve(listBackupsS3),
+			`aws s3 cp --only-show-errors "s3://$BACKUP_BUCKET/$BACKUP_PREFIX$name" "$BACKUP_DIR/$name" || { echo "could not download backup $name" | tee ` + common.TerminationLogPath + `; exit 1; }`,
+			`echo -n "$name" > "$BACKUP_DIR/name"`,
+		}, "\n"))
+		restore := db.restoreContainer("restore", DbImage(instance), `name=$(cat "$BACKUP_DIR/name")`)
+		template = backupPodTemplate(sa, []core.Container{*download}, *restore, emptyDirVolume())
+	} else {
+		restore := db.restoreContainer("restore", DbImage(instance), resolve(listBackupsPVC))
+		template = backupPodTemplate(sa, nil, *restore, pvcVolume(BackupPvcName(instance, backup)))
+	}
+	db.mountTLS(template, &template.Spec.Containers[0], false)
+
+	for i := range template.Spec.InitContainers {
+		template.Spec.InitContainers[i].Env = append(template.Spec.InitContainers[i].Env, core.EnvVar{Name: "BACKUP_NAME", Value: backupName})
+	}
+	for i := range template.Spec.Containers {
+		template.Spec.Containers[i].Env = append(template.Spec.Containers[i].Env, core.EnvVar{Name: "BACKUP_NAME", Value: backupName})
+	}
+	// the restore is not retried, a partially restored database needs the attention of the administrator
+	template.Spec.RestartPolicy = core.RestartPolicyNever
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    instance.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template:     *template,
+		},
+	}, nil
+}
+
+// ParseBackupResult parses the termination message of the backup job into the name and the size of the backup
+func ParseBackupResult(message string) (string, int64, error) {
+	name, size, ok := strings.Cut(strings.TrimSpace(message), " ")
+	if !ok || name == "" {
+		return "", 0, fmt.Errorf("unexpected termination message of backup job: %q", message)
+	}
+	bytes, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("unexpected size of backup %s: %w", name, err)
+	}
+	return name, bytes, nil
+}
+
+// BackupPvcName returns the name of the PVC the backups are stored to
+func BackupPvcName(instance *v1alpha1.Trillian, backup *v1alpha1.TrillianDBBackup) string {
+	if backup.Pvc != nil && backup.Pvc.Name != "" {
+		return backup.Pvc.Name
+	}
+	return utils.ResourceName(instance, DefaultBackupPvcName)
+}
+
+// dumpContainer returns the container writing a consistent compressed dump of the database to target.
+// The name of the backup is set in the $name variable before the then command runs.
+func (d Database) dumpContainer(containerName, image, target, then string) *core.Container {
+	var dump string
+	env := d.clientEnv()
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		// the dump of a single database is consistent, --clean lets psql restore it to the existing database
+		dump = "pg_dump --clean --if-exists --no-owner --no-privileges"
+		env = append(env, d.postgresqlSSLEnv()...)
+	} else {
+		dump = `mysqldump --single-transaction --quick --no-tablespaces --host="$MYSQL_HOSTNAME" --port="$MYSQL_PORT" --user="$MYSQL_USER" ` +
+			strings.Join(d.mysqlClientSSL(), " ") + ` "$MYSQL_DATABASE"`
+		env = append(env, d.secretEnv("MYSQL_PWD", DBSecretPassword))
+	}
+	return d.backupContainer(containerName, image, env, strings.Join([]string{
+		"set -eo pipefail",
+		`name="trillian-$(date -u +%Y%m%d%H%M%S).sql.gz"`,
+		dump + ` | gzip > "` + target + `"`,
+		then,
+	}, "\n"))
+}
+
+// restoreContainer returns the container loading the dump named by the $name variable set by the resolve command
+func (d Database) restoreContainer(containerName, image, resolve string) *core.Container {
+	var restore string
+	env := d.clientEnv()
+	if d.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		restore = "psql -v ON_ERROR_STOP=1 -q -1"
+		env = append(env, d.postgresqlSSLEnv()...)
+	} else {
+		restore = `mysql --host="$MYSQL_HOSTNAME" --port="$MYSQL_PORT" --user="$MYSQL_USER" ` +
+			strings.Join(d.mysqlClientSSL(), " ") + ` "$MYSQL_DATABASE"`
+		env = append(env, d.secretEnv("MYSQL_PWD", DBSecretPassword))
+	}
+	return d.backupContainer(containerName, image, env, strings.Join([]string{
+		"set -eo pipefail",
+		resolve,
+		`gunzip -c "$BACKUP_DIR/$name" | ` + restore,
+		`echo -n "$name" > ` + common.TerminationLogPath,
+	}, "\n"))
+}
+
+func (d Database) backupContainer(name, image string, env []core.EnvVar, script string) *core.Container {
+	return &core.Container{
+		Name:                     name,
+		Image:                    image,
+		Command:                  []string{"bash", "-c", script},
+		Env:                      append(env, core.EnvVar{Name: "BACKUP_DIR", Value: backupPath}),
+		TerminationMessagePath:   common.TerminationLogPath,
+		TerminationMessagePolicy: core.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      backupVolumeName,
+				MountPath: backupPath,
+			},
+		},
+	}
+}
+
+// mysqlClientSSL returns the arguments of the MariaDB client tools matching the TLS mode
+func (d Database) mysqlClientSSL() []string {
+	switch d.TLSMode {
+	case DBTLSPreferred:
+		return nil
+	case DBTLSSkipVerify:
+		return []string{"--ssl"}
+	case DBTLSVerifyFull:
+		args := []string{"--ssl", "--ssl-verify-server-cert"}
+		if d.CA {
+			args = append(args, "--ssl-ca="+path.Join(dbTLSPath, "ca.crt"))
+		}
+		return args
+	default:
+		return []string{"--skip-ssl"}
+	}
+}
+
+// postgresqlSSLEnv returns the libpq variables matching the TLS mode
+func (d Database) postgresqlSSLEnv() []core.EnvVar {
+	env := make([]core.EnvVar, 0, 2)
+	for _, param := range d.postgresqlSSL() {
+		name, value, _ := strings.Cut(param, "=")
+		env = append(env, core.EnvVar{Name: "PG" + strings.ToUpper(name), Value: value})
+	}
+	return env
+}
+
+func s3Container(name string, s3 *v1alpha1.TrillianDBBackupS3, script string) *core.Container {
+	credential := func(env, key string) core.EnvVar {
+		return core.EnvVar{
+			Name: env,
+			ValueFrom: &core.EnvVarSource{
+				SecretKeyRef: &core.SecretKeySelector{
+					LocalObjectReference: core.LocalObjectReference{Name: s3.CredentialsSecretRef.Name},
+					Key:                  key,
+				},
+			},
+		}
+	}
+	prefix := strings.TrimPrefix(s3.Prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	region := s3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &core.Container{
+		Name:    name,
+		Image:   constants.TrillianBackupS3Image,
+		Command: []string{"bash", "-c", script},
+		Env: []core.EnvVar{
+			{Name: "AWS_ENDPOINT_URL", Value: s3.Endpoint},
+			{Name: "AWS_DEFAULT_REGION", Value: region},
+			credential("AWS_ACCESS_KEY_ID", BackupS3AccessKeyID),
+			credential("AWS_SECRET_ACCESS_KEY", BackupS3SecretAccessKey),
+			{Name: "BACKUP_BUCKET", Value: s3.Bucket},
+			{Name: "BACKUP_PREFIX", Value: prefix},
+			{Name: "BACKUP_DIR", Value: backupPath},
+			// the aws cli needs a writable home directory
+			{Name: "HOME", Value: "/tmp"},
+		},
+		TerminationMessagePath:   common.TerminationLogPath,
+		TerminationMessagePolicy: core.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      backupVolumeName,
+				MountPath: backupPath,
+			},
+		},
+	}
+}
+
+func backupPodTemplate(sa string, initContainers []core.Container, container core.Container, volume core.Volume) *core.PodTemplateSpec {
+	return &core.PodTemplateSpec{
+		Spec: core.PodSpec{
+			ServiceAccountName: sa,
+			RestartPolicy:      core.RestartPolicyOnFailure,
+			InitContainers:     initContainers,
+			Containers:         []core.Container{container},
+			Volumes:            []core.Volume{volume},
+		},
+	}
+}
+
+func emptyDirVolume() core.Volume {
+	return core.Volume{
+		Name: backupVolumeName,
+		VolumeSource: core.VolumeSource{
+			EmptyDir: &core.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func pvcVolume(claim string) core.Volume {
+	return core.Volume{
+		Name: backupVolumeName,
+		VolumeSource: core.VolumeSource{
+			PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+				ClaimName: claim,
+			},
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package trillianUtils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/constants"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestCreateBackupCronJob(t *testing.T) {
+	newInstance := func(backup v1alpha1.TrillianDBBackup) *v1alpha1.Trillian {
+		return &v1alpha1.Trillian{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+			Spec: v1alpha1.TrillianSpec{
+				Db: v1alpha1.TrillianDB{Create: ptr.To(true), Backup: &backup},
+			},
+		}
+	}
+	mysql := Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled}
+
+	t.Run("pvc", func(t *testing.T) {
+		g := NewWithT(t)
+		size := resource.MustParse("1Gi")
+		instance := newInstance(v1alpha1.TrillianDBBackup{Schedule: "@daily", Retention: 3, Pvc: &v1alpha1.Pvc{Size: &size}})
+
+		cronJob, err := CreateBackupCronJob(instance, "backup", "sa", map[string]string{"app": "backup"}, mysql)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(cronJob.Spec.Schedule).To(Equal("@daily"))
+		g.Expect(cronJob.Spec.JobTemplate.Labels).To(Equal(map[string]string{"app": "backup"}))
+
+		spec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		g.Expect(spec.InitContainers).To(BeEmpty())
+		g.Expect(spec.Volumes).To(ConsistOf(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "trillian-"+DefaultBackupPvcName)))
+		container := spec.Containers[0]
+		g.Expect(container.Image).To(Equal(constants.TrillianDbImage))
+		g.Expect(container.Command[2]).To(ContainSubstring("mysqldump --single-transaction"))
+		g.Expect(container.Command[2]).To(ContainSubstring("--skip-ssl"))
+		g.Expect(container.Env).To(ContainElements(
+			core.EnvVar{Name: "BACKUP_RETENTION", Value: "3"},
+			HaveField("Name", "MYSQL_PWD"),
+		))
+	})
+
+	t.Run("s3 with postgresql", func(t *testing.T) {
+		g := NewWithT(t)
+		instance := newInstance(v1alpha1.TrillianDBBackup{Schedule: "@daily", Retention: 7, S3: &v1alpha1.TrillianDBBackupS3{
+			Endpoint:             "https://s3.example.com",
+			Bucket:               "backups",
+			Prefix:               "trillian",
+			CredentialsSecretRef: v1alpha1.LocalObjectReference{Name: "s3"},
+		}})
+		instance.Spec.Db.Engine = v1alpha1.DatabaseEnginePostgreSQL
+		db := Database{Engine: v1alpha1.DatabaseEnginePostgreSQL, SecretName: "db", TLSMode: DBTLSVerifyFull, CA: true}
+
+		cronJob, err := CreateBackupCronJob(instance, "backup", "sa", nil, db)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		spec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+		g.Expect(spec.Volumes).To(ContainElements(
+			HaveField("VolumeSource.EmptyDir", Not(BeNil())),
+			HaveField("Name", dbTLSVolumeName),
+		))
+		dump := spec.InitContainers[0]
+		g.Expect(dump.Image).To(Equal(constants.TrillianPostgresqlImage))
+		g.Expect(dump.Command[2]).To(ContainSubstring("pg_dump"))
+		g.Expect(dump.Env).To(ContainElement(core.EnvVar{Name: "PGSSLMODE", Value: "verify-full"}))
+		g.Expect(dump.VolumeMounts).To(ContainElement(HaveField("Name", dbTLSVolumeName)))
+
+		upload := spec.Containers[0]
+		g.Expect(upload.Image).To(Equal(constants.TrillianBackupS3Image))
+		g.Expect(upload.Env).To(ContainElements(
+			core.EnvVar{Name: "AWS_ENDPOINT_URL", Value: "https://s3.example.com"},
+			core.EnvVar{Name: "AWS_DEFAULT_REGION", Value: "us-east-1"},
+			core.EnvVar{Name: "BACKUP_BUCKET", Value: "backups"},
+			core.EnvVar{Name: "BACKUP_PREFIX", Value: "trillian/"},
+			HaveField("ValueFrom.SecretKeyRef.Key", BackupS3SecretAccessKey),
+		))
+	})
+}
+
+func TestCreateRestoreJob(t *testing.T) {
+	g := NewWithT(t)
+	size := resource.MustParse("1Gi")
+	instance := &v1alpha1.Trillian{
+		ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+		Spec: v1alpha1.TrillianSpec{
+			Db: v1alpha1.TrillianDB{Create: ptr.To(true), Backup: &v1alpha1.TrillianDBBackup{
+				Pvc: &v1alpha1.Pvc{Name: "backups", Size: &size},
+			}},
+		},
+	}
+
+	job, err := CreateRestoreJob(instance, "restore", "sa", nil, Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db"}, LatestBackup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(job.GenerateName).To(Equal("restore-"))
+	g.Expect(*job.Spec.BackoffLimit).To(BeZero())
+
+	spec := job.Spec.Template.Spec
+	g.Expect(spec.RestartPolicy).To(Equal(core.RestartPolicyNever))
+	g.Expect(spec.Volumes).To(ConsistOf(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "backups")))
+	g.Expect(spec.Containers[0].Command[2]).To(ContainSubstring(`gunzip -c "$BACKUP_DIR/$name" | mysql`))
+	g.Expect(spec.Containers[0].Env).To(ContainElement(core.EnvVar{Name: "BACKUP_NAME", Value: LatestBackup}))
+}
+
+func TestParseBackupResult(t *testing.T) {
+	g := NewWithT(t)
+
+	name, size, err := ParseBackupResult("trillian-20240101000000.sql.gz 1024\n")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(name).To(Equal("trillian-20240101000000.sql.gz"))
+	g.Expect(size).To(Equal(int64(1024)))
+
+	_, _, err = ParseBackupResult("")
+	g.Expect(err).To(HaveOccurred())
+	_, _, err = ParseBackupResult("trillian-20240101000000.sql.gz big")
+	g.Expect(err).To(HaveOccurred())
+}
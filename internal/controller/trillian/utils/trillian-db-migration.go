@@ -0,0 +1,158 @@
+package trillianUtils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	batchv1 "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// BaselineSchemaVersion is the version of the schema shipped with the first managed database
+	BaselineSchemaVersion int32 = 1
+	// MigrationsConfigMap holds the schema migrations of the managed database, see SchemaMigrations
+	MigrationsConfigMap = "trillian-db-migrations"
+
+	migrationsVolumeName = "db-migrations"
+	migrationsPath       = "/var/run/tas/db-migrations"
+)
+
+// schemaMigration is a change of the Trillian schema applied to an existing database
+type schemaMigration struct {
+	// applied is a query returning a positive count when the schema already contains the change,
+	// typically the presence of a table or a column in information_schema
+	applied string
+	// migration is the SQL applying the change
+	migration string
+}
+
+// baselineSchema returns a positive count when the database contains the Trillian schema
+var baselineSchema = map[v1alpha1.DatabaseEngine]string{
+	v1alpha1.DatabaseEngineMySQL:      "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'Trees'",
+	v1alpha1.DatabaseEnginePostgreSQL: "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = 'trees'",
+}
+
+// schemaMigrations are the changes of the Trillian schema applied to an existing database, in order.
+// The migration at index i upgrades the schema to version BaselineSchemaVersion+i+1.
+// Trillian does not record its schema version, the current version is derived from the schema itself:
+// it is the version of the last migration in the leading run of applied migrations.
+// New databases are created with the latest schema by the database image (MySQL) or by the operator (PostgreSQL).
+//
+// The list is empty, the schema of the supported Trillian versions did not change. It is the scaffolding
+// the next Trillian schema change is shipped with.
+var schemaMigrations = map[v1alpha1.DatabaseEngine][]schemaMigration{
+	v1alpha1.DatabaseEngineMySQL:      {},
+	v1alpha1.DatabaseEnginePostgreSQL: {},
+}
+
+// TargetSchemaVersion returns the schema version of the database with all the migrations applied
+func TargetSchemaVersion(engine v1alpha1.DatabaseEngine) int32 {
+	return BaselineSchemaVersion + int32(len(schemaMigrations[engine]))
+}
+
+// SchemaMigrations returns the migrations of the engine keyed by the file names <version>.sql
+// and the queries checking whether they are applied keyed by <version>.applied
+func SchemaMigrations(engine v1alpha1.DatabaseEngine) map[string]string {
+	data := make(map[string]string, 2*len(schemaMigrations[engine]))
+	for i, migration := range schemaMigrations[engine] {
+		version := BaselineSchemaVersion + int32(i) + 1
+		data[fmt.Sprintf("%d.sql", version)] = migration.migration
+		data[fmt.Sprintf("%d.applied", version)] = migration.applied
+	}
+	return data
+}
+
+// CreateMigrationJob returns the Job applying the pending schema migrations with the client tools of the image.
+// The job applies the migrations missing in the schema and writes the migrated version to the termination message.
+func CreateMigrationJob(instance *v1alpha1.Trillian, name string, sa string, labels map[string]string, db Database, image string) *batchv1.Job {
+	var sql, exec string
+	env := db.clientEnv()
+	if db.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		sql, exec = "psql -v ON_ERROR_STOP=1 -qtA", "-c"
+		env = append(env, db.postgresqlSSLEnv()...)
+	} else {
+		sql, exec = `mysql --host="$MYSQL_HOSTNAME" --port="$MYSQL_PORT" --user="$MYSQL_USER" --database="$MYSQL_DATABASE" --batch --skip-column-names `+
+			strings.Join(db.mysqlClientSSL(), " "), "-e"
+		env = append(env, db.secretEnv("MYSQL_PWD", DBSecretPassword))
+	}
+
+	script := strings.Join([]string{
+		"set -eo pipefail",
+		"sql() { " + sql + ` "$@"; }`,
+		`if [ "$(sql ` + exec + ` "` + baselineSchema[db.Engine] + `")" -eq 0 ]; then echo "Trillian schema not found"; exit 1; fi`,
+		"version=" + strconv.Itoa(int(BaselineSchemaVersion)),
+		`for f in $(ls "$MIGRATIONS_DIR" | grep '\.sql$' | sort -n); do`,
+		`  v="${f%.sql}"`,
+		`  if [ "$(sql < "$MIGRATIONS_DIR/$v.applied")" -gt 0 ]; then`,
+		`    echo "Schema version $v is applied"`,
+		`  else`,
+		`    echo "Migrating schema to version $v"`,
+		`    sql < "$MIGRATIONS_DIR/$f"`,
+		`  fi`,
+		`  version=$v`,
+		`done`,
+		`echo -n "$version" > ` + common.TerminationLogPath,
+	}, "\n")
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    instance.Namespace,
+			Labels:       labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					ServiceAccountName: sa,
+					RestartPolicy:      core.RestartPolicyNever,
+					Containers: []core.Container{
+						{
+							Name:                     "migrate",
+							Image:                    image,
+							Command:                  []string{"bash", "-c", script},
+							Env:                      append(env, core.EnvVar{Name: "MIGRATIONS_DIR", Value: migrationsPath}),
+							TerminationMessagePath:   common.TerminationLogPath,
+							TerminationMessagePolicy: core.TerminationMessageFallbackToLogsOnError,
+							VolumeMounts: []core.VolumeMount{
+								{
+									Name:      migrationsVolumeName,
+									MountPath: migrationsPath,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []core.Volume{
+						{
+							Name: migrationsVolumeName,
+							VolumeSource: core.VolumeSource{
+								ConfigMap: &core.ConfigMapVolumeSource{
+									LocalObjectReference: core.LocalObjectReference{Name: utils.ResourceName(instance, MigrationsConfigMap)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	db.mountTLS(&job.Spec.Template, &job.Spec.Template.Spec.Containers[0], false)
+	return job
+}
+
+// ParseSchemaVersion parses the termination message of the migration job
+func ParseSchemaVersion(message string) (int32, error) {
+	version, err := strconv.ParseInt(strings.TrimSpace(message), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected termination message of migration job: %w", err)
+	}
+	return int32(version), nil
+}
@@ -0,0 +1,63 @@
+package trillianUtils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCreateMigrationJob(t *testing.T) {
+	g := NewWithT(t)
+	instance := &v1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"}}
+	db := Database{Engine: v1alpha1.DatabaseEngineMySQL, SecretName: "db", TLSMode: DBTLSDisabled}
+
+	job := CreateMigrationJob(instance, "migration", "sa", nil, db, "trillian-db:new")
+	g.Expect(job.GenerateName).To(Equal("migration-"))
+	g.Expect(*job.Spec.BackoffLimit).To(BeZero())
+
+	spec := job.Spec.Template.Spec
+	g.Expect(spec.RestartPolicy).To(Equal(core.RestartPolicyNever))
+	g.Expect(spec.Volumes).To(ConsistOf(HaveField("VolumeSource.ConfigMap.Name", "trillian-"+MigrationsConfigMap)))
+	container := spec.Containers[0]
+	g.Expect(container.Image).To(Equal("trillian-db:new"))
+	g.Expect(container.Command[2]).To(ContainSubstring(baselineSchema[v1alpha1.DatabaseEngineMySQL]))
+	g.Expect(container.Command[2]).To(ContainSubstring(`$MIGRATIONS_DIR/$v.applied`))
+	g.Expect(container.Command[2]).ToNot(ContainSubstring("CREATE TABLE"))
+	g.Expect(container.Command[2]).To(ContainSubstring("--skip-ssl"))
+	g.Expect(container.Env).To(ContainElements(
+		core.EnvVar{Name: "MIGRATIONS_DIR", Value: migrationsPath},
+		HaveField("Name", "MYSQL_PWD"),
+	))
+}
+
+func TestSchemaMigrations(t *testing.T) {
+	g := NewWithT(t)
+	defer func(migrations []schemaMigration) {
+		schemaMigrations[v1alpha1.DatabaseEngineMySQL] = migrations
+	}(schemaMigrations[v1alpha1.DatabaseEngineMySQL])
+
+	schemaMigrations[v1alpha1.DatabaseEngineMySQL] = []schemaMigration{
+		{
+			applied:   "SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'Trees' AND column_name = 'Example'",
+			migration: "ALTER TABLE Trees ADD COLUMN Example INT;",
+		},
+	}
+	g.Expect(TargetSchemaVersion(v1alpha1.DatabaseEngineMySQL)).To(Equal(BaselineSchemaVersion + 1))
+	g.Expect(SchemaMigrations(v1alpha1.DatabaseEngineMySQL)).To(HaveKeyWithValue("2.sql", "ALTER TABLE Trees ADD COLUMN Example INT;"))
+	g.Expect(SchemaMigrations(v1alpha1.DatabaseEngineMySQL)).To(HaveKeyWithValue("2.applied", ContainSubstring("column_name = 'Example'")))
+	g.Expect(SchemaMigrations(v1alpha1.DatabaseEnginePostgreSQL)).To(BeEmpty())
+}
+
+func TestParseSchemaVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	version, err := ParseSchemaVersion("3\n")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version).To(Equal(int32(3)))
+
+	_, err = ParseSchemaVersion("ERROR 2002")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -10,7 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func CreateTrillDb(instance *v1alpha1.Trillian, dpName string, sa string, secCont *core.PodSecurityContext, labels map[string]string) (*apps.Deployment, error) {
+func CreateTrillDb(instance *v1alpha1.Trillian, dpName string, sa string, secCont *core.PodSecurityContext, labels map[string]string, db Database) (*apps.Deployment, error) {
 	if instance.Status.Db.DatabaseSecretRef == nil {
 		return nil, errors.New("reference to database secret is not set")
 	}
@@ -19,7 +19,14 @@ func CreateTrillDb(instance *v1alpha1.Trillian, dpName string, sa string, secCon
 	}
 	replicas := int32(1)
 
-	return &apps.Deployment{
+	var container core.Container
+	if db.Engine == v1alpha1.DatabaseEnginePostgreSQL {
+		container = postgresqlContainer(dpName, db, DbImage(instance))
+	} else {
+		container = mysqlContainer(dpName, db, DbImage(instance))
+	}
+
+	dep := &apps.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      dpName,
 			Namespace: instance.Namespace,
@@ -47,119 +54,154 @@ func CreateTrillDb(instance *v1alpha1.Trillian, dpName string, sa string, secCon
 							},
 						},
 					},
-					Containers: []core.Container{
-						{
-							Name:  dpName,
-							Image: constants.TrillianDbImage,
-							ReadinessProbe: &core.Probe{
-								ProbeHandler: core.ProbeHandler{
-									Exec: &core.ExecAction{
-										Command: []string{
-											"bash",
-											"-c",
-											"mariadb -u ${MYSQL_USER} -p${MYSQL_PASSWORD} -e \"SELECT 1;\"",
-										},
-									},
-								},
-								InitialDelaySeconds: 10,
-								PeriodSeconds:       10,
-								TimeoutSeconds:      1,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
-							LivenessProbe: &core.Probe{
-								ProbeHandler: core.ProbeHandler{
-									Exec: &core.ExecAction{
-										Command: []string{
-											"bash",
-											"-c",
-											"mariadb-admin -u ${MYSQL_USER} -p${MYSQL_PASSWORD} ping",
-										},
-									},
-								},
-								InitialDelaySeconds: 30,
-								TimeoutSeconds:      1,
-								PeriodSeconds:       10,
-								SuccessThreshold:    1,
-								FailureThreshold:    3,
-							},
-							Ports: []core.ContainerPort{
-								{
-									Protocol:      core.ProtocolTCP,
-									ContainerPort: 3306,
-								},
-							},
-							// Env variables from secret trillian-mysql
-							Env: []core.EnvVar{
-								{
-									Name: "MYSQL_USER",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-user",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_PASSWORD",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-password",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_ROOT_PASSWORD",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-root-password",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_PORT",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-port",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_DATABASE",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-database",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-							},
-							VolumeMounts: []core.VolumeMount{
-								{
-									Name:      "storage",
-									MountPath: "/var/lib/mysql",
-								},
-							},
-						},
-					},
+					Containers: []core.Container{container},
 				},
 			},
 			Strategy: apps.DeploymentStrategy{
 				Type: "Recreate",
 			},
 		},
-	}, nil
+	}
+	if args := db.serverArgs(); args != nil {
+		c := &dep.Spec.Template.Spec.Containers[0]
+		c.Args = args
+		db.mountTLS(&dep.Spec.Template, c, true)
+	}
+	return dep, nil
+}
+
+// DesiredDbImage returns the image of the managed database the operator is shipped with
+func DesiredDbImage(instance *v1alpha1.Trillian) string {
+	if Engine(instance.Spec.Db) == v1alpha1.DatabaseEnginePostgreSQL {
+		return constants.TrillianPostgresqlImage
+	}
+	return constants.TrillianDbImage
+}
+
+// DbImage returns the image the managed database runs.
+// It differs from DesiredDbImage until the upgrade of an existing database rolls the new image out.
+func DbImage(instance *v1alpha1.Trillian) string {
+	if instance.Status.DatabaseImage != "" {
+		return instance.Status.DatabaseImage
+	}
+	return DesiredDbImage(instance)
+}
+
+func mysqlContainer(name string, db Database, image string) core.Container {
+	return core.Container{
+		Name:  name,
+		Image: image,
+		ReadinessProbe: &core.Probe{
+			ProbeHandler: core.ProbeHandler{
+				Exec: &core.ExecAction{
+					Command: []string{
+						"bash",
+						"-c",
+						"mariadb -u ${MYSQL_USER} -p${MYSQL_PASSWORD} -e \"SELECT 1;\"",
+					},
+				},
+			},
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       10,
+			TimeoutSeconds:      1,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		LivenessProbe: &core.Probe{
+			ProbeHandler: core.ProbeHandler{
+				Exec: &core.ExecAction{
+					Command: []string{
+						"bash",
+						"-c",
+						"mariadb-admin -u ${MYSQL_USER} -p${MYSQL_PASSWORD} ping",
+					},
+				},
+			},
+			InitialDelaySeconds: 30,
+			TimeoutSeconds:      1,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		Ports: []core.ContainerPort{
+			{
+				Protocol:      core.ProtocolTCP,
+				ContainerPort: 3306,
+			},
+		},
+		// Env variables from the database secret
+		Env: []core.EnvVar{
+			db.secretEnv("MYSQL_USER", DBSecretUser),
+			db.secretEnv("MYSQL_PASSWORD", DBSecretPassword),
+			db.secretEnv("MYSQL_ROOT_PASSWORD", DBSecretRootPassword),
+			db.secretEnv("MYSQL_PORT", DBSecretPort),
+			db.secretEnv("MYSQL_DATABASE", DBSecretDatabase),
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      "storage",
+				MountPath: "/var/lib/mysql",
+			},
+		},
+	}
+}
+
+func postgresqlContainer(name string, db Database, image string) core.Container {
+	return core.Container{
+		Name:  name,
+		Image: image,
+		ReadinessProbe: &core.Probe{
+			ProbeHandler: core.ProbeHandler{
+				Exec: &core.ExecAction{
+					Command: []string{
+						"bash",
+						"-c",
+						"psql -h 127.0.0.1 -U ${POSTGRESQL_USER} -d ${POSTGRESQL_DATABASE} -c \"SELECT 1;\"",
+					},
+				},
+			},
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       10,
+			TimeoutSeconds:      1,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		LivenessProbe: &core.Probe{
+			ProbeHandler: core.ProbeHandler{
+				Exec: &core.ExecAction{
+					Command: []string{
+						"bash",
+						"-c",
+						"pg_isready -h 127.0.0.1 -p 5432",
+					},
+				},
+			},
+			InitialDelaySeconds: 30,
+			TimeoutSeconds:      1,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			FailureThreshold:    3,
+		},
+		Ports: []core.ContainerPort{
+			{
+				Protocol:      core.ProtocolTCP,
+				ContainerPort: 5432,
+			},
+		},
+		// Env variables from the database secret
+		Env: []core.EnvVar{
+			db.secretEnv("POSTGRESQL_USER", DBSecretUser),
+			db.secretEnv("POSTGRESQL_PASSWORD", DBSecretPassword),
+			// psql in the readiness probe
+			db.secretEnv("PGPASSWORD", DBSecretPassword),
+			db.secretEnv("POSTGRESQL_ADMIN_PASSWORD", DBSecretRootPassword),
+			db.secretEnv("POSTGRESQL_DATABASE", DBSecretDatabase),
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      "storage",
+				MountPath: "/var/lib/pgsql/data",
+			},
+		},
+	}
 }
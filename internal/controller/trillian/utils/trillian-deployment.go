@@ -2,7 +2,9 @@ package trillianUtils
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/utils"
@@ -11,9 +13,10 @@ import (
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
-func CreateTrillDeployment(instance *v1alpha1.Trillian, image string, dpName string, sa string, labels map[string]string) (*apps.Deployment, error) {
+func CreateTrillDeployment(instance *v1alpha1.Trillian, image string, dpName string, sa string, labels map[string]string, db Database) (*apps.Deployment, error) {
 	if instance.Status.Db.DatabaseSecretRef == nil {
 		return nil, errors.New("reference to database secret is not set")
 	}
@@ -53,114 +56,143 @@ func CreateTrillDeployment(instance *v1alpha1.Trillian, image string, dpName str
 						{
 							Name:  "wait-for-trillian-db",
 							Image: constants.TrillianNetcatImage,
-							Env: []core.EnvVar{
-								{
-									Name: "MYSQL_HOSTNAME",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-host",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_PORT",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-port",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-							},
+							Env:   db.waitEnv(),
 							Command: []string{
 								"sh",
 								"-c",
-								"until nc -z -v -w30 $MYSQL_HOSTNAME $MYSQL_PORT; do echo \"Waiting for MySQL to start\"; sleep 5; done;",
+								db.waitCommand(),
 							},
 						},
 					},
 					Containers: []core.Container{
 						{
-							Args: []string{
-								"--storage_system=mysql",
-								"--quota_system=mysql",
-								"--mysql_uri=$(MYSQL_USER):$(MYSQL_PASSWORD)@tcp($(MYSQL_HOSTNAME):$(MYSQL_PORT))/$(MYSQL_DATABASE)",
-								"--rpc_endpoint=0.0.0.0:" + strconv.Itoa(int(actions.ServerPort)),
-								"--http_endpoint=0.0.0.0:" + strconv.Itoa(int(actions.MetricsPort)),
+							Args: append(append(db.storageArgs(), db.quotaArgs(instance.Spec.Quota)...),
+								"--rpc_endpoint=0.0.0.0:"+strconv.Itoa(int(actions.ServerPort)),
+								"--http_endpoint=0.0.0.0:"+strconv.Itoa(int(actions.MetricsPort)),
 								"--alsologtostderr",
-							},
+							),
 							Name:  dpName,
 							Image: image,
 							Ports: containerPorts,
-							// Env variables from secret trillian-mysql
-							Env: []core.EnvVar{
-								{
-									Name: "MYSQL_USER",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-user",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_PASSWORD",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-password",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_HOSTNAME",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-host",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_PORT",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-port",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-								{
-									Name: "MYSQL_DATABASE",
-									ValueFrom: &core.EnvVarSource{
-										SecretKeyRef: &core.SecretKeySelector{
-											Key: "mysql-database",
-											LocalObjectReference: core.LocalObjectReference{
-												Name: instance.Status.Db.DatabaseSecretRef.Name,
-											},
-										},
-									},
-								},
-							},
+							// Env variables from the database secret
+							Env: db.clientEnv(),
 						},
 					},
 				},
 			},
 		},
 	}
+	template := &dep.Spec.Template
+	if instance.Spec.Quota.System == v1alpha1.TrillianQuotaEtcd {
+		template.Spec.Containers[0].Args = append(template.Spec.Containers[0].Args, "--etcd_servers="+strings.Join(EtcdServers(instance), ","))
+	}
+	if init := db.schemaInitContainer(constants.TrillianPostgresqlImage); init != nil {
+		template.Spec.InitContainers = append(template.Spec.InitContainers, *init)
+		template.Spec.Volumes = append(template.Spec.Volumes, schemaVolume(instance))
+		db.mountTLS(template, &template.Spec.InitContainers[len(template.Spec.InitContainers)-1], false)
+	}
+	db.mountTLS(template, &template.Spec.Containers[0], false)
+	if instance.Status.TLS.Enabled {
+		if err := setTLS(dep, instance.Status.TLS); err != nil {
+			return nil, err
+		}
+	}
 	utils.SetProxyEnvs(dep)
 	return dep, nil
 }
+
+// SetElection configures the master election of the Logsigner deployment.
+// Without election a single replica acts as master for all trees.
+func SetElection(dep *apps.Deployment, instance *v1alpha1.Trillian) {
+	container := &dep.Spec.Template.Spec.Containers[0]
+	if !ElectionEnabled(instance) {
+		container.Args = append(container.Args, "--force_master=true")
+		// two forced masters would corrupt the sequencing, never run them side by side
+		dep.Spec.Strategy = apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType}
+		return
+	}
+	dep.Spec.Replicas = ptr.To(ptr.Deref(instance.Spec.LogSigner.Replicas, 1))
+	if instance.Spec.Quota.System != v1alpha1.TrillianQuotaEtcd {
+		// the etcd quota system connects to etcd already
+		container.Args = append(container.Args, "--etcd_servers="+strings.Join(EtcdServers(instance), ","))
+	}
+	// elections of Trillian instances sharing the etcd must not collide
+	container.Args = append(container.Args, fmt.Sprintf("--lock_file_path=/trillian/%s/%s/master", instance.Namespace, instance.Name))
+	if hold := instance.Spec.LogSigner.MasterHoldInterval; hold != nil {
+		container.Args = append(container.Args, "--master_hold_interval="+hold.Duration.String())
+	}
+}
+
+// SetSequencer applies the sequencing tuning of the Logsigner deployment
+func SetSequencer(dep *apps.Deployment, signer v1alpha1.TrillianLogSigner) {
+	container := &dep.Spec.Template.Spec.Containers[0]
+	if signer.BatchSize != nil {
+		container.Args = append(container.Args, fmt.Sprintf("--batch_size=%d", *signer.BatchSize))
+	}
+	if signer.SequencerInterval != nil {
+		container.Args = append(container.Args, "--sequencer_interval="+signer.SequencerInterval.Duration.String())
+	}
+	if signer.NumSequencers != nil {
+		container.Args = append(container.Args, fmt.Sprintf("--num_sequencers=%d", *signer.NumSequencers))
+	}
+}
+
+// ElectionEnabled returns true when Logsigner replicas elect the master of each tree
+func ElectionEnabled(instance *v1alpha1.Trillian) bool {
+	return ptr.Deref(instance.Spec.LogSigner.Replicas, 1) > 1 || len(instance.Spec.LogSigner.Election.EtcdServers) > 0
+}
+
+// ManagedEtcd returns true when the election or the quotas are backed by etcd deployed by the operator
+func ManagedEtcd(instance *v1alpha1.Trillian) bool {
+	return (ElectionEnabled(instance) || instance.Spec.Quota.System == v1alpha1.TrillianQuotaEtcd) &&
+		len(instance.Spec.LogSigner.Election.EtcdServers) == 0
+}
+
+// EtcdServers returns the endpoints of etcd backing the election and the quotas
+func EtcdServers(instance *v1alpha1.Trillian) []string {
+	if servers := instance.Spec.LogSigner.Election.EtcdServers; len(servers) > 0 {
+		return servers
+	}
+	return []string{fmt.Sprintf("http://%s.%s.svc:%d", utils.ResourceName(instance, actions.EtcdDeploymentName), instance.Namespace, actions.EtcdPort)}
+}
+
+// setTLS mounts the TLS certificate and enables gRPC over TLS
+func setTLS(dep *apps.Deployment, tls v1alpha1.TLS) error {
+	if tls.CertRef == nil || tls.PrivateKeyRef == nil {
+		return errors.New("reference to TLS certificate is not set")
+	}
+	const tlsVolume, tlsPath = "tls-cert", "/var/run/secrets/tas/tls"
+
+	container := &dep.Spec.Template.Spec.Containers[0]
+	container.Args = append(container.Args,
+		"--tls_cert_file="+tlsPath+"/tls.crt",
+		"--tls_key_file="+tlsPath+"/tls.key",
+	)
+	container.VolumeMounts = append(container.VolumeMounts, core.VolumeMount{
+		Name:      tlsVolume,
+		MountPath: tlsPath,
+		ReadOnly:  true,
+	})
+	dep.Spec.Template.Spec.Volumes = append(dep.Spec.Template.Spec.Volumes, core.Volume{
+		Name: tlsVolume,
+		VolumeSource: core.VolumeSource{
+			Projected: &core.ProjectedVolumeSource{
+				Sources: []core.VolumeProjection{
+					{
+						Secret: &core.SecretProjection{
+							LocalObjectReference: core.LocalObjectReference{Name: tls.CertRef.Name},
+							Items:                []core.KeyToPath{{Key: tls.CertRef.Key, Path: "tls.crt"}},
+						},
+					},
+					{
+						Secret: &core.SecretProjection{
+							LocalObjectReference: core.LocalObjectReference{Name: tls.PrivateKeyRef.Name},
+							Items:                []core.KeyToPath{{Key: tls.PrivateKeyRef.Key, Path: "tls.key"}},
+						},
+					},
+				},
+			},
+		},
+	})
+	return nil
+}
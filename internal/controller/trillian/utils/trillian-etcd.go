@@ -0,0 +1,123 @@
+package trillianUtils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/constants"
+	"github.com/securesign/operator/internal/controller/trillian/actions"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// CreateEtcdDeployment returns the single member etcd backing the Logsigner master election.
+// The election state is short-lived, the data is not persisted.
+func CreateEtcdDeployment(instance *v1alpha1.Trillian, dpName string, sa string, labels map[string]string) *apps.Deployment {
+	replicas := int32(1)
+	const dataPath = "/var/run/etcd"
+	return &apps.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dpName,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Strategy: apps.DeploymentStrategy{
+				Type: apps.RecreateDeploymentStrategyType,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: core.PodSpec{
+					ServiceAccountName: sa,
+					Volumes: []core.Volume{
+						{
+							Name: "data",
+							VolumeSource: core.VolumeSource{
+								EmptyDir: &core.EmptyDirVolumeSource{},
+							},
+						},
+					},
+					Containers: []core.Container{
+						{
+							Name:    dpName,
+							Image:   constants.TrillianEtcdImage,
+							Command: []string{"etcd"},
+							Args: []string{
+								"--name=" + dpName,
+								"--data-dir=" + dataPath + "/data",
+								fmt.Sprintf("--listen-client-urls=http://0.0.0.0:%d", actions.EtcdPort),
+								fmt.Sprintf("--advertise-client-urls=http://%s.%s.svc:%d", dpName, instance.Namespace, actions.EtcdPort),
+							},
+							Ports: []core.ContainerPort{
+								{
+									Protocol:      core.ProtocolTCP,
+									ContainerPort: actions.EtcdPort,
+								},
+							},
+							ReadinessProbe: &core.Probe{
+								ProbeHandler: core.ProbeHandler{
+									HTTPGet: &core.HTTPGetAction{
+										Path: "/health",
+										Port: intstr.FromInt32(actions.EtcdPort),
+									},
+								},
+								InitialDelaySeconds: 5,
+							},
+							VolumeMounts: []core.VolumeMount{
+								{
+									Name:      "data",
+									MountPath: dataPath,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ParseMastership returns IDs of trees the Logsigner is master for, read from the is_master gauge of its Prometheus metrics
+func ParseMastership(r io.Reader) ([]int64, error) {
+	const metric, label = "is_master{", `logid="`
+	var ids []int64
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, metric) {
+			continue
+		}
+		labels, value, found := strings.Cut(strings.TrimPrefix(line, metric), "} ")
+		if !found || strings.TrimSpace(value) != "1" {
+			continue
+		}
+		_, logID, found := strings.Cut(labels, label)
+		if !found {
+			continue
+		}
+		logID, _, _ = strings.Cut(logID, `"`)
+		id, err := strconv.ParseInt(logID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected log ID in metrics: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
@@ -0,0 +1,119 @@
+package trillianUtils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseMastership(t *testing.T) {
+	g := NewWithT(t)
+	metrics := `# HELP is_master Whether this instance is master (0/1)
+# TYPE is_master gauge
+is_master{logid="3"} 1
+is_master{logid="1"} 1
+is_master{logid="2"} 0
+known_logs{logid="1"} 1
+`
+	ids, err := ParseMastership(strings.NewReader(metrics))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ids).To(Equal([]int64{1, 3}))
+
+	_, err = ParseMastership(strings.NewReader(`is_master{logid="x"} 1`))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestSetElection(t *testing.T) {
+	newDeployment := func() *apps.Deployment {
+		return &apps.Deployment{Spec: apps.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Template: core.PodTemplateSpec{Spec: core.PodSpec{Containers: []core.Container{{}}}},
+		}}
+	}
+	tests := []struct {
+		name     string
+		signer   v1alpha1.TrillianLogSigner
+		quota    v1alpha1.TrillianQuotaSystem
+		replicas int32
+		args     []string
+		strategy apps.DeploymentStrategyType
+	}{
+		{
+			name:     "single replica forces the mastership",
+			signer:   v1alpha1.TrillianLogSigner{Replicas: ptr.To(int32(1))},
+			replicas: 1,
+			args:     []string{"--force_master=true"},
+			strategy: apps.RecreateDeploymentStrategyType,
+		},
+		{
+			name:     "replicas elect the master in managed etcd",
+			signer:   v1alpha1.TrillianLogSigner{Replicas: ptr.To(int32(3))},
+			replicas: 3,
+			args: []string{
+				"--etcd_servers=http://trillian-trillian-etcd.default.svc:2379",
+				"--lock_file_path=/trillian/default/trillian/master",
+			},
+		},
+		{
+			name:     "replicas share etcd with the quota system",
+			signer:   v1alpha1.TrillianLogSigner{Replicas: ptr.To(int32(2)), MasterHoldInterval: &metav1.Duration{Duration: 30 * time.Second}},
+			quota:    v1alpha1.TrillianQuotaEtcd,
+			replicas: 2,
+			args: []string{
+				"--lock_file_path=/trillian/default/trillian/master",
+				"--master_hold_interval=30s",
+			},
+		},
+		{
+			name: "external etcd",
+			signer: v1alpha1.TrillianLogSigner{
+				Replicas: ptr.To(int32(1)),
+				Election: v1alpha1.TrillianElection{EtcdServers: []string{"http://a:2379", "http://b:2379"}},
+			},
+			replicas: 1,
+			args: []string{
+				"--etcd_servers=http://a:2379,http://b:2379",
+				"--lock_file_path=/trillian/default/trillian/master",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			instance := &v1alpha1.Trillian{
+				ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"},
+				Spec:       v1alpha1.TrillianSpec{LogSigner: tt.signer, Quota: v1alpha1.TrillianQuota{System: tt.quota}},
+			}
+			dep := newDeployment()
+			SetElection(dep, instance)
+			g.Expect(dep.Spec.Replicas).To(HaveValue(Equal(tt.replicas)))
+			g.Expect(dep.Spec.Template.Spec.Containers[0].Args).To(Equal(tt.args))
+			g.Expect(dep.Spec.Strategy.Type).To(Equal(tt.strategy))
+			g.Expect(ManagedEtcd(instance)).To(Equal(tt.replicas > 1 && len(tt.signer.Election.EtcdServers) == 0))
+		})
+	}
+}
+
+func TestSetSequencer(t *testing.T) {
+	g := NewWithT(t)
+	dep := &apps.Deployment{Spec: apps.DeploymentSpec{
+		Template: core.PodTemplateSpec{Spec: core.PodSpec{Containers: []core.Container{{}}}},
+	}}
+	SetSequencer(dep, v1alpha1.TrillianLogSigner{
+		BatchSize:         ptr.To(int32(500)),
+		SequencerInterval: &metav1.Duration{Duration: 50 * time.Millisecond},
+		NumSequencers:     ptr.To(int32(4)),
+	})
+	g.Expect(dep.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
+		"--batch_size=500",
+		"--sequencer_interval=50ms",
+		"--num_sequencers=4",
+	}))
+}
@@ -0,0 +1,355 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/constants"
+	testAction "github.com/securesign/operator/internal/testing/action"
+	testTrillian "github.com/securesign/operator/internal/testing/trillian"
+	"google.golang.org/protobuf/types/known/durationpb"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newInstance(phase string) *rhtasv1alpha1.TrillianTree {
+	return &rhtasv1alpha1.TrillianTree{
+		ObjectMeta: metav1.ObjectMeta{Name: "rekor-tree-with-a-long-name", Namespace: "default"},
+		Spec: rhtasv1alpha1.TrillianTreeSpec{
+			Trillian:        rhtasv1alpha1.TrillianService{Port: ptr.To(int32(8091))},
+			TreeType:        rhtasv1alpha1.TreeTypeLog,
+			State:           rhtasv1alpha1.TreeStateActive,
+			MaxRootDuration: &metav1.Duration{Duration: time.Hour},
+			DeletionPolicy:  rhtasv1alpha1.TrillianTreeRetain,
+		},
+		Status: rhtasv1alpha1.TrillianTreeStatus{
+			Conditions: []metav1.Condition{
+				{Type: constants.Ready, Reason: phase},
+			},
+		},
+	}
+}
+
+func TestResolveTree(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	instance := newInstance(constants.Creating)
+	instance.Spec.State = rhtasv1alpha1.TreeStateFrozen
+	fake := testTrillian.NewFakeClient()
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testAction.PrepareAction(c, NewResolveTreeAction(func(a *resolveTreeAction) {
+		a.newClient = fake.NewClient
+	}))
+
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(fake.URL).To(Equal("trillian-logserver.default.svc:8091"))
+	g.Expect(instance.Status.TreeID).To(HaveValue(BeEquivalentTo(1)))
+	g.Expect(instance.Status.DisplayName).To(Equal("rekor-tree-with-a-lo"))
+	// trees are created active
+	g.Expect(instance.Status.State).To(Equal(rhtasv1alpha1.TreeStateActive))
+	g.Expect(instance.Status.TreeType).To(Equal(rhtasv1alpha1.TreeTypeLog))
+	g.Expect(a.CanHandle(ctx, instance)).To(BeFalse())
+}
+
+func TestResolveTree_Adopt(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	fake := testTrillian.NewFakeClient(
+		&trillian.Tree{TreeId: 42, TreeType: trillian.TreeType_PREORDERED_LOG, TreeState: trillian.TreeState_DRAINING, DisplayName: "existing", MaxRootDuration: durationpb.New(0)},
+	)
+
+	instance := newInstance(constants.Creating)
+	instance.Spec.TreeID = ptr.To(int64(42))
+	instance.Spec.Trillian.Address = "trillian.example.com"
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testAction.PrepareAction(c, NewResolveTreeAction(func(a *resolveTreeAction) {
+		a.newClient = fake.NewClient
+	}))
+
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(fake.URL).To(Equal("trillian.example.com:8091"))
+	g.Expect(fake.Trees).To(HaveLen(1))
+	g.Expect(instance.Status.TreeID).To(HaveValue(BeEquivalentTo(42)))
+	g.Expect(instance.Status.State).To(Equal(rhtasv1alpha1.TreeStateDraining))
+	g.Expect(instance.Status.TreeType).To(Equal(rhtasv1alpha1.TreeTypePreorderedLog))
+
+	// missing tree is not replaced by a new one
+	instance = newInstance(constants.Creating)
+	instance.Name = "missing"
+	instance.Spec.TreeID = ptr.To(int64(7))
+	g.Expect(c.Create(ctx, instance)).To(Succeed())
+	result := a.Handle(ctx, instance)
+	g.Expect(testAction.IsFailed(result)).To(BeTrue())
+	g.Expect(fake.Trees).To(HaveLen(1))
+	g.Expect(instance.Status.TreeID).To(BeNil())
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, constants.Ready).Reason).To(Equal(constants.Creating))
+}
+
+func TestResolveTree_Tagged(t *testing.T) {
+	owner := common.TreeOwner{Kind: "TrillianTree", Namespace: "default", Name: "rekor-tree-with-a-long-name", UID: "uid"}
+	previous := owner
+	previous.UID = "previous-uid"
+	other := owner
+	other.Name = "other"
+
+	tests := []struct {
+		name      string
+		trees     []*trillian.Tree
+		treeID    int64
+		ambiguous bool
+	}{
+		{
+			name:   "no tagged tree",
+			trees:  []*trillian.Tree{{TreeId: 10, Description: other.Description()}},
+			treeID: 2,
+		},
+		{
+			name:   "tree of the resource",
+			trees:  []*trillian.Tree{{TreeId: 10, Description: owner.Description()}},
+			treeID: 10,
+		},
+		{
+			name:   "tree of the recreated resource",
+			trees:  []*trillian.Tree{{TreeId: 10, Description: previous.Description()}},
+			treeID: 10,
+		},
+		{
+			name: "tree of the resource takes precedence",
+			trees: []*trillian.Tree{
+				{TreeId: 10, Description: previous.Description()},
+				{TreeId: 11, Description: owner.Description()},
+			},
+			treeID: 11,
+		},
+		{
+			name: "deleted tree is ignored",
+			trees: []*trillian.Tree{
+				{TreeId: 10, Description: previous.Description(), Deleted: true},
+				{TreeId: 11, Description: previous.Description()},
+			},
+			treeID: 11,
+		},
+		{
+			name: "ambiguous",
+			trees: []*trillian.Tree{
+				{TreeId: 10, Description: previous.Description()},
+				{TreeId: 11, Description: previous.Description()},
+			},
+			ambiguous: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+
+			fake := testTrillian.NewFakeClient(tt.trees...)
+			instance := newInstance(constants.Creating)
+			instance.UID = owner.UID
+			c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+			a := testAction.PrepareAction(c, NewResolveTreeAction(func(a *resolveTreeAction) {
+				a.newClient = fake.NewClient
+			}))
+
+			result := a.Handle(ctx, instance)
+			if tt.ambiguous {
+				g.Expect(result.Err).To(MatchError(common.ErrAmbiguousTree))
+				g.Expect(fake.Trees).To(HaveLen(len(tt.trees)))
+				g.Expect(instance.Status.TreeID).To(BeNil())
+				g.Expect(meta.FindStatusCondition(instance.Status.Conditions, constants.TreeCondition).Reason).To(Equal(constants.TreeAmbiguous))
+				return
+			}
+			g.Expect(result).To(Equal(testAction.StatusUpdate()))
+			g.Expect(instance.Status.TreeID).To(HaveValue(Equal(tt.treeID)))
+			// the tree is tagged for the current resource
+			g.Expect(fake.Trees[tt.treeID].Description).To(Equal(owner.Description()))
+			g.Expect(meta.FindStatusCondition(instance.Status.Conditions, constants.TreeCondition)).To(BeNil())
+		})
+	}
+}
+
+func TestSyncTree(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.TODO()
+
+	fake := testTrillian.NewFakeClient(
+		&trillian.Tree{TreeId: 1, TreeType: trillian.TreeType_LOG, TreeState: trillian.TreeState_ACTIVE, DisplayName: "rekor-tree-with-a-lo", MaxRootDuration: durationpb.New(time.Hour)},
+	)
+	fake.Size = 10
+
+	instance := newInstance(constants.Initialize)
+	instance.Status.TreeID = ptr.To(int64(1))
+	c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testAction.PrepareAction(c, NewSyncTreeAction(func(a *syncTreeAction) {
+		a.newClient = fake.NewClient
+	}))
+
+	g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(fake.Updated).To(BeEmpty())
+	g.Expect(instance.Status.Size).To(HaveValue(BeEquivalentTo(10)))
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, constants.Ready)).To(BeTrue())
+
+	// nothing changed, status is refreshed later
+	result := a.Handle(ctx, instance)
+	g.Expect(result.Err).ToNot(HaveOccurred())
+	g.Expect(result.Result.RequeueAfter).To(Equal(RefreshInterval))
+
+	// freeze the tree
+	instance.Spec.State = rhtasv1alpha1.TreeStateFrozen
+	instance.Spec.DisplayName = "frozen"
+	g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.StatusUpdate()))
+	g.Expect(fake.Updated).To(ConsistOf("tree_state", "display_name"))
+	g.Expect(fake.Trees[1].TreeState).To(Equal(trillian.TreeState_FROZEN))
+	g.Expect(instance.Status.State).To(Equal(rhtasv1alpha1.TreeStateFrozen))
+	g.Expect(instance.Status.DisplayName).To(Equal("frozen"))
+
+	// tree was removed from the backend
+	delete(fake.Trees, 1)
+	result = a.Handle(ctx, instance)
+	g.Expect(testAction.IsFailed(result)).To(BeTrue())
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, constants.Ready)).To(BeFalse())
+}
+
+func TestDeleteTree(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy rhtasv1alpha1.TrillianTreeDeletionPolicy
+		treeID int64
+		trees  int
+	}{
+		{
+			name:   "retain",
+			policy: rhtasv1alpha1.TrillianTreeRetain,
+			treeID: 1,
+			trees:  1,
+		},
+		{
+			name:   "delete",
+			policy: rhtasv1alpha1.TrillianTreeDelete,
+			treeID: 1,
+			trees:  0,
+		},
+		{
+			name:   "tree does not exist",
+			policy: rhtasv1alpha1.TrillianTreeDelete,
+			treeID: 2,
+			trees:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+
+			fake := testTrillian.NewFakeClient(&trillian.Tree{TreeId: 1})
+			instance := newInstance(constants.Ready)
+			instance.Spec.DeletionPolicy = tt.policy
+			instance.Status.TreeID = ptr.To(tt.treeID)
+			controllerutil.AddFinalizer(instance, TreeFinalizer)
+			c := testAction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+			g.Expect(c.Delete(ctx, instance)).To(Succeed())
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+
+			a := testAction.PrepareAction(c, NewDeleteTreeAction(func(a *deleteTreeAction) {
+				a.newClient = fake.NewClient
+			}))
+			g.Expect(a.CanHandle(ctx, instance)).To(BeTrue())
+			g.Expect(a.Handle(ctx, instance)).To(Equal(testAction.Return()))
+			g.Expect(fake.Trees).To(HaveLen(tt.trees))
+			// finalizer is removed, the resource is gone
+			g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(instance), instance))).To(BeTrue())
+		})
+	}
+}
+
+func TestDeleteTree_Unreachable(t *testing.T) {
+	trillianInstance := &rhtasv1alpha1.Trillian{ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: "default"}}
+	service := &v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "trillian-trillian-logserver", Namespace: "default"}}
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		address     string
+		annotations map[string]string
+		deleted     bool
+	}{
+		{
+			name:    "trillian is removed",
+			deleted: true,
+		},
+		{
+			name:    "log server service is removed",
+			objects: []client.Object{trillianInstance},
+			deleted: true,
+		},
+		{
+			name:    "trillian is running",
+			objects: []client.Object{trillianInstance, service},
+		},
+		{
+			name:    "explicit address",
+			address: "trillian.example.com",
+		},
+		{
+			name:        "deletion is skipped",
+			objects:     []client.Object{trillianInstance, service},
+			annotations: map[string]string{annotations.SkipTreeDeletion: "true"},
+			deleted:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.TODO()
+			instance := newInstance(constants.Ready)
+			instance.Annotations = tt.annotations
+			instance.Spec.DeletionPolicy = rhtasv1alpha1.TrillianTreeDelete
+			instance.Spec.Trillian.Address = tt.address
+			instance.Status.TreeID = ptr.To(int64(1))
+			controllerutil.AddFinalizer(instance, TreeFinalizer)
+			c := testAction.FakeClientBuilder().WithObjects(instance).WithObjects(tt.objects...).WithStatusSubresource(instance).Build()
+			g.Expect(c.Delete(ctx, instance)).To(Succeed())
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+			a := testAction.PrepareAction(c, NewDeleteTreeAction(func(a *deleteTreeAction) {
+				a.newClient = func(string, []byte) (common.TrillianClient, error) {
+					return nil, errors.New("connection refused")
+				}
+			}))
+
+			result := a.Handle(ctx, instance)
+			if !tt.deleted {
+				g.Expect(testAction.IsFailed(result)).To(BeTrue())
+				g.Expect(c.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				return
+			}
+			g.Expect(result).To(Equal(testAction.Return()))
+			g.Expect(a.(*deleteTreeAction).Recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring("TrillianTreeRetained")))
+			g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(instance), instance))).To(BeTrue())
+		})
+	}
+}
+
+func TestCanHandle_Uninitialized(t *testing.T) {
+	g := NewWithT(t)
+	instance := newInstance(constants.Ready)
+	instance.Status.Conditions = nil
+	g.Expect(NewResolveTreeAction().CanHandle(context.TODO(), instance)).To(BeFalse())
+	instance.Status.TreeID = ptr.To(int64(1))
+	g.Expect(NewSyncTreeAction().CanHandle(context.TODO(), instance)).To(BeFalse())
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	"google.golang.org/protobuf/types/known/durationpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	treeStates = map[rhtasv1alpha1.TreeState]trillian.TreeState{
+		rhtasv1alpha1.TreeStateActive:   trillian.TreeState_ACTIVE,
+		rhtasv1alpha1.TreeStateFrozen:   trillian.TreeState_FROZEN,
+		rhtasv1alpha1.TreeStateDraining: trillian.TreeState_DRAINING,
+	}
+	treeTypes = map[rhtasv1alpha1.TreeType]trillian.TreeType{
+		rhtasv1alpha1.TreeTypeLog:           trillian.TreeType_LOG,
+		rhtasv1alpha1.TreeTypePreorderedLog: trillian.TreeType_PREORDERED_LOG,
+	}
+)
+
+// connect opens a connection to the Trillian log server configured in the TrillianTree spec
+func connect(ctx context.Context, cli client.Client, instance *rhtasv1alpha1.TrillianTree, newClient common.NewTrillianClientFunc) (common.TrillianClient, error) {
+	var trillUrl string
+	switch {
+	case instance.Spec.Trillian.Port == nil:
+		return nil, errors.New("trillian port not specified")
+	case instance.Spec.Trillian.Address == "":
+		address, err := trillianUtils.LogserverAddress(ctx, cli, instance)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve Trillian address: %w", err)
+		}
+		trillUrl = fmt.Sprintf("%s:%d", address, *instance.Spec.Trillian.Port)
+	default:
+		trillUrl = fmt.Sprintf("%s:%d", instance.Spec.Trillian.Address, *instance.Spec.Trillian.Port)
+	}
+
+	caCert, err := trillianUtils.GetCACert(ctx, cli, instance, instance.Spec.Trillian)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve Trillian CA certificate: %w", err)
+	}
+	return newClient(trillUrl, caCert)
+}
+
+// displayName returns the display name of the tree, defaults to the resource name
+func displayName(instance *rhtasv1alpha1.TrillianTree) string {
+	if instance.Spec.DisplayName != "" {
+		return instance.Spec.DisplayName
+	}
+	if len(instance.Name) > maxDisplayNameLength {
+		return instance.Name[:maxDisplayNameLength]
+	}
+	return instance.Name
+}
+
+func maxRootDuration(instance *rhtasv1alpha1.TrillianTree) time.Duration {
+	if instance.Spec.MaxRootDuration == nil {
+		return 0
+	}
+	return instance.Spec.MaxRootDuration.Duration
+}
+
+// desiredTree returns the tree described by the TrillianTree spec
+func desiredTree(instance *rhtasv1alpha1.TrillianTree) *trillian.Tree {
+	treeType, ok := treeTypes[instance.Spec.TreeType]
+	if !ok {
+		treeType = trillian.TreeType_LOG
+	}
+	treeState, ok := treeStates[instance.Spec.State]
+	if !ok {
+		treeState = trillian.TreeState_ACTIVE
+	}
+	return &trillian.Tree{
+		TreeType:        treeType,
+		TreeState:       treeState,
+		DisplayName:     displayName(instance),
+		MaxRootDuration: durationpb.New(maxRootDuration(instance)),
+	}
+}
+
+// setStatus copies the observed tree into the TrillianTree status
+func setStatus(instance *rhtasv1alpha1.TrillianTree, tree *trillian.Tree) {
+	instance.Status.TreeID = &tree.TreeId
+	instance.Status.DisplayName = tree.DisplayName
+	instance.Status.MaxRootDuration = &metav1.Duration{Duration: tree.MaxRootDuration.AsDuration()}
+	for k, v := range treeStates {
+		if v == tree.TreeState {
+			instance.Status.State = k
+		}
+	}
+	for k, v := range treeTypes {
+		if v == tree.TreeType {
+			instance.Status.TreeType = k
+		}
+	}
+}
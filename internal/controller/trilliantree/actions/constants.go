@@ -0,0 +1,14 @@
+package actions
+
+import "time"
+
+const (
+	// TreeFinalizer guards deletion of the tree in the Trillian backend
+	TreeFinalizer = "trilliantree.rhtas.redhat.com"
+
+	// RefreshInterval is the period of the tree status refresh
+	RefreshInterval = time.Minute
+
+	// maxDisplayNameLength is the length of the Trillian storage column
+	maxDisplayNameLength = 20
+)
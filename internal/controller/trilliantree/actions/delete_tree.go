@@ -0,0 +1,128 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	trillianActions "github.com/securesign/operator/internal/controller/trillian/actions"
+	trillianUtils "github.com/securesign/operator/internal/controller/trillian/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func NewDeleteTreeAction(opts ...func(*deleteTreeAction)) action.Action[*rhtasv1alpha1.TrillianTree] {
+	a := &deleteTreeAction{
+		newClient: common.NewTrillianClient,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type deleteTreeAction struct {
+	action.BaseAction
+	newClient common.NewTrillianClientFunc
+}
+
+func (i deleteTreeAction) Name() string {
+	return "delete tree"
+}
+
+func (i deleteTreeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.TrillianTree) bool {
+	return instance.DeletionTimestamp != nil && controllerutil.ContainsFinalizer(instance, TreeFinalizer)
+}
+
+func (i deleteTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.TrillianTree) *action.Result {
+	if instance.Spec.DeletionPolicy == rhtasv1alpha1.TrillianTreeDelete && instance.Status.TreeID != nil {
+		if err := i.deleteTree(ctx, instance); err != nil {
+			return i.Failed(err)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, TreeFinalizer)
+	if err := i.Client.Update(ctx, instance); err != nil {
+		return i.Failed(err)
+	}
+	return i.Return()
+}
+
+// deleteTree deletes the tree in the Trillian backend. The deletion is skipped when it is requested by the annotation
+// or the Trillian the tree lives in is removed, the resource would never be deleted otherwise.
+func (i deleteTreeAction) deleteTree(ctx context.Context, instance *rhtasv1alpha1.TrillianTree) error {
+	treeID := *instance.Status.TreeID
+	if skip, _ := strconv.ParseBool(instance.Annotations[annotations.SkipTreeDeletion]); skip {
+		i.Recorder.Eventf(instance, v1.EventTypeWarning, "TrillianTreeRetained", "Trillian tree %d not deleted, the deletion is skipped by the %s annotation", treeID, annotations.SkipTreeDeletion)
+		return nil
+	}
+
+	err := i.deleteInBackend(ctx, instance, treeID)
+	if err == nil {
+		return nil
+	}
+	removed, removedErr := trillianRemoved(ctx, i.Client, instance)
+	switch {
+	case removedErr != nil:
+		return errors.Join(err, removedErr)
+	case removed:
+		i.Recorder.Eventf(instance, v1.EventTypeWarning, "TrillianTreeRetained", "Trillian tree %d not deleted, the Trillian serving it is removed: %v", treeID, err)
+		return nil
+	}
+	return err
+}
+
+// deleteInBackend soft-deletes the tree, a tree already missing in the backend is ignored
+func (i deleteTreeAction) deleteInBackend(ctx context.Context, instance *rhtasv1alpha1.TrillianTree, treeID int64) error {
+	trillianClient, err := connect(ctx, i.Client, instance, i.newClient)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = trillianClient.Close() }()
+
+	err = trillianClient.DeleteTree(ctx, treeID)
+	switch status.Code(err) {
+	case codes.OK:
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeDeleted", "Trillian tree deleted: %d", treeID)
+	case codes.NotFound:
+		i.Logger.Info("Trillian tree does not exist", "treeID", treeID)
+	default:
+		return fmt.Errorf("could not delete Trillian tree %d: %w", treeID, err)
+	}
+	return nil
+}
+
+// trillianRemoved returns true when the in-cluster Trillian serving the tree is deleted, is being deleted or its
+// log server service is gone. The state of a Trillian reached through an explicit address is unknown.
+func trillianRemoved(ctx context.Context, c client.Client, instance *rhtasv1alpha1.TrillianTree) (bool, error) {
+	if instance.Spec.Trillian.Address != "" {
+		return false, nil
+	}
+	trillian, err := trillianUtils.ResolveTrillian(ctx, c, instance)
+	switch {
+	case err != nil:
+		return false, err
+	case trillian == nil || trillian.DeletionTimestamp != nil:
+		return true, nil
+	}
+	service := &v1.Service{}
+	err = c.Get(ctx, client.ObjectKey{Namespace: trillian.Namespace, Name: utils.ResourceName(trillian, trillianActions.LogserverDeploymentName)}, service)
+	switch {
+	case apierrors.IsNotFound(err):
+		return true, nil
+	case err != nil:
+		return false, err
+	}
+	return service.DeletionTimestamp != nil, nil
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/trillian"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func NewResolveTreeAction(opts ...func(*resolveTreeAction)) action.Action[*rhtasv1alpha1.TrillianTree] {
+	a := &resolveTreeAction{
+		newClient: common.NewTrillianClient,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type resolveTreeAction struct {
+	action.BaseAction
+	newClient common.NewTrillianClientFunc
+}
+
+func (i resolveTreeAction) Name() string {
+	return "resolve tree"
+}
+
+func (i resolveTreeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.TrillianTree) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c != nil && c.Reason == constants.Creating && instance.Status.TreeID == nil
+}
+
+func (i resolveTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.TrillianTree) *action.Result {
+	trillianClient, err := connect(ctx, i.Client, instance, i.newClient)
+	if err != nil {
+		return i.Failed(err)
+	}
+	defer func() { _ = trillianClient.Close() }()
+
+	treeCtx, cancel := context.WithTimeout(ctx, time.Duration(constants.CreateTreeDeadline)*time.Second)
+	defer cancel()
+
+	var tree *trillian.Tree
+	created := false
+	if instance.Spec.TreeID != nil {
+		if tree, err = trillianClient.GetTree(treeCtx, *instance.Spec.TreeID); err != nil {
+			err = fmt.Errorf("could not get Trillian tree %d: %w", *instance.Spec.TreeID, err)
+		}
+	} else {
+		req := desiredTree(instance)
+		// trees are always created active, the desired state is applied afterward
+		req.TreeState = trillian.TreeState_ACTIVE
+		if tree, created, err = common.EnsureTree(treeCtx, trillianClient, common.NewTreeOwner("TrillianTree", instance), req); err != nil {
+			err = fmt.Errorf("could not create Trillian tree: %w", err)
+		}
+	}
+	if errors.Is(err, common.ErrAmbiguousTree) {
+		// refuse to pick one of the trees, the user has to resolve the conflict
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.TreeCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.TreeAmbiguous,
+			Message: err.Error(),
+		})
+		i.Recorder.Event(instance, v1.EventTypeWarning, "TrillianTreeAmbiguous", err.Error())
+	}
+	if err != nil {
+		// keep the creating phase, the tree is resolved again on the next attempt
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Creating,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, err, instance)
+	}
+
+	if created {
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeCreated", "New Trillian tree created: %d", tree.TreeId)
+	} else {
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeAdopted", "Existing Trillian tree adopted: %d", tree.TreeId)
+	}
+	meta.RemoveStatusCondition(&instance.Status.Conditions, constants.TreeCondition)
+	setStatus(instance, tree)
+	return i.StatusUpdate(ctx, instance)
+}
@@ -0,0 +1,111 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/trillian"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func NewSyncTreeAction(opts ...func(*syncTreeAction)) action.Action[*rhtasv1alpha1.TrillianTree] {
+	a := &syncTreeAction{
+		newClient: common.NewTrillianClient,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+type syncTreeAction struct {
+	action.BaseAction
+	newClient common.NewTrillianClientFunc
+}
+
+func (i syncTreeAction) Name() string {
+	return "sync tree"
+}
+
+func (i syncTreeAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.TrillianTree) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	return c != nil && (c.Reason == constants.Initialize || c.Reason == constants.Ready) && instance.Status.TreeID != nil
+}
+
+func (i syncTreeAction) Handle(ctx context.Context, instance *rhtasv1alpha1.TrillianTree) *action.Result {
+	observed := instance.Status.DeepCopy()
+
+	tree, size, err := i.sync(ctx, instance)
+	if err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Initialize,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, err, instance)
+	}
+
+	setStatus(instance, tree)
+	instance.Status.Size = &size
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:   constants.Ready,
+		Status: metav1.ConditionTrue,
+		Reason: constants.Ready,
+	})
+
+	if !equality.Semantic.DeepEqual(observed, &instance.Status) {
+		return i.StatusUpdate(ctx, instance)
+	}
+	// refresh the tree size periodically
+	return &action.Result{Result: reconcile.Result{RequeueAfter: RefreshInterval}}
+}
+
+// sync applies the spec to the tree in the Trillian backend and returns the updated tree and its size
+func (i syncTreeAction) sync(ctx context.Context, instance *rhtasv1alpha1.TrillianTree) (*trillian.Tree, uint64, error) {
+	trillianClient, err := connect(ctx, i.Client, instance, i.newClient)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = trillianClient.Close() }()
+
+	tree, err := trillianClient.GetTree(ctx, *instance.Status.TreeID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get Trillian tree %d: %w", *instance.Status.TreeID, err)
+	}
+
+	desired := desiredTree(instance)
+	var paths []string
+	if tree.TreeState != desired.TreeState {
+		paths = append(paths, "tree_state")
+	}
+	if tree.DisplayName != desired.DisplayName {
+		paths = append(paths, "display_name")
+	}
+	if tree.MaxRootDuration.AsDuration() != desired.MaxRootDuration.AsDuration() {
+		paths = append(paths, "max_root_duration")
+	}
+	if len(paths) > 0 {
+		desired.TreeId = tree.TreeId
+		if tree, err = trillianClient.UpdateTree(ctx, desired, paths...); err != nil {
+			return nil, 0, fmt.Errorf("could not update Trillian tree %d: %w", desired.TreeId, err)
+		}
+		i.Recorder.Eventf(instance, v1.EventTypeNormal, "TrillianTreeUpdated", "Trillian tree %d updated: %s", tree.TreeId, strings.Join(paths, ", "))
+	}
+
+	size, err := trillianClient.TreeSize(ctx, tree.TreeId)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get size of Trillian tree %d: %w", tree.TreeId, err)
+	}
+	return tree, size, nil
+}
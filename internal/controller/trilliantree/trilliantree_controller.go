@@ -0,0 +1,133 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trilliantree
+
+import (
+	"context"
+
+	olpredicate "github.com/operator-framework/operator-lib/predicate"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/action/transitions"
+	"github.com/securesign/operator/internal/controller/trilliantree/actions"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TrillianTreeReconciler reconciles a TrillianTree object
+type TrillianTreeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trilliantrees,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trilliantrees/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rhtas.redhat.com,resources=trilliantrees/finalizers,verbs=update
+
+// Reconcile keeps the tree in the Trillian backend in sync with the TrillianTree resource
+func (r *TrillianTreeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var instance rhtasv1alpha1.TrillianTree
+	log := ctrllog.FromContext(ctx)
+
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Fetch the namespace
+	var namespace v1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Namespace}, &namespace); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	target := instance.DeepCopy()
+
+	// Check if the namespace is marked for deletion
+	if !namespace.DeletionTimestamp.IsZero() {
+		// the Trillian backend is removed together with the namespace
+		if target.DeletionTimestamp != nil && controllerutil.RemoveFinalizer(target, actions.TreeFinalizer) {
+			return ctrl.Result{}, r.Update(ctx, target)
+		}
+		log.Info("namespace is marked for deletion, stopping reconciliation", "namespace", req.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	// The finalizer is required only when the tree is deleted together with the resource
+	if target.DeletionTimestamp == nil {
+		var changed bool
+		if target.Spec.DeletionPolicy == rhtasv1alpha1.TrillianTreeDelete {
+			changed = controllerutil.AddFinalizer(target, actions.TreeFinalizer)
+		} else {
+			changed = controllerutil.RemoveFinalizer(target, actions.TreeFinalizer)
+		}
+		if changed {
+			return ctrl.Result{}, r.Update(ctx, target)
+		}
+	}
+
+	acs := []action.Action[*rhtasv1alpha1.TrillianTree]{
+		actions.NewDeleteTreeAction(),
+
+		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.TrillianTree](func(_ *rhtasv1alpha1.TrillianTree) []string {
+			return []string{}
+		}),
+		transitions.NewToCreatePhaseAction[*rhtasv1alpha1.TrillianTree](),
+
+		actions.NewResolveTreeAction(),
+
+		transitions.NewToInitializePhaseAction[*rhtasv1alpha1.TrillianTree](),
+
+		actions.NewSyncTreeAction(),
+	}
+
+	for _, a := range acs {
+		a.InjectClient(r.Client)
+		a.InjectLogger(log.WithName(a.Name()))
+		a.InjectRecorder(r.Recorder)
+
+		if a.CanHandle(ctx, target) {
+			log.V(2).Info("Executing " + a.Name())
+			result := a.Handle(ctx, target)
+			if result != nil {
+				return result.Result, result.Err
+			}
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TrillianTreeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Filter out with the pause annotation.
+	pause, err := olpredicate.NewPause(annotations.PausedReconciliation)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithEventFilter(pause).
+		For(&rhtasv1alpha1.TrillianTree{}).
+		Complete(r)
+}
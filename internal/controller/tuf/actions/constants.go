@@ -1,9 +1,32 @@
 package actions
 
+import "github.com/securesign/operator/internal/controller/constants"
+
 const (
 	ComponentName  = "tuf"
 	DeploymentName = "tuf"
 	RBACName       = "tuf"
 	PortName       = "http"
 	Port           = 8080
+
+	// RepositoryName is the name of the ConfigMap holding the TUF repository generated by the operator
+	RepositoryName      = "tuf-repository"
+	RepositoryCondition = "Repository"
+	// HttpdConfigName is the name of the ConfigMap with the httpd configuration serving the TUF repository
+	HttpdConfigName = "tuf-httpd-config"
+	// ExpiryCondition is false while the root or targets metadata expires soon
+	ExpiryCondition = "MetadataExpiry"
+	ExpiringReason  = "Expiring"
+	// RootSigningCondition is false while a root version waits for the signatures of the root keys
+	RootSigningCondition       = "RootSigning"
+	WaitingForSignaturesReason = "WaitingForSignatures"
+
+	// MirrorCondition is false when the last update of the mirrored repository failed the verification
+	MirrorCondition          = "Mirror"
+	VerificationFailedReason = "VerificationFailed"
+	// MirrorURLAnnotation records the URL of the repository mirrored to the repository ConfigMap
+	MirrorURLAnnotation = constants.LabelNamespace + "/tuf-mirror-url"
+
+	// RootSignatureLabel marks the secrets with the detached signatures of the pending root keyed by the key ID
+	RootSignatureLabel = constants.LabelNamespace + "/tuf-root-signature"
 )
@@ -6,6 +6,8 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -38,7 +40,21 @@ func (i deployAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf) *
 
 	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
 
-	dp := tufutils.CreateTufDeployment(instance, DeploymentName, RBACName, labels)
+	dp := tufutils.CreateTufDeployment(instance, utils.ResourceName(instance, DeploymentName), utils.ResourceName(instance, RBACName), labels)
+	if instance.Spec.Repository != nil || instance.Spec.Mirror != nil {
+		repository, err := k8sutils.GetConfigMap(ctx, i.Client, instance.Namespace, utils.ResourceName(instance, RepositoryName))
+		if err != nil {
+			return i.Failed(fmt.Errorf("could not read TUF repository: %w", err))
+		}
+		httpdConfig := k8sutils.CreateConfigmap(instance.Namespace, utils.ResourceName(instance, HttpdConfigName), labels, nil)
+		if _, err = controllerutil.CreateOrUpdate(ctx, i.Client, httpdConfig, func() error {
+			httpdConfig.Data = map[string]string{tufutils.HttpdConfigKey: tufutils.HttpdConfig}
+			return controllerutil.SetControllerReference(instance, httpdConfig, i.Client.Scheme())
+		}); err != nil {
+			return i.Failed(fmt.Errorf("could not create TUF httpd configuration: %w", err))
+		}
+		dp = tufutils.CreateTufRepositoryDeployment(instance, utils.ResourceName(instance, DeploymentName), utils.ResourceName(instance, RBACName), labels, repository, httpdConfig)
+	}
 
 	if err = controllerutil.SetControllerReference(instance, dp, i.Client.Scheme()); err != nil {
 		return i.Failed(fmt.Errorf("could not set controller reference for Deployment: %w", err))
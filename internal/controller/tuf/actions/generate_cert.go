@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
+	"sort"
+	"strings"
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
-	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
+	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func NewResolveKeysAction() action.Action[*rhtasv1alpha1.Tuf] {
@@ -32,24 +35,20 @@ func (i resolveKeysAction) CanHandle(ctx context.Context, instance *rhtasv1alpha
 	if c.Reason != constants.Pending && c.Reason != constants.Ready {
 		return false
 	}
-
-	if !equality.Semantic.DeepDerivative(instance.Spec.Keys, instance.Status.Keys) {
-		return true
+	if instance.Spec.Mirror != nil {
+		// the mirrored repository publishes its own targets
+		return false
 	}
-	for index, k := range instance.Spec.Keys {
-		if k.SecretRef == nil {
-			if scr, _ := k8sutils.FindSecret(ctx, i.Client, instance.Namespace, fmt.Sprintf("%s/%s", constants.LabelNamespace, k.Name)); scr != nil {
-				if instance.Status.Keys[index].SecretRef == nil ||
-					instance.Status.Keys[index].SecretRef.Name != scr.Name ||
-					instance.Status.Keys[index].SecretRef.Key != scr.Labels[fmt.Sprintf("%s/%s", constants.LabelNamespace, k.Name)] {
-					return true
-				}
-			} else {
-				return true
-			}
+
+	keys := make([]rhtasv1alpha1.TufKey, 0, len(instance.Spec.Keys))
+	for _, k := range instance.Spec.Keys {
+		resolved, err := i.handleKey(ctx, instance, k)
+		if err != nil {
+			return true
 		}
+		keys = append(keys, resolved...)
 	}
-	return false
+	return !equality.Semantic.DeepEqual(keys, instance.Status.Keys)
 }
 
 func (i resolveKeysAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf) *action.Result {
@@ -58,11 +57,20 @@ func (i resolveKeysAction) Handle(ctx context.Context, instance *rhtasv1alpha1.T
 			Status: v1.ConditionFalse, Reason: constants.Pending, Message: "Resolving keys"})
 	}
 
-	if cap(instance.Status.Keys) < len(instance.Spec.Keys) {
-		instance.Status.Keys = make([]rhtasv1alpha1.TufKey, 0, len(instance.Spec.Keys))
-	}
-	for index, key := range instance.Spec.Keys {
-		k, err := i.handleKey(ctx, instance, &key)
+	keys := make([]rhtasv1alpha1.TufKey, 0, len(instance.Spec.Keys))
+	targets := make(map[string]string, len(instance.Spec.Keys))
+	for _, key := range instance.Spec.Keys {
+		resolved, err := i.handleKey(ctx, instance, key)
+		if err == nil {
+			// every target name must be unique across the keys
+			for _, k := range resolved {
+				if spec, ok := targets[k.Name]; ok {
+					err = fmt.Errorf("target %s is already published by the %s key", k.Name, spec)
+					break
+				}
+				targets[k.Name] = key.Name
+			}
+		}
 		if err != nil {
 			meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{Type: constants.Ready,
 				Status: v1.ConditionFalse, Reason: constants.Pending, Message: "Resolving keys"})
@@ -76,65 +84,85 @@ func (i resolveKeysAction) Handle(ctx context.Context, instance *rhtasv1alpha1.T
 			i.StatusUpdate(ctx, instance)
 			return i.Requeue()
 		}
-		if len(instance.Status.Keys) < index+1 {
-			instance.Status.Keys = append(instance.Status.Keys, *k)
-			meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-				Type:   key.Name,
-				Status: v1.ConditionTrue,
-				Reason: constants.Ready,
-			})
-		} else {
-			if !reflect.DeepEqual(*k, instance.Status.Keys[index]) {
-				instance.Status.Keys[index] = *k
-				meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
-					Type:   key.Name,
-					Status: v1.ConditionTrue,
-					Reason: constants.Ready,
-				})
-			}
-		}
-		if index == len(instance.Spec.Keys)-1 {
-			return i.Continue()
-		}
+		keys = append(keys, resolved...)
+		meta.SetStatusCondition(&instance.Status.Conditions, v1.Condition{
+			Type:   key.Name,
+			Status: v1.ConditionTrue,
+			Reason: constants.Ready,
+		})
 	}
-	return i.StatusUpdate(ctx, instance)
+	instance.Status.Keys = keys
+	return i.Continue()
 }
 
-func (i resolveKeysAction) handleKey(ctx context.Context, instance *rhtasv1alpha1.Tuf, key *rhtasv1alpha1.TufKey) (*rhtasv1alpha1.TufKey, error) {
-	switch {
-	case key.SecretRef == nil:
-		sks, err := i.discoverSecret(ctx, instance.Namespace, key)
-		if err != nil {
-			return nil, err
-		}
-		key.SecretRef = sks
-		return key, nil
-	case key.SecretRef != nil:
-		return key, nil
-	default:
-		return nil, fmt.Errorf("unable to resolve %s key. Enable autodiscovery or set secret reference", key.Name)
+// handleKey returns the targets of the key, a target for every autoconfigured secret
+func (i resolveKeysAction) handleKey(ctx context.Context, instance *rhtasv1alpha1.Tuf, key rhtasv1alpha1.TufKey) ([]rhtasv1alpha1.TufKey, error) {
+	if key.SecretRef != nil {
+		return []rhtasv1alpha1.TufKey{key}, nil
 	}
+	return i.discoverSecrets(ctx, instance, key)
 }
 
-func (i resolveKeysAction) discoverSecret(ctx context.Context, namespace string, key *rhtasv1alpha1.TufKey) (*rhtasv1alpha1.SecretKeySelector, error) {
+func (i resolveKeysAction) discoverSecrets(ctx context.Context, instance *rhtasv1alpha1.Tuf, key rhtasv1alpha1.TufKey) ([]rhtasv1alpha1.TufKey, error) {
+	namespace := instance.Namespace
+	if key.Namespace != "" {
+		namespace = key.Namespace
+	}
 	labelName := constants.LabelNamespace + "/" + key.Name
-	s, err := k8sutils.FindSecret(ctx, i.Client, namespace, labelName)
+	selector, err := labels.Parse(labelName)
+	if key.Selector != nil {
+		selector, err = v1.LabelSelectorAsSelector(key.Selector)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if s != nil {
-		keySelector := s.Labels[labelName]
+
+	list := &v1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(core.SchemeGroupVersion.WithKind("SecretList"))
+	if err = i.Client.List(ctx, list, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, errors.New("secret not found")
+	}
+	sort.Slice(list.Items, func(a, b int) bool { return list.Items[a].Name < list.Items[b].Name })
+
+	usage := key.Usage
+	if usage == "" {
+		usage = rhtasv1alpha1.TargetUsage(key.Name)
+	}
+	keys := make([]rhtasv1alpha1.TufKey, 0, len(list.Items))
+	for _, s := range list.Items {
+		keySelector, ok := s.Labels[labelName]
+		if !ok && key.Selector != nil {
+			keySelector = key.Name
+		}
 		if keySelector == "" {
-			err = fmt.Errorf("label %s is empty", labelName)
-			return nil, err
+			return nil, fmt.Errorf("label %s of secret %s is empty", labelName, s.Name)
 		}
-		return &rhtasv1alpha1.SecretKeySelector{
-			Key: keySelector,
-			LocalObjectReference: rhtasv1alpha1.LocalObjectReference{
-				Name: s.Name,
+		name := key.Name
+		if len(list.Items) > 1 {
+			name = targetName(key.Name, s.Name)
+		}
+		keys = append(keys, rhtasv1alpha1.TufKey{
+			Name: name,
+			SecretRef: &rhtasv1alpha1.SecretKeySelector{
+				Key: keySelector,
+				LocalObjectReference: rhtasv1alpha1.LocalObjectReference{
+					Name: s.Name,
+				},
 			},
-		}, nil
+			Namespace: key.Namespace,
+			Usage:     usage,
+		})
 	}
+	return keys, nil
+}
 
-	return nil, errors.New("secret not found")
+// targetName inserts the secret name into the name of the target, e.g. `rekor-<secret>.pub`
+func targetName(name, secret string) string {
+	if stem, ext, ok := strings.Cut(name, "."); ok {
+		return stem + "-" + secret + "." + ext
+	}
+	return name + "-" + secret
 }
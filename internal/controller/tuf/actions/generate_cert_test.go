@@ -10,6 +10,7 @@ import (
 	common "github.com/securesign/operator/internal/controller/common/action"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
+	testaction "github.com/securesign/operator/internal/testing/action"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
@@ -158,3 +159,52 @@ func TestKeyDelete(t *testing.T) {
 
 	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, "ctfe.pub")).To(BeTrue())
 }
+
+func TestKeyDiscoverMultiple(t *testing.T) {
+	g := NewWithT(t)
+	instance := &v1alpha1.Tuf{
+		ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: "default"},
+		Spec: v1alpha1.TufSpec{Keys: []v1alpha1.TufKey{
+			{Name: "rekor.pub", Namespace: "rekor"},
+			{Name: "ctfe.pub", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ctlog"}}},
+		}},
+		Status: v1alpha1.TufStatus{Conditions: []metav1.Condition{
+			{Type: constants.Ready, Reason: constants.Pending, Status: metav1.ConditionFalse},
+		}},
+	}
+	c := testaction.FakeClientBuilder().WithObjects(
+		kubernetes.CreateSecret("shard-b", "rekor", map[string][]byte{"public": nil}, map[string]string{constants.LabelNamespace + "/rekor.pub": "public"}),
+		kubernetes.CreateSecret("shard-a", "rekor", map[string][]byte{"public": nil}, map[string]string{constants.LabelNamespace + "/rekor.pub": "public"}),
+		kubernetes.CreateSecret("local", "default", map[string][]byte{"public": nil}, map[string]string{constants.LabelNamespace + "/rekor.pub": "public"}),
+		kubernetes.CreateSecret("ctlog", "default", map[string][]byte{"ctfe.pub": nil}, map[string]string{"app": "ctlog"}),
+	).Build()
+	a := testaction.PrepareAction(c, NewResolveKeysAction())
+
+	g.Expect(a.CanHandle(testContext, instance)).To(BeTrue())
+	a.Handle(testContext, instance)
+	g.Expect(instance.Status.Keys).To(Equal([]v1alpha1.TufKey{
+		{
+			Name:      "rekor-shard-a.pub",
+			SecretRef: &v1alpha1.SecretKeySelector{LocalObjectReference: v1alpha1.LocalObjectReference{Name: "shard-a"}, Key: "public"},
+			Namespace: "rekor",
+			Usage:     "Rekor",
+		},
+		{
+			Name:      "rekor-shard-b.pub",
+			SecretRef: &v1alpha1.SecretKeySelector{LocalObjectReference: v1alpha1.LocalObjectReference{Name: "shard-b"}, Key: "public"},
+			Namespace: "rekor",
+			Usage:     "Rekor",
+		},
+		{
+			Name:      "ctfe.pub",
+			SecretRef: &v1alpha1.SecretKeySelector{LocalObjectReference: v1alpha1.LocalObjectReference{Name: "ctlog"}, Key: "ctfe.pub"},
+			Usage:     "CTFE",
+		},
+	}))
+	g.Expect(a.CanHandle(testContext, instance)).To(BeFalse())
+
+	// a new shard is discovered
+	g.Expect(c.Create(testContext, kubernetes.CreateSecret("shard-c", "rekor", map[string][]byte{"public": nil},
+		map[string]string{constants.LabelNamespace + "/rekor.pub": "public"}))).To(Succeed())
+	g.Expect(a.CanHandle(testContext, instance)).To(BeTrue())
+}
@@ -0,0 +1,168 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// mirrorTimeout bounds the download of a file of the mirrored repository
+	mirrorTimeout = 30 * time.Second
+	// DefaultMirrorInterval is the period the mirrored repository is checked for updates at when none is set
+	DefaultMirrorInterval = time.Hour
+)
+
+func NewMirrorAction() action.Action[*rhtasv1alpha1.Tuf] {
+	return &mirrorAction{httpClient: &http.Client{Timeout: mirrorTimeout}}
+}
+
+// mirrorAction verifies the updates of the mirrored TUF repository and stores it to the repository ConfigMap
+type mirrorAction struct {
+	action.BaseAction
+	httpClient *http.Client
+}
+
+func (i mirrorAction) Name() string {
+	return "mirror"
+}
+
+func (i mirrorAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Tuf) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if c.Reason != constants.Creating && c.Reason != constants.Ready {
+		return false
+	}
+	if instance.Spec.Mirror == nil {
+		return instance.Status.Mirror != nil
+	}
+	status := instance.Status.Mirror
+	return status == nil || status.URL != instance.Spec.Mirror.URL || status.LastVerifiedTime == nil ||
+		mirrorAfter(instance, time.Now()) <= 0
+}
+
+func (i mirrorAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf) *action.Result {
+	if instance.Spec.Mirror == nil {
+		// the repository generated by the operator replaces the mirror
+		instance.Status.Mirror = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, MirrorCondition)
+		return i.StatusUpdate(ctx, instance)
+	}
+
+	now := time.Now()
+	mirror := instance.Spec.Mirror
+	status := &rhtasv1alpha1.TufMirrorStatus{URL: mirror.URL, LastSyncTime: metav1.NewTime(now)}
+	if instance.Status.Mirror != nil && instance.Status.Mirror.URL == mirror.URL {
+		status.Version, status.RootVersion = instance.Status.Mirror.Version, instance.Status.Mirror.RootVersion
+		status.LastVerifiedTime = instance.Status.Mirror.LastVerifiedTime
+	}
+	instance.Status.Mirror = status
+
+	secret, err := k8sutils.GetSecret(i.Client, instance.Namespace, mirror.Root.Name)
+	if err != nil {
+		return i.fail(ctx, instance, fmt.Errorf("could not read initial root: %w", err))
+	}
+	initialRoot, ok := secret.Data[mirror.Root.Key]
+	if !ok {
+		return i.fail(ctx, instance, fmt.Errorf("could not read initial root: secret %s has no key %s", secret.Name, mirror.Root.Key))
+	}
+
+	var (
+		repo        *tufutils.Repository
+		mirrored    *tufutils.MirrorStatus
+		verifyError error
+	)
+	labels := constants.LabelsFor(ComponentName, RepositoryName, instance.Name)
+	cm := k8sutils.CreateConfigmap(instance.Namespace, utils.ResourceName(instance, RepositoryName), labels, nil)
+	result, err := controllerutil.CreateOrUpdate(ctx, i.Client, cm, func() error {
+		current := tufutils.ReadRepository(cm)
+		if cm.Annotations[MirrorURLAnnotation] != mirror.URL {
+			// the repository generated by the operator or another mirror is replaced
+			current = tufutils.ReadRepository(nil)
+		}
+		if repo, mirrored, verifyError = tufutils.Mirror(ctx, i.httpClient, mirror.URL, current, initialRoot, now); verifyError != nil {
+			return verifyError
+		}
+		repo.Write(cm)
+		if cm.Annotations == nil {
+			cm.Annotations = map[string]string{}
+		}
+		cm.Annotations[MirrorURLAnnotation] = mirror.URL
+		return controllerutil.SetControllerReference(instance, cm, i.Client.Scheme())
+	})
+	if verifyError != nil {
+		message := fmt.Sprintf("Verification of %s failed: %s", mirror.URL, verifyError.Error())
+		i.Recorder.Event(instance, v1.EventTypeWarning, "MirrorVerificationFailed", message)
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    MirrorCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  VerificationFailedReason,
+			Message: message,
+		})
+		if status.LastVerifiedTime == nil {
+			// nothing to serve yet
+			return i.FailedWithStatusUpdate(ctx, errors.New(message), instance)
+		}
+		// the last verified copy is served until the next update
+		return i.StatusUpdate(ctx, instance)
+	}
+	if err != nil {
+		return i.fail(ctx, instance, fmt.Errorf("could not update TUF mirror: %w", err))
+	}
+
+	roles, err := repo.RoleStatus()
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		i.Recorder.Event(instance, v1.EventTypeNormal, "MirrorUpdated",
+			fmt.Sprintf("TUF repository %s mirrored, version %d", mirror.URL, mirrored.Version))
+	}
+	status.Version, status.RootVersion = mirrored.Version, mirrored.RootVersion
+	status.LastVerifiedTime = status.LastSyncTime.DeepCopy()
+	instance.Status.Repository = &rhtasv1alpha1.TufRepositoryStatus{Roles: roles}
+	for _, c := range []string{RepositoryCondition, RootSigningCondition, ExpiryCondition} {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, c)
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    MirrorCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  constants.Ready,
+		Message: fmt.Sprintf("Version %d verified, root version %d", mirrored.Version, mirrored.RootVersion),
+	})
+	return i.StatusUpdate(ctx, instance)
+}
+
+func (i mirrorAction) fail(ctx context.Context, instance *rhtasv1alpha1.Tuf, err error) *action.Result {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    MirrorCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  constants.Failure,
+		Message: err.Error(),
+	})
+	return i.FailedWithStatusUpdate(ctx, err, instance)
+}
+
+// mirrorAfter returns the time until the mirrored repository is due to update
+func mirrorAfter(instance *rhtasv1alpha1.Tuf, now time.Time) time.Duration {
+	if instance.Status.Mirror == nil {
+		return 0
+	}
+	interval := instance.Spec.Mirror.Interval.Duration
+	if interval == 0 {
+		interval = DefaultMirrorInterval
+	}
+	return instance.Status.Mirror.LastSyncTime.Add(interval).Sub(now)
+}
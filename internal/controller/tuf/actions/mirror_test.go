@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	testaction "github.com/securesign/operator/internal/testing/action"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMirror(t *testing.T) {
+	g := NewWithT(t)
+	keys, err := tufutils.GenerateSigningKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+	signers, err := tufutils.LoadSigners(keys)
+	g.Expect(err).ToNot(HaveOccurred())
+	upstream, err := tufutils.Update(tufutils.ReadRepository(nil), signers, map[string][]byte{"rekor.pub": []byte("rekor")},
+		tufutils.UpdateOptions{Now: time.Now()})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	served := &core.ConfigMap{}
+	upstream.Write(served)
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, item := range tufutils.Items(served) {
+			if available && "/"+item.Path == r.URL.Path {
+				if content, ok := served.Data[item.Key]; ok {
+					_, _ = w.Write([]byte(content))
+				} else {
+					_, _ = w.Write(served.BinaryData[item.Key])
+				}
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	instance := &rhtasv1alpha1.Tuf{
+		ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.TufSpec{Mirror: &rhtasv1alpha1.TufMirror{
+			URL: server.URL,
+			Root: rhtasv1alpha1.SecretKeySelector{
+				LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "upstream-root"},
+				Key:                  "root.json",
+			},
+		}},
+		Status: rhtasv1alpha1.TufStatus{Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Creating}}},
+	}
+	c := testaction.FakeClientBuilder().
+		WithObjects(instance,
+			kubernetes.CreateSecret("upstream-root", "default", map[string][]byte{"root.json": upstream.Metadata["1.root.json"]}, nil),
+		).
+		WithStatusSubresource(instance).
+		Build()
+	a := testaction.PrepareAction(c, NewMirrorAction())
+
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, MirrorCondition)).To(BeTrue())
+	g.Expect(instance.Status.Mirror.URL).To(Equal(server.URL))
+	g.Expect(instance.Status.Mirror.Version).To(Equal(int64(1)))
+	g.Expect(instance.Status.Mirror.RootVersion).To(Equal(int64(1)))
+	g.Expect(instance.Status.Mirror.LastVerifiedTime).ToNot(BeNil())
+	g.Expect(instance.Status.Repository.Roles).To(HaveLen(4))
+
+	cm := &core.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "tuf-" + RepositoryName}, cm)).To(Succeed())
+	g.Expect(cm.Annotations).To(HaveKeyWithValue(MirrorURLAnnotation, server.URL))
+	g.Expect(tufutils.ReadRepository(cm).Targets).To(HaveKeyWithValue("rekor.pub", []byte("rekor")))
+
+	// the update is due after the interval
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+	instance.Status.Mirror.LastSyncTime = metav1.NewTime(time.Now().Add(-2 * DefaultMirrorInterval))
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+
+	// the verified copy is kept when the update fails
+	available = false
+	verified := instance.Status.Mirror.LastVerifiedTime
+	_ = a.Handle(context.TODO(), instance)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, MirrorCondition)
+	g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(condition.Reason).To(Equal(VerificationFailedReason))
+	g.Expect(instance.Status.Mirror.LastVerifiedTime).To(Equal(verified))
+	g.Expect(instance.Status.Mirror.Version).To(Equal(int64(1)))
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "tuf-" + RepositoryName}, cm)).To(Succeed())
+	g.Expect(tufutils.ReadRepository(cm).Targets).To(HaveKey("rekor.pub"))
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+}
@@ -0,0 +1,69 @@
+package actions
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// MaxRefreshInterval bounds the period the expiry of the TUF metadata is checked at
+const MaxRefreshInterval = time.Hour
+
+func NewRefreshAction() action.Action[*rhtasv1alpha1.Tuf] {
+	return &refreshAction{}
+}
+
+// refreshAction schedules the reconcile re-signing the online roles before their metadata expires
+// and the next update of the mirrored repository
+type refreshAction struct {
+	action.BaseAction
+}
+
+func (i refreshAction) Name() string {
+	return "refresh"
+}
+
+func (i refreshAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Tuf) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if instance.Spec.Mirror != nil {
+		return c.Reason == constants.Ready && instance.Status.Mirror != nil
+	}
+	return c.Reason == constants.Ready && instance.Spec.Repository != nil && instance.Status.Repository != nil
+}
+
+func (i refreshAction) Handle(_ context.Context, instance *rhtasv1alpha1.Tuf) *action.Result {
+	if instance.Spec.Mirror != nil {
+		after := mirrorAfter(instance, time.Now())
+		if after < time.Second {
+			after = time.Second
+		}
+		return &action.Result{Result: reconcile.Result{RequeueAfter: after}}
+	}
+	return &action.Result{Result: reconcile.Result{RequeueAfter: refreshAfter(instance, time.Now())}}
+}
+
+// refreshAfter returns the time until the first online role is due to refresh
+func refreshAfter(instance *rhtasv1alpha1.Tuf, now time.Time) time.Duration {
+	expirations := tufutils.ExpirationsOf(instance.Spec.Repository)
+	after := MaxRefreshInterval
+	for _, role := range instance.Status.Repository.Roles {
+		if !slices.Contains(tufutils.OnlineRoles, role.Name) {
+			continue
+		}
+		if d := tufutils.RefreshAt(role.Expires.Time, expirations[role.Name]).Sub(now); d < after {
+			after = d
+		}
+	}
+	// the refresh is due
+	if after < time.Second {
+		after = time.Second
+	}
+	return after
+}
@@ -0,0 +1,316 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func NewRepositoryAction() action.Action[*rhtasv1alpha1.Tuf] {
+	return &repositoryAction{}
+}
+
+// repositoryAction generates and signs the TUF repository and stores it to the repository ConfigMap
+type repositoryAction struct {
+	action.BaseAction
+}
+
+func (i repositoryAction) Name() string {
+	return "repository"
+}
+
+func (i repositoryAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Tuf) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if c.Reason != constants.Creating && c.Reason != constants.Ready {
+		return false
+	}
+	if instance.Spec.Mirror != nil {
+		return false
+	}
+	return instance.Spec.Repository != nil || instance.Status.Repository != nil
+}
+
+func (i repositoryAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf) *action.Result {
+	if instance.Spec.Repository == nil {
+		// the TUF server generates the repository
+		instance.Status.Repository = nil
+		meta.RemoveStatusCondition(&instance.Status.Conditions, RepositoryCondition)
+		return i.StatusUpdate(ctx, instance)
+	}
+
+	signers, err := i.signers(instance)
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	targets := make(map[string][]byte, len(instance.Status.Keys))
+	published := make(map[string]time.Time, len(instance.Status.Keys))
+	usages := make(map[string]string, len(instance.Status.Keys))
+	for _, key := range instance.Status.Keys {
+		if key.SecretRef == nil {
+			return i.fail(ctx, instance, fmt.Errorf("target %s is not resolved", key.Name))
+		}
+		namespace := instance.Namespace
+		if key.Namespace != "" {
+			namespace = key.Namespace
+		}
+		secret, err := k8sutils.GetSecret(i.Client, namespace, key.SecretRef.Name)
+		if err != nil {
+			return i.fail(ctx, instance, fmt.Errorf("could not read target %s: %w", key.Name, err))
+		}
+		content, ok := secret.Data[key.SecretRef.Key]
+		if !ok {
+			return i.fail(ctx, instance, fmt.Errorf("could not read target %s: secret %s has no key %s", key.Name, secret.Name, key.SecretRef.Key))
+		}
+		targets[key.Name] = content
+		published[key.Name] = secret.CreationTimestamp.Time
+		usages[key.Name] = key.Usage
+	}
+
+	root, err := i.rootOptions(instance)
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	rootSignatures, err := i.rootSignatures(ctx, instance)
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+
+	resign, err := resignRoles(instance)
+	if err != nil {
+		i.Recorder.Event(instance, v1.EventTypeWarning, "InvalidResignRequest", err.Error())
+	}
+
+	now := time.Now()
+	expirations := tufutils.ExpirationsOf(instance.Spec.Repository)
+	labels := constants.LabelsFor(ComponentName, RepositoryName, instance.Name)
+	cm := k8sutils.CreateConfigmap(instance.Namespace, utils.ResourceName(instance, RepositoryName), labels, nil)
+	var repo *tufutils.Repository
+	result, err := controllerutil.CreateOrUpdate(ctx, i.Client, cm, func() error {
+		current := tufutils.ReadRepository(cm)
+		if _, ok := cm.Annotations[MirrorURLAnnotation]; ok {
+			// the mirrored repository is replaced
+			current = tufutils.ReadRepository(nil)
+			delete(cm.Annotations, MirrorURLAnnotation)
+		}
+		if trustedRoot := instance.Spec.Repository.TrustedRoot; trustedRoot != nil {
+			// the trusted root is rebuilt from the targets, the previous one keeps the validity windows
+			trustTargets, err := tufutils.TrustTargets(current.Targets[tufutils.TrustedRootTarget], targets,
+				tufutils.TrustConfigOf(trustedRoot, published, usages), now)
+			if err != nil {
+				return err
+			}
+			maps.Copy(targets, trustTargets)
+		}
+		if repo, err = tufutils.Update(current, signers, targets, tufutils.UpdateOptions{
+			Now:            now,
+			Expirations:    expirations,
+			Resign:         resign,
+			Root:           root,
+			RootSignatures: rootSignatures,
+			Usages:         usages,
+		}); err != nil {
+			return err
+		}
+		repo.Write(cm)
+		return controllerutil.SetControllerReference(instance, cm, i.Client.Scheme())
+	})
+	if err != nil {
+		return i.fail(ctx, instance, fmt.Errorf("could not update TUF repository: %w", err))
+	}
+
+	if _, ok := instance.GetAnnotations()[annotations.ResignTufMetadata]; ok {
+		// the patch refreshes the instance, the status is updated afterwards
+		patch := client.MergeFrom(instance.DeepCopy())
+		delete(instance.Annotations, annotations.ResignTufMetadata)
+		if err = i.Client.Patch(ctx, instance, patch); err != nil {
+			return i.Failed(fmt.Errorf("could not remove %s annotation: %w", annotations.ResignTufMetadata, err))
+		}
+	}
+
+	roles, err := repo.RoleStatus()
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	pending, err := repo.PendingRootStatus()
+	if err != nil {
+		return i.fail(ctx, instance, err)
+	}
+	if result != controllerutil.OperationResultNone {
+		i.Recorder.Event(instance, v1.EventTypeNormal, "RepositoryUpdated", "TUF repository signed")
+	}
+
+	before := instance.Status.DeepCopy()
+	instance.Status.Repository.Roles = roles
+	instance.Status.Repository.PendingRoot = pending
+	if pending != nil {
+		message := fmt.Sprintf("Root version %d is waiting for signatures, signed by %d of %d root keys",
+			pending.Version, len(pending.SignedKeyIDs), pending.Threshold)
+		if pending.PreviousThreshold > 0 {
+			message += fmt.Sprintf(" and the threshold %d of the previous root keys", pending.PreviousThreshold)
+		}
+		if !meta.IsStatusConditionFalse(before.Conditions, RootSigningCondition) {
+			i.Recorder.Event(instance, v1.EventTypeNormal, "RootSignaturesRequired", message)
+		}
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    RootSigningCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  WaitingForSignaturesReason,
+			Message: message,
+		})
+	} else {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:   RootSigningCondition,
+			Status: metav1.ConditionTrue,
+			Reason: constants.Ready,
+		})
+	}
+
+	if len(roles) == 0 {
+		// the repository is not served until the first root is signed, the signature secrets trigger the reconcile
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    RepositoryCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Pending,
+			Message: "Waiting for the signatures of the first root",
+		})
+		if equality.Semantic.DeepEqual(before, &instance.Status) {
+			return i.Return()
+		}
+		return i.StatusUpdate(ctx, instance)
+	}
+
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    RepositoryCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  constants.Ready,
+		Message: fmt.Sprintf("TUF repository signed, root version %d", roles[0].Version),
+	})
+	if expiring := expiringRoles(roles, expirations, now); len(expiring) > 0 {
+		message := "Metadata expires soon: " + strings.Join(expiring, ", ")
+		if !meta.IsStatusConditionFalse(before.Conditions, ExpiryCondition) {
+			i.Recorder.Event(instance, v1.EventTypeWarning, "MetadataExpiring", message)
+		}
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    ExpiryCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  ExpiringReason,
+			Message: message,
+		})
+	} else {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:   ExpiryCondition,
+			Status: metav1.ConditionTrue,
+			Reason: constants.Ready,
+		})
+	}
+
+	if result == controllerutil.OperationResultNone && equality.Semantic.DeepEqual(before, &instance.Status) {
+		return i.Continue()
+	}
+	return i.StatusUpdate(ctx, instance)
+}
+
+func (i repositoryAction) signers(instance *rhtasv1alpha1.Tuf) (tufutils.RoleSigners, error) {
+	if instance.Status.Repository == nil || instance.Status.Repository.SigningKeys == nil {
+		return nil, fmt.Errorf("signing keys are not resolved")
+	}
+	secret, err := k8sutils.GetSecret(i.Client, instance.Namespace, instance.Status.Repository.SigningKeys.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read signing keys: %w", err)
+	}
+	return tufutils.LoadSigners(secret.Data)
+}
+
+// rootOptions returns the root keys of the root signed offline
+func (i repositoryAction) rootOptions(instance *rhtasv1alpha1.Tuf) (*tufutils.RootOptions, error) {
+	root := instance.Spec.Repository.Root
+	if root == nil {
+		return nil, nil
+	}
+	options := &tufutils.RootOptions{Threshold: root.Threshold, Keys: make([]tufutils.Key, 0, len(root.Keys))}
+	for _, ref := range root.Keys {
+		content, err := k8sutils.GetSecretData(i.Client, instance.Namespace, &ref)
+		if err != nil {
+			return nil, fmt.Errorf("could not read root key %s/%s: %w", ref.Name, ref.Key, err)
+		}
+		key, err := tufutils.NewKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root key %s/%s: %w", ref.Name, ref.Key, err)
+		}
+		options.Keys = append(options.Keys, key)
+	}
+	return options, nil
+}
+
+// rootSignatures returns the detached signatures of the pending root collected from the labelled secrets
+func (i repositoryAction) rootSignatures(ctx context.Context, instance *rhtasv1alpha1.Tuf) ([]tufutils.Signature, error) {
+	secrets := &v1.SecretList{}
+	if err := i.Client.List(ctx, secrets, client.InNamespace(instance.Namespace), client.HasLabels{RootSignatureLabel}); err != nil {
+		return nil, fmt.Errorf("could not list root signatures: %w", err)
+	}
+	signatures := make([]tufutils.Signature, 0)
+	for _, secret := range secrets.Items {
+		for id, sig := range secret.Data {
+			signatures = append(signatures, tufutils.Signature{KeyID: id, Signature: strings.TrimSpace(string(sig))})
+		}
+	}
+	return signatures, nil
+}
+
+func (i repositoryAction) fail(ctx context.Context, instance *rhtasv1alpha1.Tuf, err error) *action.Result {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    RepositoryCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  constants.Failure,
+		Message: err.Error(),
+	})
+	return i.FailedWithStatusUpdate(ctx, err, instance)
+}
+
+// resignRoles returns the roles requested to be re-signed by the annotation
+func resignRoles(instance *rhtasv1alpha1.Tuf) ([]string, error) {
+	value, ok := instance.GetAnnotations()[annotations.ResignTufMetadata]
+	if !ok {
+		return nil, nil
+	}
+	roles := make([]string, 0, len(tufutils.Roles))
+	for _, role := range strings.Split(value, ",") {
+		role = strings.TrimSpace(role)
+		if !slices.Contains(tufutils.Roles, role) {
+			return nil, fmt.Errorf("unknown TUF role %q in %s annotation", role, annotations.ResignTufMetadata)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// expiringRoles returns the roles not re-signed on schedule whose metadata expires soon
+func expiringRoles(roles []rhtasv1alpha1.TufRoleStatus, expirations tufutils.Expirations, now time.Time) []string {
+	expiring := make([]string, 0)
+	for _, role := range roles {
+		if slices.Contains(tufutils.OnlineRoles, role.Name) {
+			continue
+		}
+		if tufutils.ExpiresSoon(role.Expires.Time, expirations[role.Name], now) {
+			expiring = append(expiring, fmt.Sprintf("%s at %s", role.Name, role.Expires.UTC().Format(time.RFC3339)))
+		}
+	}
+	return expiring
+}
@@ -0,0 +1,211 @@
+package actions
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/annotations"
+	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	testaction "github.com/securesign/operator/internal/testing/action"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSigningKeys(t *testing.T) {
+	g := NewWithT(t)
+	instance := &rhtasv1alpha1.Tuf{
+		ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: "default"},
+		Spec:       rhtasv1alpha1.TufSpec{Repository: &rhtasv1alpha1.TufRepository{}},
+		Status: rhtasv1alpha1.TufStatus{Conditions: []metav1.Condition{
+			{Type: constants.Ready, Reason: constants.Pending},
+		}},
+	}
+	c := testaction.FakeClientBuilder().WithObjects(instance).WithStatusSubresource(instance).Build()
+	a := testaction.PrepareAction(c, NewSigningKeysAction())
+
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(instance.Status.Repository.SigningKeys).ToNot(BeNil())
+
+	secret := &core.Secret{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: instance.Status.Repository.SigningKeys.Name}, secret)).To(Succeed())
+	_, err := tufutils.LoadSigners(secret.Data)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeFalse())
+
+	instance.Spec.Repository.SigningKeys = &rhtasv1alpha1.LocalObjectReference{Name: "offline-keys"}
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(instance.Status.Repository.SigningKeys.Name).To(Equal("offline-keys"))
+}
+
+func TestRepository(t *testing.T) {
+	g := NewWithT(t)
+	keys, err := tufutils.GenerateSigningKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	instance := &rhtasv1alpha1.Tuf{
+		ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: "default", UID: "uid"},
+		Spec:       rhtasv1alpha1.TufSpec{Repository: &rhtasv1alpha1.TufRepository{}},
+		Status: rhtasv1alpha1.TufStatus{
+			Keys: []rhtasv1alpha1.TufKey{{
+				Name: "rekor.pub",
+				SecretRef: &rhtasv1alpha1.SecretKeySelector{
+					LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "rekor"},
+					Key:                  "public",
+				},
+			}},
+			Repository: &rhtasv1alpha1.TufRepositoryStatus{SigningKeys: &rhtasv1alpha1.LocalObjectReference{Name: "keys"}},
+			Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Creating}},
+		},
+	}
+	c := testaction.FakeClientBuilder().
+		WithObjects(instance,
+			kubernetes.CreateSecret("keys", "default", keys, nil),
+			kubernetes.CreateSecret("rekor", "default", map[string][]byte{"public": []byte("rekor")}, nil),
+		).
+		WithStatusSubresource(instance).
+		Build()
+	a := testaction.PrepareAction(c, NewRepositoryAction())
+
+	g.Expect(a.CanHandle(context.TODO(), instance)).To(BeTrue())
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, RepositoryCondition)).To(BeTrue())
+	g.Expect(instance.Status.Repository.Roles).To(HaveLen(4))
+	g.Expect(instance.Status.Repository.Roles).To(HaveEach(HaveField("Version", int64(1))))
+
+	cm := &core.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "tuf-" + RepositoryName}, cm)).To(Succeed())
+	g.Expect(cm.OwnerReferences).To(HaveLen(1))
+	g.Expect(cm.BinaryData).To(HaveKeyWithValue("targets_rekor.pub", []byte("rekor")))
+	resourceVersion := cm.ResourceVersion
+
+	// the repository is up-to-date
+	g.Expect(a.Handle(context.TODO(), instance)).To(BeNil())
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "tuf-" + RepositoryName}, cm)).To(Succeed())
+	g.Expect(cm.ResourceVersion).To(Equal(resourceVersion))
+
+	t.Run("re-sign annotation", func(t *testing.T) {
+		g := NewWithT(t)
+		instance.Annotations = map[string]string{annotations.ResignTufMetadata: "targets"}
+		g.Expect(c.Update(context.TODO(), instance)).To(Succeed())
+		_ = a.Handle(context.TODO(), instance)
+		g.Expect(instance.Annotations).ToNot(HaveKey(annotations.ResignTufMetadata))
+		g.Expect(instance.Status.Repository.Roles).To(ContainElement(And(
+			HaveField("Name", tufutils.TargetsRole),
+			HaveField("Version", int64(2)),
+		)))
+	})
+
+	t.Run("missing signing keys", func(t *testing.T) {
+		g := NewWithT(t)
+		instance.Status.Repository.SigningKeys.Name = "missing"
+		result := a.Handle(context.TODO(), instance)
+		g.Expect(testaction.IsFailed(result)).To(BeTrue())
+		g.Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, RepositoryCondition)).To(BeTrue())
+	})
+}
+
+func TestRepository_offlineRoot(t *testing.T) {
+	g := NewWithT(t)
+	keys, err := tufutils.GenerateSigningKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+	rootKey, err := cryptoutils.UnmarshalPEMToPrivateKey(keys[tufutils.RootRole], cryptoutils.SkipPassword)
+	g.Expect(err).ToNot(HaveOccurred())
+	rootPublic, err := cryptoutils.MarshalPublicKeyToPEM(rootKey.(*ecdsa.PrivateKey).Public())
+	g.Expect(err).ToNot(HaveOccurred())
+	delete(keys, tufutils.RootRole)
+
+	instance := &rhtasv1alpha1.Tuf{
+		ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: "default", UID: "uid"},
+		Spec: rhtasv1alpha1.TufSpec{Repository: &rhtasv1alpha1.TufRepository{
+			Root: &rhtasv1alpha1.TufRoot{
+				Keys: []rhtasv1alpha1.SecretKeySelector{{
+					LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "root"},
+					Key:                  "public",
+				}},
+				Threshold: 1,
+			},
+		}},
+		Status: rhtasv1alpha1.TufStatus{
+			Repository: &rhtasv1alpha1.TufRepositoryStatus{SigningKeys: &rhtasv1alpha1.LocalObjectReference{Name: "keys"}},
+			Conditions: []metav1.Condition{{Type: constants.Ready, Reason: constants.Creating}},
+		},
+	}
+	c := testaction.FakeClientBuilder().
+		WithObjects(instance,
+			kubernetes.CreateSecret("keys", "default", keys, nil),
+			kubernetes.CreateSecret("root", "default", map[string][]byte{"public": rootPublic}, nil),
+		).
+		WithStatusSubresource(instance).
+		Build()
+	a := testaction.PrepareAction(c, NewRepositoryAction())
+
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(meta.IsStatusConditionFalse(instance.Status.Conditions, RepositoryCondition)).To(BeTrue())
+	g.Expect(meta.FindStatusCondition(instance.Status.Conditions, RootSigningCondition).Reason).To(Equal(WaitingForSignaturesReason))
+	g.Expect(instance.Status.Repository.Roles).To(BeEmpty())
+	g.Expect(instance.Status.Repository.PendingRoot).ToNot(BeNil())
+	g.Expect(instance.Status.Repository.PendingRoot.Version).To(Equal(int64(1)))
+
+	// the repository is not served until the root is signed
+	result := a.Handle(context.TODO(), instance)
+	g.Expect(result).ToNot(BeNil())
+	g.Expect(result.Err).ToNot(HaveOccurred())
+
+	cm := &core.ConfigMap{}
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "tuf-" + RepositoryName}, cm)).To(Succeed())
+	digest := sha256.Sum256([]byte(cm.Data[tufutils.PendingPayloadKey]))
+	sig, err := ecdsa.SignASN1(rand.Reader, rootKey.(*ecdsa.PrivateKey), digest[:])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Create(context.TODO(), kubernetes.CreateSecret("root-signature", "default",
+		map[string][]byte{instance.Status.Repository.PendingRoot.KeyIDs[0]: []byte(hex.EncodeToString(sig) + "\n")},
+		map[string]string{RootSignatureLabel: "tuf"},
+	))).To(Succeed())
+
+	_ = a.Handle(context.TODO(), instance)
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, RepositoryCondition)).To(BeTrue())
+	g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, RootSigningCondition)).To(BeTrue())
+	g.Expect(instance.Status.Repository.PendingRoot).To(BeNil())
+	g.Expect(instance.Status.Repository.Roles).To(HaveLen(4))
+}
+
+func TestExpiringRoles(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	roles := []rhtasv1alpha1.TufRoleStatus{
+		{Name: tufutils.RootRole, Expires: metav1.NewTime(now.Add(300 * 24 * time.Hour))},
+		{Name: tufutils.TargetsRole, Expires: metav1.NewTime(now.Add(30 * 24 * time.Hour))},
+		{Name: tufutils.SnapshotRole, Expires: metav1.NewTime(now.Add(time.Hour))},
+		{Name: tufutils.TimestampRole, Expires: metav1.NewTime(now.Add(time.Hour))},
+	}
+	g.Expect(expiringRoles(roles, tufutils.DefaultExpirations, now)).To(Equal([]string{"targets at 2024-01-31T00:00:00Z"}))
+}
+
+func TestRefreshAfter(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	instance := &rhtasv1alpha1.Tuf{
+		Spec: rhtasv1alpha1.TufSpec{Repository: &rhtasv1alpha1.TufRepository{}},
+		Status: rhtasv1alpha1.TufStatus{Repository: &rhtasv1alpha1.TufRepositoryStatus{Roles: []rhtasv1alpha1.TufRoleStatus{
+			{Name: tufutils.RootRole, Expires: metav1.NewTime(now)},
+			{Name: tufutils.SnapshotRole, Expires: metav1.NewTime(now.Add(7 * 24 * time.Hour))},
+			{Name: tufutils.TimestampRole, Expires: metav1.NewTime(now.Add(24 * time.Hour))},
+		}}},
+	}
+	g.Expect(refreshAfter(instance, now)).To(Equal(MaxRefreshInterval))
+	g.Expect(refreshAfter(instance, now.Add(11*time.Hour+30*time.Minute))).To(Equal(30 * time.Minute))
+	g.Expect(refreshAfter(instance, now.Add(13*time.Hour))).To(Equal(time.Second))
+}
@@ -6,6 +6,7 @@ import (
 
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
 	"github.com/securesign/operator/internal/controller/common/action"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/internal/controller/constants"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -38,7 +39,7 @@ func (i serviceAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf)
 
 	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
 
-	svc := kubernetes.CreateService(instance.Namespace, DeploymentName, PortName, Port, Port, labels)
+	svc := kubernetes.CreateService(instance.Namespace, utils.ResourceName(instance, DeploymentName), PortName, Port, Port, labels)
 	//patch the pregenerated service
 	svc.Spec.Ports[0].Port = instance.Spec.Port
 	if err = controllerutil.SetControllerReference(instance, svc, i.Client.Scheme()); err != nil {
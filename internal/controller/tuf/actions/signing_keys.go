@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/action"
+	k8sutils "github.com/securesign/operator/internal/controller/common/utils/kubernetes"
+	"github.com/securesign/operator/internal/controller/constants"
+	tufutils "github.com/securesign/operator/internal/controller/tuf/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const signingKeysSecretNameFormat = "tuf-signing-keys-%s-"
+
+func NewSigningKeysAction() action.Action[*rhtasv1alpha1.Tuf] {
+	return &signingKeysAction{}
+}
+
+// signingKeysAction resolves the keys signing the TUF repository generated by the operator
+type signingKeysAction struct {
+	action.BaseAction
+}
+
+func (i signingKeysAction) Name() string {
+	return "signing keys"
+}
+
+func (i signingKeysAction) CanHandle(_ context.Context, instance *rhtasv1alpha1.Tuf) bool {
+	c := meta.FindStatusCondition(instance.Status.Conditions, constants.Ready)
+	if c.Reason != constants.Pending && c.Reason != constants.Ready {
+		return false
+	}
+	if instance.Spec.Repository == nil || instance.Spec.Mirror != nil {
+		return false
+	}
+	if instance.Status.Repository == nil || instance.Status.Repository.SigningKeys == nil {
+		return true
+	}
+	spec := instance.Spec.Repository.SigningKeys
+	return spec != nil && *spec != *instance.Status.Repository.SigningKeys
+}
+
+func (i signingKeysAction) Handle(ctx context.Context, instance *rhtasv1alpha1.Tuf) *action.Result {
+	// Return to pending state because the signing keys changed
+	if meta.FindStatusCondition(instance.Status.Conditions, constants.Ready).Reason != constants.Pending {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Pending,
+			Message: "Resolving signing keys",
+		})
+		return i.StatusUpdate(ctx, instance)
+	}
+
+	if instance.Status.Repository == nil {
+		instance.Status.Repository = &rhtasv1alpha1.TufRepositoryStatus{}
+	}
+	if instance.Spec.Repository.SigningKeys != nil {
+		ref := *instance.Spec.Repository.SigningKeys
+		instance.Status.Repository.SigningKeys = &ref
+		return i.StatusUpdate(ctx, instance)
+	}
+
+	keys, err := tufutils.GenerateSigningKeys()
+	if err != nil {
+		return i.Failed(fmt.Errorf("could not generate TUF signing keys: %w", err))
+	}
+	labels := constants.LabelsFor(ComponentName, DeploymentName, instance.Name)
+	secret := k8sutils.CreateImmutableSecret(fmt.Sprintf(signingKeysSecretNameFormat, instance.Name), instance.Namespace, keys, labels)
+	if _, err = i.Ensure(ctx, secret); err != nil {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    constants.Ready,
+			Status:  metav1.ConditionFalse,
+			Reason:  constants.Failure,
+			Message: err.Error(),
+		})
+		return i.FailedWithStatusUpdate(ctx, fmt.Errorf("could not create signing keys secret: %w", err), instance)
+	}
+	i.Recorder.Eventf(instance, v1.EventTypeNormal, "SigningKeysCreated", "TUF signing keys created: %s", secret.Name)
+
+	instance.Status.Repository.SigningKeys = &rhtasv1alpha1.LocalObjectReference{Name: secret.Name}
+	return i.StatusUpdate(ctx, instance)
+}
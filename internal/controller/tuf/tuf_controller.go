@@ -18,6 +18,7 @@ package tuf
 
 import (
 	"context"
+	"slices"
 
 	olpredicate "github.com/operator-framework/operator-lib/predicate"
 	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
@@ -31,6 +32,7 @@ import (
 	v1 "k8s.io/api/apps/v1"
 	v12 "k8s.io/api/core/v1"
 	v13 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -57,6 +59,7 @@ type TufReconciler struct {
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=tufs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=tufs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=rhtas.redhat.com,resources=tufs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -91,7 +94,13 @@ func (r *TufReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 
 	target := instance.DeepCopy()
 	acs := []action.Action[*rhtasv1alpha1.Tuf]{
+		transitions.NewLegacyNamesAction[*rhtasv1alpha1.Tuf](func(_ *rhtasv1alpha1.Tuf) client.Object {
+			return &v1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: actions.DeploymentName}}
+		}),
 		transitions.NewToPendingPhaseAction[*rhtasv1alpha1.Tuf](func(tuf *rhtasv1alpha1.Tuf) []string {
+			if tuf.Spec.Mirror != nil {
+				return nil
+			}
 			keys := make([]string, len(tuf.Spec.Keys))
 			for i, k := range tuf.Spec.Keys {
 				keys[i] = k.Name
@@ -100,15 +109,21 @@ func (r *TufReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		}),
 
 		actions.NewResolveKeysAction(),
+		actions.NewSigningKeysAction(),
 		transitions.NewToCreatePhaseAction[*rhtasv1alpha1.Tuf](),
 		actions.NewRBACAction(),
+		actions.NewRepositoryAction(),
+		actions.NewMirrorAction(),
 		actions.NewDeployAction(),
+		actions.NewPodDisruptionBudgetAction(),
 		actions.NewServiceAction(),
 		actions.NewIngressAction(),
 
 		transitions.NewToInitializePhaseAction[*rhtasv1alpha1.Tuf](),
 
 		actions.NewInitializeAction(),
+		transitions.NewObservedGenerationAction[*rhtasv1alpha1.Tuf](),
+		actions.NewRefreshAction(),
 	}
 
 	for _, a := range acs {
@@ -130,8 +145,8 @@ func (r *TufReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 // SetupWithManager sets up the controller with the Manager.
 func (r *TufReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	var (
-		fulcioP, rekorP, ctlP predicate.Predicate
-		err                   error
+		fulcioP, rekorP, ctlP, rootP predicate.Predicate
+		err                          error
 	)
 
 	// Filter out with the pause annotation.
@@ -165,6 +180,15 @@ func (r *TufReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	if rootP, err = predicate.LabelSelectorPredicate(metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{
+			Key:      actions.RootSignatureLabel,
+			Operator: metav1.LabelSelectorOpExists,
+		},
+	}}); err != nil {
+		return err
+	}
+
 	partialSecret := &metav1.PartialObjectMetadata{}
 	partialSecret.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "",
@@ -177,32 +201,29 @@ func (r *TufReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&rhtasv1alpha1.Tuf{}).
 		Owns(&v1.Deployment{}).
 		Owns(&v12.Service{}).
+		Owns(&v12.ConfigMap{}).
 		Owns(&v13.Ingress{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		WatchesMetadata(partialSecret, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
-			val, ok := object.GetLabels()["app.kubernetes.io/instance"]
-			if ok {
-				return []reconcile.Request{
-					{
-						NamespacedName: types.NamespacedName{
-							Namespace: object.GetNamespace(),
-							Name:      val,
-						},
-					},
-				}
-			}
-
+			requests := make([]reconcile.Request, 0)
 			list := &rhtasv1alpha1.TufList{}
-			err := mgr.GetClient().List(ctx, list, client.InNamespace(object.GetNamespace()))
-			if err != nil {
-				return make([]reconcile.Request, 0)
+			if err := mgr.GetClient().List(ctx, list); err != nil {
+				return requests
 			}
 
-			requests := make([]reconcile.Request, len(list.Items))
-			for i, k := range list.Items {
-				requests[i] = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: k.Name}}
+			val, labelled := object.GetLabels()["app.kubernetes.io/instance"]
+			for _, k := range list.Items {
+				switch {
+				case k.Namespace == object.GetNamespace() && (!labelled || k.Name == val):
+				case slices.ContainsFunc(k.Spec.Keys, func(key rhtasv1alpha1.TufKey) bool { return key.Namespace == object.GetNamespace() }):
+					// the keys are discovered in the namespace of the secret
+				default:
+					continue
+				}
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: k.Namespace, Name: k.Name}})
 			}
 			return requests
 
-		}), builder.WithPredicates(predicate.Or(fulcioP, rekorP, ctlP))).
+		}), builder.WithPredicates(predicate.Or(fulcioP, rekorP, ctlP, rootP))).
 		Complete(r)
 }
@@ -159,7 +159,7 @@ var _ = Describe("TUF update test", func() {
 			deployment := &appsv1.Deployment{}
 			By("Checking if Deployment was successfully created in the reconciliation")
 			Eventually(func() error {
-				return k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: TufNamespace}, deployment)
+				return k8sClient.Get(ctx, types.NamespacedName{Name: TufName + "-" + actions.DeploymentName, Namespace: TufNamespace}, deployment)
 			}).Should(Succeed())
 
 			By("Move to Ready phase")
@@ -220,7 +220,7 @@ var _ = Describe("TUF update test", func() {
 			By("CTL deployment is updated")
 			Eventually(func(g Gomega) bool {
 				updated := &appsv1.Deployment{}
-				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: actions.DeploymentName, Namespace: TufNamespace}, updated)).To(Succeed())
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: TufName + "-" + actions.DeploymentName, Namespace: TufNamespace}, updated)).To(Succeed())
 				return equality.Semantic.DeepDerivative(deployment.Spec.Template.Spec.Volumes, updated.Spec.Template.Spec.Volumes)
 			}).Should(BeFalse())
 		})
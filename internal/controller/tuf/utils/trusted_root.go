@@ -0,0 +1,362 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// Targets describing the Sigstore services to the clients
+const (
+	TrustedRootTarget   = "trusted_root.json"
+	SigningConfigTarget = "signing_config.json"
+)
+
+const (
+	trustedRootMediaType   = "application/vnd.dev.sigstore.trustedroot+json;version=0.1"
+	signingConfigMediaType = "application/vnd.dev.sigstore.signingconfig.v0.1+json"
+)
+
+// TrustConfig are the service URLs and the inactive Rekor shards described by the trusted root and the signing config
+type TrustConfig struct {
+	FulcioURL string
+	RekorURL  string
+	OIDCURL   string
+	// RekorSharding are the inactive Rekor shards, their keys verify the existing log entries
+	RekorSharding []v1alpha1.RekorLogRange
+	// Published are the times the targets were published at keyed by the target name, they start the validity
+	// of the keys new to the trusted root
+	Published map[string]time.Time
+	// Usages are the Sigstore usages of the targets keyed by the target name
+	Usages map[string]string
+}
+
+// TrustConfigOf returns the trust configuration of the TUF repository
+func TrustConfigOf(trustedRoot *v1alpha1.TufTrustedRoot, published map[string]time.Time, usages map[string]string) TrustConfig {
+	return TrustConfig{
+		FulcioURL:     trustedRoot.FulcioURL,
+		RekorURL:      trustedRoot.RekorURL,
+		OIDCURL:       trustedRoot.OIDCURL,
+		RekorSharding: trustedRoot.RekorSharding,
+		Published:     published,
+		Usages:        usages,
+	}
+}
+
+// TrustedRoot is the Sigstore trusted root, the JSON encoding of the dev.sigstore.trustroot.v1.TrustedRoot message
+type TrustedRoot struct {
+	MediaType              string                 `json:"mediaType"`
+	Tlogs                  []TransparencyLog      `json:"tlogs"`
+	CertificateAuthorities []CertificateAuthority `json:"certificateAuthorities"`
+	Ctlogs                 []TransparencyLog      `json:"ctlogs"`
+	TimestampAuthorities   []CertificateAuthority `json:"timestampAuthorities"`
+}
+
+type TransparencyLog struct {
+	BaseURL       string    `json:"baseUrl,omitempty"`
+	HashAlgorithm string    `json:"hashAlgorithm"`
+	PublicKey     PublicKey `json:"publicKey"`
+	LogID         LogID     `json:"logId"`
+}
+
+type PublicKey struct {
+	RawBytes   string   `json:"rawBytes"`
+	KeyDetails string   `json:"keyDetails"`
+	ValidFor   ValidFor `json:"validFor"`
+}
+
+type LogID struct {
+	KeyID string `json:"keyId"`
+}
+
+type CertificateAuthority struct {
+	Subject   Subject   `json:"subject"`
+	URI       string    `json:"uri,omitempty"`
+	CertChain CertChain `json:"certChain"`
+	ValidFor  ValidFor  `json:"validFor"`
+}
+
+type Subject struct {
+	Organization string `json:"organization,omitempty"`
+	CommonName   string `json:"commonName,omitempty"`
+}
+
+type CertChain struct {
+	Certificates []RawCertificate `json:"certificates"`
+}
+
+type RawCertificate struct {
+	RawBytes string `json:"rawBytes"`
+}
+
+// ValidFor is the validity window of the key or the certificate authority, an open window has no end
+type ValidFor struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// SigningConfig is the Sigstore signing config, the JSON encoding of the dev.sigstore.trustroot.v1.SigningConfig message
+type SigningConfig struct {
+	MediaType string   `json:"mediaType"`
+	CAURL     string   `json:"caUrl,omitempty"`
+	OIDCURL   string   `json:"oidcUrl,omitempty"`
+	TlogURLs  []string `json:"tlogUrls"`
+	TSAURLs   []string `json:"tsaUrls"`
+}
+
+// TrustTargets builds the trusted root and the signing config targets from the Sigstore targets.
+// The validity windows of the previous trusted root are kept, the keys and the certificate authorities
+// which are no longer published are retired and valid until now.
+func TrustTargets(previous []byte, targets map[string][]byte, config TrustConfig, now time.Time) (map[string][]byte, error) {
+	prev := &TrustedRoot{}
+	if previous != nil {
+		if err := json.Unmarshal(previous, prev); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", TrustedRootTarget, err)
+		}
+	}
+	now = now.UTC().Truncate(time.Second)
+	b := &trustBuilder{config: config, now: now}
+
+	root := &TrustedRoot{MediaType: trustedRootMediaType}
+	var tlogs, ctlogs []TransparencyLog
+	var cas, tsas []CertificateAuthority
+	for _, name := range sortedNames(targets) {
+		var err error
+		switch usageOf(config.Usages, name) {
+		case "Fulcio":
+			cas, err = b.appendAuthority(cas, name, targets[name], config.FulcioURL)
+		case "TSA":
+			tsas, err = b.appendAuthority(tsas, name, targets[name], "")
+		case "Rekor":
+			tlogs, err = b.appendLog(tlogs, name, targets[name], config.RekorURL, false)
+		case "CTFE":
+			ctlogs, err = b.appendLog(ctlogs, name, targets[name], "", false)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %s: %w", name, err)
+		}
+	}
+	for _, shard := range config.RekorSharding {
+		if shard.EncodedPublicKey == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(shard.EncodedPublicKey, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of the Rekor shard %d: %w", shard.TreeID, err)
+		}
+		if tlogs, err = b.appendLog(tlogs, "", key, config.RekorURL, true); err != nil {
+			return nil, fmt.Errorf("invalid public key of the Rekor shard %d: %w", shard.TreeID, err)
+		}
+	}
+
+	root.Tlogs = mergeLogs(prev.Tlogs, tlogs, now)
+	root.Ctlogs = mergeLogs(prev.Ctlogs, ctlogs, now)
+	root.CertificateAuthorities = mergeAuthorities(prev.CertificateAuthorities, cas, now)
+	root.TimestampAuthorities = mergeAuthorities(prev.TimestampAuthorities, tsas, now)
+	trustedRoot, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	signingConfig := SigningConfig{
+		MediaType: signingConfigMediaType,
+		CAURL:     config.FulcioURL,
+		OIDCURL:   config.OIDCURL,
+		TlogURLs:  []string{},
+		TSAURLs:   []string{},
+	}
+	if config.RekorURL != "" {
+		signingConfig.TlogURLs = append(signingConfig.TlogURLs, config.RekorURL)
+	}
+	signing, err := json.MarshalIndent(signingConfig, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{TrustedRootTarget: trustedRoot, SigningConfigTarget: signing}, nil
+}
+
+type trustBuilder struct {
+	config TrustConfig
+	now    time.Time
+}
+
+// since returns the start of the validity of the target new to the trusted root
+func (b *trustBuilder) since(name string) time.Time {
+	if t, ok := b.config.Published[name]; ok && !t.IsZero() {
+		return t.UTC().Truncate(time.Second)
+	}
+	return b.now
+}
+
+// appendLog appends the transparency log of the PEM encoded public key, the logs sharing the key are listed once
+func (b *trustBuilder) appendLog(logs []TransparencyLog, name string, content []byte, url string, inactive bool) ([]TransparencyLog, error) {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(content)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	details, err := keyDetails(pub)
+	if err != nil {
+		return nil, err
+	}
+	id := sha256.Sum256(der)
+	log := TransparencyLog{
+		BaseURL:       url,
+		HashAlgorithm: "SHA2_256",
+		PublicKey:     PublicKey{RawBytes: base64.StdEncoding.EncodeToString(der), KeyDetails: details},
+		LogID:         LogID{KeyID: base64.StdEncoding.EncodeToString(id[:])},
+	}
+	for _, l := range logs {
+		if l.LogID == log.LogID {
+			return logs, nil
+		}
+	}
+	if inactive {
+		// the start of the validity of an inactive shard new to the trusted root is unknown
+		end := b.now
+		log.PublicKey.ValidFor = ValidFor{Start: time.Unix(0, 0).UTC(), End: &end}
+	} else {
+		log.PublicKey.ValidFor = ValidFor{Start: b.since(name)}
+	}
+	return append(logs, log), nil
+}
+
+// appendAuthority appends the certificate authority of the PEM encoded certificate chain
+func (b *trustBuilder) appendAuthority(cas []CertificateAuthority, name string, content []byte, url string) ([]CertificateAuthority, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	ca := CertificateAuthority{
+		Subject:   Subject{CommonName: certs[0].Subject.CommonName},
+		URI:       url,
+		CertChain: CertChain{Certificates: make([]RawCertificate, len(certs))},
+		ValidFor:  ValidFor{Start: certs[0].NotBefore.UTC()},
+	}
+	if len(certs[0].Subject.Organization) > 0 {
+		ca.Subject.Organization = certs[0].Subject.Organization[0]
+	}
+	for i, cert := range certs {
+		ca.CertChain.Certificates[i] = RawCertificate{RawBytes: base64.StdEncoding.EncodeToString(cert.Raw)}
+	}
+	return append(cas, ca), nil
+}
+
+// mergeLogs keeps the validity windows of the previous logs and retires the logs which are no longer listed
+func mergeLogs(previous, current []TransparencyLog, now time.Time) []TransparencyLog {
+	merged := make([]TransparencyLog, 0, len(previous)+len(current))
+	for _, log := range current {
+		for _, p := range previous {
+			if p.LogID == log.LogID {
+				log.PublicKey.ValidFor.Start = p.PublicKey.ValidFor.Start
+				if log.PublicKey.ValidFor.End != nil && p.PublicKey.ValidFor.End != nil {
+					log.PublicKey.ValidFor.End = p.PublicKey.ValidFor.End
+				}
+			}
+		}
+		merged = append(merged, log)
+	}
+	for _, p := range previous {
+		listed := false
+		for _, log := range current {
+			listed = listed || p.LogID == log.LogID
+		}
+		if !listed {
+			p.PublicKey.ValidFor = retire(p.PublicKey.ValidFor, now)
+			merged = append(merged, p)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].PublicKey.ValidFor.Start.Before(merged[j].PublicKey.ValidFor.Start)
+	})
+	return merged
+}
+
+// mergeAuthorities retires the certificate authorities which are no longer listed
+func mergeAuthorities(previous, current []CertificateAuthority, now time.Time) []CertificateAuthority {
+	merged := make([]CertificateAuthority, 0, len(previous)+len(current))
+	merged = append(merged, current...)
+	for _, p := range previous {
+		listed := false
+		for _, ca := range current {
+			listed = listed || sameChain(p.CertChain, ca.CertChain)
+		}
+		if !listed {
+			p.ValidFor = retire(p.ValidFor, now)
+			merged = append(merged, p)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].ValidFor.Start.Before(merged[j].ValidFor.Start)
+	})
+	return merged
+}
+
+func retire(validFor ValidFor, now time.Time) ValidFor {
+	if validFor.End == nil {
+		validFor.End = &now
+	}
+	return validFor
+}
+
+func sameChain(a, b CertChain) bool {
+	if len(a.Certificates) != len(b.Certificates) {
+		return false
+	}
+	for i := range a.Certificates {
+		if a.Certificates[i] != b.Certificates[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// keyDetails returns the dev.sigstore.common.v1.PublicKeyDetails of the public key
+func keyDetails(pub any) (string, error) {
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return "PKIX_ECDSA_P256_SHA_256", nil
+		case elliptic.P384():
+			return "PKIX_ECDSA_P384_SHA_384", nil
+		case elliptic.P521():
+			return "PKIX_ECDSA_P521_SHA_512", nil
+		}
+	case *rsa.PublicKey:
+		switch key.Size() * 8 {
+		case 2048, 3072, 4096:
+			return fmt.Sprintf("PKIX_RSA_PKCS1V15_%d_SHA256", key.Size()*8), nil
+		}
+	case ed25519.PublicKey:
+		return "PKIX_ED25519", nil
+	}
+	return "", fmt.Errorf("unsupported public key type %T", pub)
+}
+
+func sortedNames[T any](targets map[string]T) []string {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
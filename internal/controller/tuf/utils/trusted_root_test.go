@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+func publicKeyPEM(g Gomega) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	pem, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	g.Expect(err).ToNot(HaveOccurred())
+	return pem
+}
+
+func certificatePEM(g Gomega, notBefore time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio", Organization: []string{"Red Hat"}},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	g.Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	g.Expect(err).ToNot(HaveOccurred())
+	pem, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{cert})
+	g.Expect(err).ToNot(HaveOccurred())
+	return pem
+}
+
+func parseTrustedRoot(g Gomega, data []byte) *TrustedRoot {
+	root := &TrustedRoot{}
+	g.Expect(json.Unmarshal(data, root)).To(Succeed())
+	return root
+}
+
+func TestTrustTargets(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	published := now.Add(-time.Hour)
+	targets := map[string][]byte{
+		"fulcio_v1.crt.pem": certificatePEM(g, now.Add(-24*time.Hour)),
+		"rekor.pub":         publicKeyPEM(g),
+		"ctfe.pub":          publicKeyPEM(g),
+	}
+	config := TrustConfig{
+		FulcioURL: "https://fulcio.example.com",
+		RekorURL:  "https://rekor.example.com",
+		OIDCURL:   "https://oidc.example.com",
+		RekorSharding: []v1alpha1.RekorLogRange{
+			{TreeID: 1, TreeLength: 10, EncodedPublicKey: base64.StdEncoding.EncodeToString(publicKeyPEM(g))},
+			// the active shard shares the key of the log
+			{TreeID: 2, EncodedPublicKey: base64.StdEncoding.EncodeToString(targets["rekor.pub"])},
+		},
+		Published: map[string]time.Time{"rekor.pub": published, "ctfe.pub": published},
+	}
+
+	trust, err := TrustTargets(nil, targets, config, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	root := parseTrustedRoot(g, trust[TrustedRootTarget])
+	g.Expect(root.MediaType).To(Equal(trustedRootMediaType))
+	g.Expect(root.Tlogs).To(HaveLen(2))
+	g.Expect(root.Tlogs[0].PublicKey.ValidFor.End).To(HaveValue(Equal(now)))
+	g.Expect(root.Tlogs[1].PublicKey.ValidFor).To(Equal(ValidFor{Start: published}))
+	g.Expect(root.Tlogs[1].BaseURL).To(Equal(config.RekorURL))
+	g.Expect(root.Tlogs[1].PublicKey.KeyDetails).To(Equal("PKIX_ECDSA_P256_SHA_256"))
+	g.Expect(root.Ctlogs).To(HaveLen(1))
+	g.Expect(root.CertificateAuthorities).To(HaveLen(1))
+	g.Expect(root.CertificateAuthorities[0].URI).To(Equal(config.FulcioURL))
+	g.Expect(root.CertificateAuthorities[0].Subject).To(Equal(Subject{Organization: "Red Hat", CommonName: "fulcio"}))
+	g.Expect(root.CertificateAuthorities[0].ValidFor).To(Equal(ValidFor{Start: now.Add(-24 * time.Hour)}))
+
+	signing := &SigningConfig{}
+	g.Expect(json.Unmarshal(trust[SigningConfigTarget], signing)).To(Succeed())
+	g.Expect(*signing).To(Equal(SigningConfig{
+		MediaType: signingConfigMediaType,
+		CAURL:     config.FulcioURL,
+		OIDCURL:   config.OIDCURL,
+		TlogURLs:  []string{config.RekorURL},
+		TSAURLs:   []string{},
+	}))
+
+	t.Run("unchanged targets", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := TrustTargets(trust[TrustedRootTarget], targets, config, now.Add(time.Hour))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next).To(Equal(trust))
+	})
+
+	t.Run("rotated keys are retired", func(t *testing.T) {
+		g := NewWithT(t)
+		later := now.Add(time.Hour)
+		rotated := map[string][]byte{
+			"fulcio_v1.crt.pem": certificatePEM(g, later),
+			"rekor.pub":         publicKeyPEM(g),
+			"ctfe.pub":          targets["ctfe.pub"],
+		}
+		next, err := TrustTargets(trust[TrustedRootTarget], rotated, TrustConfig{RekorURL: config.RekorURL}, later)
+		g.Expect(err).ToNot(HaveOccurred())
+		root := parseTrustedRoot(g, next[TrustedRootTarget])
+		g.Expect(root.Tlogs).To(HaveLen(3))
+		// the shard keeps its window, the previous key is valid until the rotation
+		g.Expect(root.Tlogs[0].PublicKey.ValidFor.End).To(HaveValue(Equal(now)))
+		g.Expect(root.Tlogs[1].PublicKey.ValidFor).To(Equal(ValidFor{Start: published, End: &later}))
+		g.Expect(root.Tlogs[2].PublicKey.ValidFor).To(Equal(ValidFor{Start: later}))
+		g.Expect(root.Ctlogs).To(Equal(parseTrustedRoot(g, trust[TrustedRootTarget]).Ctlogs))
+		g.Expect(root.CertificateAuthorities).To(HaveLen(2))
+		g.Expect(root.CertificateAuthorities[0].ValidFor.End).To(HaveValue(Equal(later)))
+		g.Expect(root.CertificateAuthorities[1].ValidFor.End).To(BeNil())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func secretsVolumeProjection(keys []v1alpha1.TufKey) *core.ProjectedVolumeSource {
@@ -95,3 +96,58 @@ func CreateTufDeployment(instance *v1alpha1.Tuf, dpName string, sa string, label
 	utils.SetProxyEnvs(dep)
 	return dep
 }
+
+// HttpdConfigKey is the ConfigMap key of the httpd configuration
+const HttpdConfigKey = "tuf.conf"
+
+// HttpdConfig lets CDNs and clients cache the files of the consistent snapshots, they never change once published.
+// The unversioned metadata is revalidated on every request.
+const HttpdConfig = `<Directory "/var/www/html">
+    Header set Cache-Control "no-cache"
+    <FilesMatch "^([0-9]+\.(root|targets|snapshot)\.json|[0-9a-f]{64}\..+)$">
+        Header set Cache-Control "public, max-age=31536000, immutable"
+    </FilesMatch>
+</Directory>
+`
+
+// CreateTufRepositoryDeployment returns the deployment serving the TUF repository stored in the ConfigMap,
+// every replica serves the same content
+func CreateTufRepositoryDeployment(instance *v1alpha1.Tuf, dpName string, sa string, labels map[string]string, repository *core.ConfigMap, httpdConfig *core.ConfigMap) *apps.Deployment {
+	dep := CreateTufDeployment(instance, dpName, sa, labels)
+	dep.Spec.Replicas = ptr.To(ptr.Deref(instance.Spec.Replicas, 1))
+	dep.Spec.Template.Spec.Volumes = []core.Volume{
+		{
+			Name: "tuf-repository",
+			VolumeSource: core.VolumeSource{
+				ConfigMap: &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{Name: repository.Name},
+					Items:                Items(repository),
+				},
+			},
+		},
+		{
+			Name: "httpd-config",
+			VolumeSource: core.VolumeSource{
+				ConfigMap: &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{Name: httpdConfig.Name},
+				},
+			},
+		},
+	}
+	container := &dep.Spec.Template.Spec.Containers[0]
+	container.Image = constants.TufRepositoryImage
+	container.VolumeMounts = []core.VolumeMount{
+		{
+			Name:      "tuf-repository",
+			MountPath: "/var/www/html",
+			ReadOnly:  true,
+		},
+		{
+			Name:      "httpd-config",
+			MountPath: "/etc/httpd/conf.d/" + HttpdConfigKey,
+			SubPath:   HttpdConfigKey,
+			ReadOnly:  true,
+		},
+	}
+	return dep
+}
@@ -0,0 +1,335 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// TUF top-level roles
+const (
+	RootRole      = "root"
+	TargetsRole   = "targets"
+	SnapshotRole  = "snapshot"
+	TimestampRole = "timestamp"
+)
+
+// Roles are the top-level roles in the order of their dependency
+var Roles = []string{RootRole, TargetsRole, SnapshotRole, TimestampRole}
+
+const specVersion = "1.0"
+
+// Metadata is the signed envelope of the TUF role metadata
+type Metadata[T any] struct {
+	Signatures []Signature `json:"signatures"`
+	Signed     T           `json:"signed"`
+}
+
+type Signature struct {
+	KeyID     string `json:"keyid"`
+	Signature string `json:"sig"`
+}
+
+type Key struct {
+	Type   string   `json:"keytype"`
+	Scheme string   `json:"scheme"`
+	Value  KeyValue `json:"keyval"`
+}
+
+type KeyValue struct {
+	Public string `json:"public"`
+}
+
+type Role struct {
+	KeyIDs    []string `json:"keyids"`
+	Threshold int      `json:"threshold"`
+}
+
+// Common are the attributes shared by the metadata of all roles
+type Common struct {
+	Type        string    `json:"_type"`
+	SpecVersion string    `json:"spec_version"`
+	Version     int64     `json:"version"`
+	Expires     time.Time `json:"expires"`
+}
+
+type Root struct {
+	Common
+	ConsistentSnapshot bool            `json:"consistent_snapshot"`
+	Keys               map[string]Key  `json:"keys"`
+	Roles              map[string]Role `json:"roles"`
+}
+
+type Targets struct {
+	Common
+	Targets     map[string]TargetFile `json:"targets"`
+	Delegations *Delegations          `json:"delegations,omitempty"`
+}
+
+// Delegations are the roles the targets metadata delegates the trust of target paths to
+type Delegations struct {
+	Keys  map[string]Key  `json:"keys"`
+	Roles []DelegatedRole `json:"roles"`
+}
+
+type DelegatedRole struct {
+	Role
+	Name        string   `json:"name"`
+	Paths       []string `json:"paths,omitempty"`
+	Terminating bool     `json:"terminating"`
+}
+
+type TargetFile struct {
+	Length int64             `json:"length"`
+	Hashes map[string]string `json:"hashes"`
+	Custom *TargetCustom     `json:"custom,omitempty"`
+}
+
+// TargetCustom is the custom target metadata Sigstore clients use to select the trusted material
+type TargetCustom struct {
+	Sigstore SigstoreCustom `json:"sigstore"`
+}
+
+type SigstoreCustom struct {
+	Usage  string `json:"usage"`
+	Status string `json:"status"`
+}
+
+type Snapshot struct {
+	Common
+	Meta map[string]MetaFile `json:"meta"`
+}
+
+type Timestamp struct {
+	Common
+	Meta map[string]MetaFile `json:"meta"`
+}
+
+type MetaFile struct {
+	Version int64             `json:"version"`
+	Length  int64             `json:"length,omitempty"`
+	Hashes  map[string]string `json:"hashes,omitempty"`
+}
+
+// Signer signs the TUF metadata with a private key
+type Signer struct {
+	// ID is the TUF key ID, the hex encoded SHA-256 of the canonical JSON of the public key
+	ID  string
+	Key Key
+
+	signer crypto.Signer
+	hash   crypto.Hash
+}
+
+// NewSigner returns the signer of the PEM encoded ECDSA (P-256, P-384) or Ed25519 private key
+func NewSigner(privateKey []byte) (*Signer, error) {
+	priv, err := cryptoutils.UnmarshalPEMToPrivateKey(privateKey, cryptoutils.SkipPassword)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+
+	s := &Signer{signer: signer}
+	if s.Key, s.hash, err = tufKey(signer.Public()); err != nil {
+		return nil, err
+	}
+	if s.ID, err = keyID(s.Key); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewKey returns the TUF key of the PEM encoded ECDSA (P-256, P-384) or Ed25519 public key
+func NewKey(publicKey []byte) (Key, error) {
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(publicKey)
+	if err != nil {
+		return Key{}, err
+	}
+	key, _, err := tufKey(pub)
+	return key, err
+}
+
+// tufKey returns the TUF key of the public key and the hash function of its signature scheme
+func tufKey(public crypto.PublicKey) (Key, crypto.Hash, error) {
+	switch key := public.(type) {
+	case *ecdsa.PublicKey:
+		var (
+			scheme string
+			hash   crypto.Hash
+		)
+		switch key.Curve {
+		case elliptic.P256():
+			scheme, hash = "ecdsa-sha2-nistp256", crypto.SHA256
+		case elliptic.P384():
+			scheme, hash = "ecdsa-sha2-nistp384", crypto.SHA384
+		default:
+			return Key{}, 0, fmt.Errorf("unsupported ECDSA curve %s", key.Curve.Params().Name)
+		}
+		pem, err := cryptoutils.MarshalPublicKeyToPEM(key)
+		if err != nil {
+			return Key{}, 0, err
+		}
+		return Key{Type: "ecdsa", Scheme: scheme, Value: KeyValue{Public: string(pem)}}, hash, nil
+	case ed25519.PublicKey:
+		return Key{Type: "ed25519", Scheme: "ed25519", Value: KeyValue{Public: hex.EncodeToString(key)}}, crypto.Hash(0), nil
+	default:
+		return Key{}, 0, fmt.Errorf("unsupported public key type %T", public)
+	}
+}
+
+func (s *Signer) sign(payload []byte) (Signature, error) {
+	sig, err := s.signer.Sign(rand.Reader, digest(s.hash, payload), s.hash)
+	if err != nil {
+		return Signature{}, err
+	}
+	return Signature{KeyID: s.ID, Signature: hex.EncodeToString(sig)}, nil
+}
+
+// verify checks the hex encoded signature of the payload with the key
+func verify(key Key, payload []byte, signature string) error {
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	switch key.Type {
+	// older TUF implementations name the ECDSA key type after the scheme
+	case "ecdsa", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384":
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(key.Value.Public))
+		if err != nil {
+			return err
+		}
+		ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("unexpected public key type %T", pub)
+		}
+		_, hash, err := tufKey(ecdsaKey)
+		if err != nil {
+			return err
+		}
+		if !ecdsa.VerifyASN1(ecdsaKey, digest(hash, payload), sig) {
+			return errInvalidSignature
+		}
+	case "ed25519":
+		pub, err := hex.DecodeString(key.Value.Public)
+		if err != nil || len(pub) != ed25519.PublicKeySize {
+			return fmt.Errorf("invalid ed25519 public key")
+		}
+		if !ed25519.Verify(pub, payload, sig) {
+			return errInvalidSignature
+		}
+	default:
+		return fmt.Errorf("unsupported key type %s", key.Type)
+	}
+	return nil
+}
+
+// digest returns the digest the key signs, Ed25519 signs the message itself
+func digest(hash crypto.Hash, payload []byte) []byte {
+	switch hash {
+	case crypto.SHA256:
+		d := sha256.Sum256(payload)
+		return d[:]
+	case crypto.SHA384:
+		d := sha512.Sum384(payload)
+		return d[:]
+	default:
+		return payload
+	}
+}
+
+// GenerateSigningKey generates the PEM encoded ECDSA P-256 private key
+func GenerateSigningKey() ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return cryptoutils.MarshalPrivateKeyToPEM(key)
+}
+
+func keyID(key Key) (string, error) {
+	data, err := cjson.EncodeCanonical(key)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// signMetadata signs the canonical JSON of the signed metadata and returns the metadata file content
+func signMetadata[T any](signed T, signers ...*Signer) ([]byte, error) {
+	payload, err := cjson.EncodeCanonical(signed)
+	if err != nil {
+		return nil, err
+	}
+	metadata := Metadata[T]{Signed: signed, Signatures: make([]Signature, 0, len(signers))}
+	for _, s := range signers {
+		sig, err := s.sign(payload)
+		if err != nil {
+			return nil, err
+		}
+		metadata.Signatures = append(metadata.Signatures, sig)
+	}
+	return json.MarshalIndent(metadata, "", "  ")
+}
+
+// parseMetadata parses the metadata file, it returns nil when the file is missing
+func parseMetadata[T any](data []byte) (*Metadata[T], error) {
+	if data == nil {
+		return nil, nil
+	}
+	metadata := &Metadata[T]{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// signedBy returns true when the metadata carries the signature of the key
+func signedBy(signatures []Signature, id string) bool {
+	for _, s := range signatures {
+		if s.KeyID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// thresholdMet returns true when the signatures of the role keys reach the threshold of the role
+func thresholdMet(role Role, signatures []Signature) bool {
+	signed := 0
+	for _, id := range role.KeyIDs {
+		if signedBy(signatures, id) {
+			signed++
+		}
+	}
+	return signed >= role.Threshold
+}
+
+func newCommon(role string, version int64, expires time.Time) Common {
+	return Common{
+		Type:        role,
+		SpecVersion: specVersion,
+		Version:     version,
+		Expires:     expires.UTC().Truncate(time.Second),
+	}
+}
+
+var (
+	errMissingSigner    = errors.New("missing signing key")
+	errInvalidSignature = errors.New("invalid signature")
+)
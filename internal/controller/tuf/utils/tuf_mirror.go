@@ -0,0 +1,449 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// maxRootRotations bounds the root versions verified in one update
+	maxRootRotations = 32
+	// maxDelegations bounds the delegated targets roles mirrored
+	maxDelegations = 32
+	// maxMetadataSize bounds the download of the metadata files of unknown length, the ConfigMap holds 1MiB
+	maxMetadataSize = 1 << 20
+)
+
+var (
+	errNotFound          = errors.New("not found")
+	errThreshold         = errors.New("signature threshold not met")
+	errExpired           = errors.New("metadata expired")
+	errRollback          = errors.New("rollback to a previous version")
+	errVersionMismatch   = errors.New("version mismatch")
+	errHashMismatch      = errors.New("length or hash mismatch")
+	errUnsupportedTarget = errors.New("unsupported target name")
+)
+
+// MirrorStatus describes the verified update of a mirrored repository
+type MirrorStatus struct {
+	// Version of the timestamp metadata
+	Version int64
+	// RootVersion is the version of the root metadata
+	RootVersion int64
+}
+
+// Mirror downloads the TUF repository at the URL following the TUF client workflow. The root metadata is updated
+// version by version starting from the root of the current mirror, or from the initial root for a new mirror.
+// The timestamp, snapshot and targets metadata, the delegated targets metadata and the target files are verified
+// against the updated root. The versions must not be older than the versions of the current mirror.
+//
+// The returned repository holds the files clients fetch from the mirrored repository, the current mirror is left
+// unchanged when the verification fails.
+func Mirror(ctx context.Context, client *http.Client, repositoryURL string, current *Repository, initialRoot []byte, now time.Time) (*Repository, *MirrorStatus, error) {
+	base, err := url.Parse(repositoryURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+	m := &mirror{
+		client:  client,
+		base:    base,
+		now:     now,
+		current: current,
+		next:    &Repository{Metadata: map[string][]byte{}, Targets: map[string][]byte{}},
+	}
+
+	root, err := m.updateRoot(ctx, initialRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	timestamp, err := m.updateTimestamp(ctx, root)
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshot, err := m.updateSnapshot(ctx, root, timestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets, err := m.updateTargets(ctx, root, snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = m.downloadTargets(ctx, root, targets); err != nil {
+		return nil, nil, err
+	}
+	return m.next, &MirrorStatus{Version: timestamp.Version, RootVersion: root.Version}, nil
+}
+
+type mirror struct {
+	client        *http.Client
+	base          *url.URL
+	now           time.Time
+	current, next *Repository
+}
+
+// updateRoot verifies the root versions following the trusted root, each version is signed by the threshold of the
+// root keys of both the previous and the new version
+func (m *mirror) updateRoot(ctx context.Context, initialRoot []byte) (*Root, error) {
+	data := m.current.Metadata[metadataFile(RootRole)]
+	if data == nil {
+		data = initialRoot
+	}
+	trusted, err := parseMetadata[Root](data)
+	if err != nil || trusted == nil {
+		return nil, fmt.Errorf("invalid trusted root metadata: %w", err)
+	}
+	root, err := verifyRole[Root](data, trusted.Signed.Keys, trusted.Signed.Roles[RootRole], RootRole)
+	if err != nil {
+		return nil, err
+	}
+	// the root versions are kept, clients trusting an older root walk the chain
+	for name, content := range m.current.Metadata {
+		if strings.HasSuffix(name, "."+metadataFile(RootRole)) {
+			m.next.Metadata[name] = content
+		}
+	}
+	m.next.Metadata[versionedFile(root.Version, RootRole)] = data
+
+	for i := 0; i < maxRootRotations; i++ {
+		next, err := m.fetch(ctx, versionedFile(root.Version+1, RootRole), maxMetadataSize)
+		if errors.Is(err, errNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err = verifyRole[Root](next, root.Keys, root.Roles[RootRole], RootRole); err != nil {
+			return nil, fmt.Errorf("root version %d: %w", root.Version+1, err)
+		}
+		unverified, err := parseMetadata[Root](next)
+		if err != nil {
+			return nil, fmt.Errorf("invalid root metadata: %w", err)
+		}
+		nextRoot, err := verifyRole[Root](next, unverified.Signed.Keys, unverified.Signed.Roles[RootRole], RootRole)
+		if err != nil {
+			return nil, fmt.Errorf("root version %d: %w", root.Version+1, err)
+		}
+		if nextRoot.Version != root.Version+1 {
+			return nil, fmt.Errorf("root version %d: %w: got version %d", root.Version+1, errVersionMismatch, nextRoot.Version)
+		}
+		root, data = nextRoot, next
+		m.next.Metadata[versionedFile(root.Version, RootRole)] = data
+	}
+	if err = m.check(RootRole, RootRole, root.Common); err != nil {
+		return nil, err
+	}
+	m.next.Metadata[metadataFile(RootRole)] = data
+	return root, nil
+}
+
+// updateTimestamp verifies the timestamp metadata, it must not be older than the timestamp of the current mirror
+func (m *mirror) updateTimestamp(ctx context.Context, root *Root) (*Timestamp, error) {
+	data, err := m.fetch(ctx, metadataFile(TimestampRole), maxMetadataSize)
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := verifyRole[Timestamp](data, root.Keys, root.Roles[TimestampRole], TimestampRole)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := timestamp.Meta[metadataFile(SnapshotRole)]; !ok {
+		return nil, fmt.Errorf("timestamp metadata does not list the snapshot metadata")
+	}
+	if previous, err := parseMetadata[Timestamp](m.current.Metadata[metadataFile(TimestampRole)]); err == nil && previous != nil {
+		if timestamp.Version < previous.Signed.Version ||
+			timestamp.Meta[metadataFile(SnapshotRole)].Version < previous.Signed.Meta[metadataFile(SnapshotRole)].Version {
+			return nil, fmt.Errorf("timestamp metadata: %w", errRollback)
+		}
+	}
+	if err = m.check(TimestampRole, TimestampRole, timestamp.Common); err != nil {
+		return nil, err
+	}
+	m.next.Metadata[metadataFile(TimestampRole)] = data
+	return timestamp, nil
+}
+
+// updateSnapshot verifies the snapshot metadata listed by the timestamp metadata
+func (m *mirror) updateSnapshot(ctx context.Context, root *Root, timestamp *Timestamp) (*Snapshot, error) {
+	meta := timestamp.Meta[metadataFile(SnapshotRole)]
+	data, err := m.fetchMetadata(ctx, root, SnapshotRole, meta)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := verifyRole[Snapshot](data, root.Keys, root.Roles[SnapshotRole], SnapshotRole)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Version != meta.Version {
+		return nil, fmt.Errorf("snapshot metadata: %w: got version %d, timestamp lists version %d", errVersionMismatch, snapshot.Version, meta.Version)
+	}
+	if previous, err := parseMetadata[Snapshot](m.current.Metadata[metadataFile(SnapshotRole)]); err == nil && previous != nil {
+		for name, file := range previous.Signed.Meta {
+			if next, ok := snapshot.Meta[name]; !ok || next.Version < file.Version {
+				return nil, fmt.Errorf("snapshot metadata: %w of %s", errRollback, name)
+			}
+		}
+	}
+	if err = m.check(SnapshotRole, SnapshotRole, snapshot.Common); err != nil {
+		return nil, err
+	}
+	m.store(root, SnapshotRole, meta.Version, data)
+	return snapshot, nil
+}
+
+// delegation is a targets role to verify with the keys of the delegating role
+type delegation struct {
+	name string
+	keys map[string]Key
+	role Role
+	// trusts returns true when the role is trusted with the target
+	trusts func(string) bool
+}
+
+// updateTargets verifies the targets metadata and the metadata of the delegated roles listed by the snapshot metadata,
+// it returns the target files trusted by the roles
+func (m *mirror) updateTargets(ctx context.Context, root *Root, snapshot *Snapshot) (map[string]TargetFile, error) {
+	targets := map[string]TargetFile{}
+	queue := []delegation{{name: TargetsRole, keys: root.Keys, role: root.Roles[TargetsRole], trusts: func(string) bool { return true }}}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		if visited[d.name] {
+			continue
+		}
+		if visited[d.name] = true; len(visited) > maxDelegations+1 {
+			return nil, fmt.Errorf("more than %d delegated targets roles", maxDelegations)
+		}
+		if len(validation.IsConfigMapKey(metadataFile(d.name))) > 0 {
+			return nil, fmt.Errorf("unsupported role name %s", d.name)
+		}
+		meta, ok := snapshot.Meta[metadataFile(d.name)]
+		if !ok {
+			return nil, fmt.Errorf("snapshot metadata does not list the %s metadata", d.name)
+		}
+		data, err := m.fetchMetadata(ctx, root, d.name, meta)
+		if err != nil {
+			return nil, err
+		}
+		metadata, err := verifyRole[Targets](data, d.keys, d.role, d.name)
+		if err != nil {
+			return nil, err
+		}
+		if metadata.Version != meta.Version {
+			return nil, fmt.Errorf("%s metadata: %w: got version %d, snapshot lists version %d", d.name, errVersionMismatch, metadata.Version, meta.Version)
+		}
+		if err = m.check(d.name, TargetsRole, metadata.Common); err != nil {
+			return nil, err
+		}
+		m.store(root, d.name, meta.Version, data)
+
+		for _, name := range sortedNames(metadata.Targets) {
+			// the role delegating first is trusted with the target
+			if _, ok := targets[name]; !ok && d.trusts(name) {
+				targets[name] = metadata.Targets[name]
+			}
+		}
+		if metadata.Delegations == nil {
+			continue
+		}
+		for _, role := range metadata.Delegations.Roles {
+			parent, patterns := d.trusts, role.Paths
+			queue = append(queue, delegation{name: role.Name, keys: metadata.Delegations.Keys, role: role.Role, trusts: func(name string) bool {
+				// roles delegated by path hash prefixes are not trusted with any target
+				return parent(name) && slices.ContainsFunc(patterns, func(pattern string) bool {
+					matched, err := path.Match(pattern, name)
+					return err == nil && matched
+				})
+			}})
+		}
+	}
+	return targets, nil
+}
+
+// downloadTargets downloads and verifies the target files, the unchanged files of the current mirror are kept
+func (m *mirror) downloadTargets(ctx context.Context, root *Root, targets map[string]TargetFile) error {
+	for _, name := range sortedNames(targets) {
+		file := targets[name]
+		if path.IsAbs(name) || slices.Contains(strings.Split(name, "/"), "..") {
+			return fmt.Errorf("%w %s", errUnsupportedTarget, name)
+		}
+		if content, ok := m.current.Targets[name]; ok && checkFile(content, file.Length, file.Hashes) == nil {
+			m.next.Targets[name] = content
+			continue
+		}
+		filePath := name
+		if root.ConsistentSnapshot {
+			hash, ok := file.Hashes["sha256"]
+			if !ok {
+				hash, ok = file.Hashes["sha512"]
+			}
+			if !ok {
+				return fmt.Errorf("target %s: no supported hash", name)
+			}
+			filePath = consistentTargetPath(name, hash)
+		}
+		content, err := m.fetch(ctx, targetsDir+filePath, file.Length)
+		if err != nil {
+			return err
+		}
+		if err = checkFile(content, file.Length, file.Hashes); err != nil {
+			return fmt.Errorf("target %s: %w", name, err)
+		}
+		m.next.Targets[name] = content
+	}
+	return nil
+}
+
+// fetchMetadata downloads the metadata file of the role and checks it against the listed length and hashes
+func (m *mirror) fetchMetadata(ctx context.Context, root *Root, role string, meta MetaFile) ([]byte, error) {
+	name := metadataFile(role)
+	if root.ConsistentSnapshot {
+		name = versionedFile(meta.Version, role)
+	}
+	maxLength := meta.Length
+	if maxLength == 0 {
+		maxLength = maxMetadataSize
+	}
+	data, err := m.fetch(ctx, name, maxLength)
+	if err != nil {
+		return nil, err
+	}
+	if meta.Length > 0 && int64(len(data)) != meta.Length {
+		return nil, fmt.Errorf("%s: %w", name, errHashMismatch)
+	}
+	if len(meta.Hashes) > 0 {
+		if err = checkFile(data, int64(len(data)), meta.Hashes); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return data, nil
+}
+
+// store adds the metadata file of the role to the mirror, the versioned file for consistent snapshots
+func (m *mirror) store(root *Root, role string, version int64, data []byte) {
+	m.next.Metadata[metadataFile(role)] = data
+	if root.ConsistentSnapshot {
+		m.next.Metadata[versionedFile(version, role)] = data
+	}
+}
+
+// check checks the type and the expiry of the role metadata
+func (m *mirror) check(role string, metadataType string, common Common) error {
+	if common.Type != metadataType {
+		return fmt.Errorf("%s metadata: unexpected type %s", role, common.Type)
+	}
+	if !m.now.Before(common.Expires) {
+		return fmt.Errorf("%s metadata: %w at %s", role, errExpired, common.Expires.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// fetch downloads the file of the repository, it fails for files exceeding the maximum length
+func (m *mirror) fetch(ctx context.Context, name string, maxLength int64) ([]byte, error) {
+	fileURL := m.base.JoinPath(strings.Split(name, "/")...).String()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		// object stores deny the access to missing files
+		return nil, fmt.Errorf("could not download %s: %w", name, errNotFound)
+	default:
+		return nil, fmt.Errorf("could not download %s: %s", name, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLength+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s: %w", name, err)
+	}
+	if int64(len(data)) > maxLength {
+		return nil, fmt.Errorf("could not download %s: exceeds %d bytes", name, maxLength)
+	}
+	return data, nil
+}
+
+// verifyRole verifies the metadata file is signed by the threshold of the role keys and returns the signed metadata
+func verifyRole[T any](data []byte, keys map[string]Key, role Role, name string) (*T, error) {
+	metadata, err := parseMetadata[json.RawMessage](data)
+	if err != nil || metadata == nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", name, err)
+	}
+	var signed any
+	decoder := json.NewDecoder(bytes.NewReader(metadata.Signed))
+	decoder.UseNumber()
+	if err = decoder.Decode(&signed); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", name, err)
+	}
+	payload, err := cjson.EncodeCanonical(signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", name, err)
+	}
+	valid := make([]Signature, 0, len(metadata.Signatures))
+	for _, sig := range metadata.Signatures {
+		key, ok := keys[sig.KeyID]
+		if !ok || !slices.Contains(role.KeyIDs, sig.KeyID) || signedBy(valid, sig.KeyID) || verify(key, payload, sig.Signature) != nil {
+			continue
+		}
+		valid = append(valid, sig)
+	}
+	if role.Threshold < 1 || !thresholdMet(role, valid) {
+		return nil, fmt.Errorf("%s metadata: %w, %d of %d valid signatures", name, errThreshold, len(valid), role.Threshold)
+	}
+
+	result := new(T)
+	if err = json.Unmarshal(metadata.Signed, result); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", name, err)
+	}
+	return result, nil
+}
+
+// checkFile checks the length and the known hashes of the file
+func checkFile(content []byte, length int64, fileHashes map[string]string) error {
+	if int64(len(content)) != length {
+		return errHashMismatch
+	}
+	checked := 0
+	for algorithm, expected := range fileHashes {
+		var digest []byte
+		switch algorithm {
+		case "sha256":
+			d := sha256.Sum256(content)
+			digest = d[:]
+		case "sha512":
+			d := sha512.Sum512(content)
+			digest = d[:]
+		default:
+			continue
+		}
+		if hex.EncodeToString(digest) != expected {
+			return errHashMismatch
+		}
+		checked++
+	}
+	if checked == 0 {
+		return fmt.Errorf("no supported hash")
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	core "k8s.io/api/core/v1"
+)
+
+// files returns the files of the repository by the path they are served at
+func files(repo *Repository) map[string][]byte {
+	cm := &core.ConfigMap{}
+	repo.Write(cm)
+	served := map[string][]byte{}
+	for _, item := range Items(cm) {
+		if content, ok := cm.Data[item.Key]; ok {
+			served["/"+item.Path] = []byte(content)
+		} else {
+			served["/"+item.Path] = cm.BinaryData[item.Key]
+		}
+	}
+	return served
+}
+
+func serve(t *testing.T, served map[string][]byte) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := served[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMirror(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signers := newSigners(g)
+	targets := map[string][]byte{"rekor.pub": []byte("rekor"), "registry.npmjs.org/keys.json": []byte("{}")}
+	upstream, err := Update(ReadRepository(nil), signers, targets, UpdateOptions{Now: now})
+	g.Expect(err).ToNot(HaveOccurred())
+	initialRoot := upstream.Metadata["1.root.json"]
+
+	served := files(upstream)
+	server := serve(t, served)
+
+	mirrored, status, err := Mirror(ctx, server.Client(), server.URL, ReadRepository(nil), initialRoot, now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status).To(Equal(&MirrorStatus{Version: 1, RootVersion: 1}))
+	g.Expect(mirrored.Targets).To(Equal(targets))
+	g.Expect(files(mirrored)).To(Equal(served))
+
+	t.Run("root rotation", func(t *testing.T) {
+		g := NewWithT(t)
+		rotated := newSigners(g)
+		rotated[RootRole], rotated[TargetsRole], rotated[SnapshotRole] = signers[RootRole], signers[TargetsRole], signers[SnapshotRole]
+		next, err := Update(upstream, rotated, targets, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		server := serve(t, files(next))
+
+		updated, status, err := Mirror(ctx, server.Client(), server.URL, mirrored, initialRoot, now)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status).To(Equal(&MirrorStatus{Version: 2, RootVersion: 2}))
+		g.Expect(updated.Metadata).To(HaveKey("1.root.json"))
+		g.Expect(updated.Metadata["root.json"]).To(Equal(next.Metadata["2.root.json"]))
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		g := NewWithT(t)
+		other, err := Update(ReadRepository(nil), newSigners(g), targets, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		server := serve(t, files(other))
+
+		_, _, err = Mirror(ctx, server.Client(), server.URL, mirrored, initialRoot, now)
+		g.Expect(err).To(MatchError(errThreshold))
+	})
+
+	t.Run("tampered target", func(t *testing.T) {
+		g := NewWithT(t)
+		tampered := files(upstream)
+		for path := range tampered {
+			if strings.HasSuffix(path, "rekor.pub") {
+				tampered[path] = []byte("Rekor")
+			}
+		}
+		server := serve(t, tampered)
+
+		_, _, err := Mirror(ctx, server.Client(), server.URL, ReadRepository(nil), initialRoot, now)
+		g.Expect(err).To(MatchError(errHashMismatch))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(upstream, signers, targets, UpdateOptions{Now: now.Add(13 * time.Hour)})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		_, _, err = Mirror(ctx, server.Client(), server.URL, next, initialRoot, now)
+		g.Expect(err).To(MatchError(errRollback))
+	})
+
+	t.Run("expired metadata", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := Mirror(ctx, server.Client(), server.URL, mirrored, initialRoot, now.Add(25*time.Hour))
+		g.Expect(err).To(MatchError(errExpired))
+	})
+
+	t.Run("unavailable repository", func(t *testing.T) {
+		g := NewWithT(t)
+		_, _, err := Mirror(ctx, server.Client(), server.URL+"/missing", mirrored, initialRoot, now)
+		g.Expect(err).To(MatchError(errNotFound))
+	})
+}
+
+func TestMirror_delegations(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	signers := newSigners(g)
+	delegate := newSigners(g)[TargetsRole]
+
+	root := Root{Common: newCommon(RootRole, 1, expires), Keys: map[string]Key{}, Roles: map[string]Role{}}
+	for _, role := range Roles {
+		root.Keys[signers[role].ID] = signers[role].Key
+		root.Roles[role] = Role{KeyIDs: []string{signers[role].ID}, Threshold: 1}
+	}
+	npm := Targets{Common: newCommon(TargetsRole, 1, expires), Targets: map[string]TargetFile{
+		"npm/keys.json": targetFile([]byte("npm"), ""),
+		// outside of the delegated paths
+		"rekor.pub": targetFile([]byte("attack"), ""),
+	}}
+	top := Targets{Common: newCommon(TargetsRole, 1, expires), Targets: map[string]TargetFile{}, Delegations: &Delegations{
+		Keys:  map[string]Key{delegate.ID: delegate.Key},
+		Roles: []DelegatedRole{{Name: "npm", Role: Role{KeyIDs: []string{delegate.ID}, Threshold: 1}, Paths: []string{"npm/*"}}},
+	}}
+	snapshot := Snapshot{Common: newCommon(SnapshotRole, 1, expires), Meta: map[string]MetaFile{"targets.json": {Version: 1}, "npm.json": {Version: 1}}}
+
+	served := map[string][]byte{"/targets/npm/keys.json": []byte("npm")}
+	for name, metadata := range map[string]struct {
+		signed any
+		signer *Signer
+	}{
+		"/1.root.json":   {root, signers[RootRole]},
+		"/targets.json":  {top, signers[TargetsRole]},
+		"/npm.json":      {npm, delegate},
+		"/snapshot.json": {snapshot, signers[SnapshotRole]},
+	} {
+		data, err := signMetadata(metadata.signed, metadata.signer)
+		g.Expect(err).ToNot(HaveOccurred())
+		served[name] = data
+	}
+	timestamp, err := signMetadata(Timestamp{Common: newCommon(TimestampRole, 1, expires), Meta: map[string]MetaFile{
+		"snapshot.json": {Version: 1, Length: int64(len(served["/snapshot.json"])), Hashes: hashes(served["/snapshot.json"])},
+	}}, signers[TimestampRole])
+	g.Expect(err).ToNot(HaveOccurred())
+	served["/timestamp.json"] = timestamp
+	server := serve(t, served)
+
+	mirrored, _, err := Mirror(ctx, server.Client(), server.URL, ReadRepository(nil), served["/1.root.json"], now)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(mirrored.Targets).To(Equal(map[string][]byte{"npm/keys.json": []byte("npm")}))
+	g.Expect(mirrored.Metadata).To(HaveKey("npm.json"))
+}
@@ -0,0 +1,670 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/securesign/operator/api/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// targetKeyPrefix separates the targets from the metadata in the repository ConfigMap
+	targetKeyPrefix = "targets_"
+	// targetPathKeyPrefix stores the targets whose names are no valid ConfigMap keys, the name is base64url encoded
+	targetPathKeyPrefix = "targetpath_"
+	targetsDir          = "targets/"
+	// previousKeyPrefix stores the previous content of the changed targets, it is served under its hashes only
+	previousKeyPrefix = "previous_"
+
+	// the pending root is stored in the repository ConfigMap, but it is not served
+	pendingKeyPrefix  = "pending_"
+	PendingRootKey    = pendingKeyPrefix + "root.json"
+	PendingPayloadKey = pendingKeyPrefix + "root.payload"
+)
+
+// usageOf returns the Sigstore usage of the target, the usage of the well-known target by default
+func usageOf(usages map[string]string, name string) string {
+	if usage := usages[name]; usage != "" {
+		return usage
+	}
+	return v1alpha1.TargetUsage(name)
+}
+
+// Expirations are the validity periods of the metadata of the top-level roles
+type Expirations map[string]time.Duration
+
+// DefaultExpirations match the defaults of the TufExpiration API
+var DefaultExpirations = Expirations{
+	RootRole:      365 * 24 * time.Hour,
+	TargetsRole:   365 * 24 * time.Hour,
+	SnapshotRole:  7 * 24 * time.Hour,
+	TimestampRole: 24 * time.Hour,
+}
+
+// OnlineRoles are re-signed by the operator before their metadata expires
+var OnlineRoles = []string{SnapshotRole, TimestampRole}
+
+// ExpirationsOf returns the configured expirations, the defaults for the unset ones
+func ExpirationsOf(repository *v1alpha1.TufRepository) Expirations {
+	expirations := maps.Clone(DefaultExpirations)
+	if repository == nil {
+		return expirations
+	}
+	for role, d := range map[string]metav1.Duration{
+		RootRole:      repository.Expiration.Root,
+		TargetsRole:   repository.Expiration.Targets,
+		SnapshotRole:  repository.Expiration.Snapshot,
+		TimestampRole: repository.Expiration.Timestamp,
+	} {
+		if d.Duration > 0 {
+			expirations[role] = d.Duration
+		}
+	}
+	return expirations
+}
+
+// RefreshAt returns the time the online role metadata is re-signed at, half of its validity period before it expires
+func RefreshAt(expires time.Time, expiration time.Duration) time.Time {
+	return expires.Add(-expiration / 2)
+}
+
+// ExpiresSoon returns true when less than a third of the validity period of the metadata is left
+func ExpiresSoon(expires time.Time, expiration time.Duration, now time.Time) bool {
+	return expires.Sub(now) < expiration/3
+}
+
+// UpdateOptions control the signing of the repository metadata
+type UpdateOptions struct {
+	Now         time.Time
+	Expirations Expirations
+	// Resign are the roles whose metadata is re-signed regardless of its state
+	Resign []string
+	// Root configures the root role signed with offline keys, the root signer signs the root if it is unset
+	Root *RootOptions
+	// RootSignatures are the detached signatures of the pending root metadata
+	RootSignatures []Signature
+	// Usages are the Sigstore usages of the targets keyed by the target name
+	Usages map[string]string
+}
+
+// RootOptions configure the root role whose metadata is signed with offline keys
+type RootOptions struct {
+	// Keys of the root role
+	Keys []Key
+	// Threshold is the number of root key signatures required to trust the root metadata
+	Threshold int
+}
+
+// RoleSigners are the signers of the top-level roles
+type RoleSigners map[string]*Signer
+
+// LoadSigners parses the PEM encoded private keys of the top-level roles keyed by the role name.
+// The root key is optional, the root metadata can be signed offline.
+func LoadSigners(keys map[string][]byte) (RoleSigners, error) {
+	signers := make(RoleSigners, len(Roles))
+	for _, role := range Roles {
+		key, ok := keys[role]
+		if !ok && role == RootRole {
+			continue
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w for role %s", errMissingSigner, role)
+		}
+		s, err := NewSigner(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s signing key: %w", role, err)
+		}
+		signers[role] = s
+	}
+	return signers, nil
+}
+
+// GenerateSigningKeys generates a private key for each top-level role
+func GenerateSigningKeys() (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(Roles))
+	for _, role := range Roles {
+		key, err := GenerateSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		keys[role] = key
+	}
+	return keys, nil
+}
+
+// Repository is the content of the TUF repository
+type Repository struct {
+	// Metadata files keyed by the file name
+	Metadata map[string][]byte
+	// Target files keyed by the target name
+	Targets map[string][]byte
+	// PreviousTargets are the previous content of the targets changed by the last update, keyed by the target name.
+	// The clients in the middle of an update still fetch them by the hashes of the previous targets metadata.
+	PreviousTargets map[string][]byte
+	// PendingRoot is the next root metadata waiting for the signatures of the root keys
+	PendingRoot []byte
+	// PendingPayload is the canonical JSON of the signed part of the pending root, the content the root keys sign
+	PendingPayload []byte
+}
+
+// ReadRepository reads the repository stored in the ConfigMap
+func ReadRepository(cm *core.ConfigMap) *Repository {
+	repo := &Repository{Metadata: map[string][]byte{}, Targets: map[string][]byte{}, PreviousTargets: map[string][]byte{}}
+	if cm == nil {
+		return repo
+	}
+	for name, content := range cm.Data {
+		switch name {
+		case PendingRootKey:
+			repo.PendingRoot = []byte(content)
+		case PendingPayloadKey:
+			repo.PendingPayload = []byte(content)
+		default:
+			repo.Metadata[name] = []byte(content)
+		}
+	}
+	for key, content := range cm.BinaryData {
+		if previous, ok := strings.CutPrefix(key, previousKeyPrefix); ok {
+			if name, ok := targetName(previous); ok {
+				repo.PreviousTargets[name] = content
+			}
+		} else if name, ok := targetName(key); ok {
+			repo.Targets[name] = content
+		}
+	}
+	return repo
+}
+
+// Write stores the repository to the ConfigMap, the metadata as data and the targets as binary data
+func (r *Repository) Write(cm *core.ConfigMap) {
+	cm.Data = make(map[string]string, len(r.Metadata))
+	for name, content := range r.Metadata {
+		cm.Data[name] = string(content)
+	}
+	if r.PendingRoot != nil {
+		cm.Data[PendingRootKey] = string(r.PendingRoot)
+		cm.Data[PendingPayloadKey] = string(r.PendingPayload)
+	}
+	cm.BinaryData = make(map[string][]byte, len(r.Targets)+len(r.PreviousTargets))
+	for name, content := range r.Targets {
+		cm.BinaryData[targetKey(name)] = content
+	}
+	for name, content := range r.PreviousTargets {
+		cm.BinaryData[previousKeyPrefix+targetKey(name)] = content
+	}
+}
+
+// Items maps the ConfigMap keys of the stored repository to the paths the repository is served at
+func Items(cm *core.ConfigMap) []core.KeyToPath {
+	items := make([]core.KeyToPath, 0, len(cm.Data)+3*len(cm.BinaryData))
+	for key := range cm.Data {
+		if !strings.HasPrefix(key, pendingKeyPrefix) {
+			items = append(items, core.KeyToPath{Key: key, Path: key})
+		}
+	}
+	for key, content := range cm.BinaryData {
+		previous, isPrevious := strings.CutPrefix(key, previousKeyPrefix)
+		if !isPrevious {
+			previous = key
+		}
+		name, ok := targetName(previous)
+		if !ok {
+			continue
+		}
+		if !isPrevious {
+			items = append(items, core.KeyToPath{Key: key, Path: targetsDir + name})
+		}
+		// consistent snapshot clients fetch the targets prefixed with any of their hashes
+		sha256Digest, sha512Digest := sha256.Sum256(content), sha512.Sum512(content)
+		for _, hash := range [][]byte{sha256Digest[:], sha512Digest[:]} {
+			items = append(items, core.KeyToPath{Key: key, Path: targetsDir + consistentTargetPath(name, hex.EncodeToString(hash))})
+		}
+	}
+	// stable order keeps the deployment unchanged
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+	return items
+}
+
+// Update signs a new version of the metadata of every role whose content or signing key changed
+// and of the online roles due to refresh. Metadata that is still up-to-date is kept,
+// the update of an up-to-date repository is a no-op.
+//
+// A changed root is published once it is signed by the threshold of the keys of both the new and the previous root.
+// Until then it is kept as the pending root collecting the signatures and the other roles are signed with the keys
+// of the published root.
+func Update(current *Repository, signers RoleSigners, targets map[string][]byte, opts UpdateOptions) (*Repository, error) {
+	for _, role := range Roles {
+		if signers[role] == nil && (role != RootRole || opts.Root == nil) {
+			return nil, fmt.Errorf("%w for role %s", errMissingSigner, role)
+		}
+	}
+	u := &updater{
+		current: current,
+		next:    &Repository{Metadata: maps.Clone(current.Metadata), Targets: maps.Clone(targets), PreviousTargets: previousTargets(current, targets)},
+		signers: signers,
+		opts:    opts,
+	}
+	if u.opts.Expirations == nil {
+		u.opts.Expirations = DefaultExpirations
+	}
+	if u.next.Metadata == nil {
+		u.next.Metadata = map[string][]byte{}
+	}
+
+	desiredRoot, err := u.desiredRoot()
+	if err != nil {
+		return nil, err
+	}
+	if err = u.updateRoot(desiredRoot); err != nil {
+		return nil, err
+	}
+	root, err := parseMetadata[Root](u.next.Metadata[metadataFile(RootRole)])
+	if err != nil {
+		return nil, fmt.Errorf("invalid root metadata: %w", err)
+	}
+	if root == nil {
+		// nothing is published until the first root is signed
+		return u.next, nil
+	}
+	u.root = &root.Signed
+
+	targetsMetadata, err := parseMetadata[Targets](current.Metadata[metadataFile(TargetsRole)])
+	if err != nil {
+		return nil, fmt.Errorf("invalid targets metadata: %w", err)
+	}
+	desiredTargets := Targets{Targets: make(map[string]TargetFile, len(targets))}
+	for name, content := range targets {
+		desiredTargets.Targets[name] = targetFile(content, usageOf(opts.Usages, name))
+	}
+	targetsChanged := u.trusted(TargetsRole) && (targetsMetadata == nil || u.stale(TargetsRole) ||
+		!reflect.DeepEqual(targetsMetadata.Signed.Targets, desiredTargets.Targets))
+	if targetsChanged {
+		if err = u.sign(TargetsRole, func(c Common) any {
+			desiredTargets.Common = c
+			return desiredTargets
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	snapshotChanged := u.trusted(SnapshotRole) && (targetsChanged || u.stale(SnapshotRole))
+	if snapshotChanged {
+		targetsVersion, err := versionOf(u.next.Metadata[metadataFile(TargetsRole)])
+		if err != nil {
+			return nil, err
+		}
+		if err = u.sign(SnapshotRole, func(c Common) any {
+			return Snapshot{Common: c, Meta: map[string]MetaFile{metadataFile(TargetsRole): {Version: targetsVersion}}}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if u.trusted(TimestampRole) && (snapshotChanged || u.stale(TimestampRole)) {
+		snapshotData := u.next.Metadata[metadataFile(SnapshotRole)]
+		snapshotVersion, err := versionOf(snapshotData)
+		if err != nil {
+			return nil, err
+		}
+		if err = u.sign(TimestampRole, func(c Common) any {
+			return Timestamp{Common: c, Meta: map[string]MetaFile{metadataFile(SnapshotRole): {
+				Version: snapshotVersion,
+				Length:  int64(len(snapshotData)),
+				Hashes:  hashes(snapshotData),
+			}}}
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return u.next, nil
+}
+
+// previousTargets returns the previous content of the targets changed by the update. Like the versioned metadata,
+// only the version before the last change of a target is kept. A removed target is kept for one more update.
+func previousTargets(current *Repository, targets map[string][]byte) map[string][]byte {
+	previous := make(map[string][]byte, len(current.PreviousTargets))
+	for name, content := range current.PreviousTargets {
+		_, exists := targets[name]
+		_, existed := current.Targets[name]
+		if exists || existed {
+			previous[name] = content
+		}
+	}
+	for name, content := range current.Targets {
+		if next, ok := targets[name]; !ok || !bytes.Equal(content, next) {
+			previous[name] = content
+		}
+	}
+	return previous
+}
+
+type updater struct {
+	current, next *Repository
+	signers       RoleSigners
+	opts          UpdateOptions
+	// root is the published root the metadata of the other roles is signed for
+	root *Root
+}
+
+// desiredRoot returns the root with the keys of the signers and the root keys. Consistent snapshots are enabled,
+// an existing root without them keeps them disabled until the root changes, see updateRoot.
+func (u *updater) desiredRoot() (Root, error) {
+	// consistent snapshots keep every file a client fetches immutable, so it can be cached
+	root := Root{ConsistentSnapshot: true, Keys: map[string]Key{}, Roles: map[string]Role{}}
+	for _, role := range Roles[1:] {
+		s := u.signers[role]
+		root.Keys[s.ID] = s.Key
+		root.Roles[role] = Role{KeyIDs: []string{s.ID}, Threshold: 1}
+	}
+	if u.opts.Root == nil {
+		s := u.signers[RootRole]
+		root.Keys[s.ID] = s.Key
+		root.Roles[RootRole] = Role{KeyIDs: []string{s.ID}, Threshold: 1}
+		return root, nil
+	}
+
+	role := Role{KeyIDs: make([]string, 0, len(u.opts.Root.Keys)), Threshold: u.opts.Root.Threshold}
+	for _, key := range u.opts.Root.Keys {
+		id, err := keyID(key)
+		if err != nil {
+			return Root{}, err
+		}
+		if slices.Contains(role.KeyIDs, id) {
+			continue
+		}
+		root.Keys[id] = key
+		role.KeyIDs = append(role.KeyIDs, id)
+	}
+	sort.Strings(role.KeyIDs)
+	if role.Threshold < 1 || role.Threshold > len(role.KeyIDs) {
+		return Root{}, fmt.Errorf("root threshold %d out of range for %d root keys", role.Threshold, len(role.KeyIDs))
+	}
+	root.Roles[RootRole] = role
+	return root, nil
+}
+
+// updateRoot publishes the desired root once the root keys of the previous and the desired root signed it,
+// it keeps the root pending and collects the signatures otherwise
+func (u *updater) updateRoot(desired Root) error {
+	published, err := parseMetadata[Root](u.current.Metadata[metadataFile(RootRole)])
+	if err != nil {
+		return fmt.Errorf("invalid root metadata: %w", err)
+	}
+	pending, err := parseMetadata[Root](u.current.PendingRoot)
+	if err != nil {
+		return fmt.Errorf("invalid pending root metadata: %w", err)
+	}
+	version := int64(1)
+	if published != nil {
+		version = published.Signed.Version + 1
+	}
+	if published != nil && !published.Signed.ConsistentSnapshot && !slices.Contains(u.opts.Resign, RootRole) {
+		// enabling consistent snapshots alone would sign a new root version, that waits for the signatures of
+		// offline root keys. They are enabled with the next change of the root keys or a requested re-signing.
+		unchanged := desired
+		unchanged.ConsistentSnapshot = false
+		if sameRoot(published.Signed, unchanged) {
+			desired = unchanged
+		}
+	}
+
+	switch {
+	case pending != nil && pending.Signed.Version == version && sameRoot(pending.Signed, desired):
+		// keep collecting the signatures of the pending root
+	case published == nil || !sameRoot(published.Signed, desired) || slices.Contains(u.opts.Resign, RootRole):
+		desired.Common = newCommon(RootRole, version, u.opts.Now.Add(u.opts.Expirations[RootRole]))
+		pending = &Metadata[Root]{Signed: desired, Signatures: []Signature{}}
+	default:
+		// the published root is up-to-date
+		return nil
+	}
+
+	payload, err := cjson.EncodeCanonical(pending.Signed)
+	if err != nil {
+		return err
+	}
+	rootKeys := rootKeys(pending, published)
+	candidates := append(slices.Clone(pending.Signatures), u.opts.RootSignatures...)
+	if s := u.signers[RootRole]; s != nil && !signedBy(candidates, s.ID) {
+		if _, ok := rootKeys[s.ID]; ok {
+			sig, err := s.sign(payload)
+			if err != nil {
+				return fmt.Errorf("could not sign root metadata: %w", err)
+			}
+			candidates = append(candidates, sig)
+		}
+	}
+	pending.Signatures = make([]Signature, 0, len(candidates))
+	for _, sig := range candidates {
+		key, ok := rootKeys[sig.KeyID]
+		if !ok || signedBy(pending.Signatures, sig.KeyID) || verify(key, payload, sig.Signature) != nil {
+			continue
+		}
+		pending.Signatures = append(pending.Signatures, sig)
+	}
+	sort.Slice(pending.Signatures, func(i, j int) bool { return pending.Signatures[i].KeyID < pending.Signatures[j].KeyID })
+
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return err
+	}
+	if !thresholdMet(pending.Signed.Roles[RootRole], pending.Signatures) ||
+		(published != nil && !thresholdMet(published.Signed.Roles[RootRole], pending.Signatures)) {
+		u.next.PendingRoot, u.next.PendingPayload = data, payload
+		return nil
+	}
+	u.next.Metadata[metadataFile(RootRole)] = data
+	// clients walk the chain of the versioned root files
+	u.next.Metadata[versionedFile(version, RootRole)] = data
+	return nil
+}
+
+// trusted returns true when the published root trusts the signer of the role
+func (u *updater) trusted(role string) bool {
+	return slices.Contains(u.root.Roles[role].KeyIDs, u.signers[role].ID)
+}
+
+// stale returns true when the metadata of the role is missing, unreadable, not signed by the current key of the role,
+// due to refresh or requested to be re-signed
+func (u *updater) stale(role string) bool {
+	metadata, err := parseMetadata[Common](u.current.Metadata[metadataFile(role)])
+	if err != nil || metadata == nil || !signedBy(metadata.Signatures, u.signers[role].ID) || slices.Contains(u.opts.Resign, role) {
+		return true
+	}
+	if u.root.ConsistentSnapshot && role != TimestampRole && u.current.Metadata[versionedFile(metadata.Signed.Version, role)] == nil {
+		// the version was signed before consistent snapshots were enabled
+		return true
+	}
+	if slices.Contains(OnlineRoles, role) {
+		expiration := u.opts.Expirations[role]
+		// a shortened expiration applies immediately
+		return !u.opts.Now.Before(RefreshAt(metadata.Signed.Expires, expiration)) ||
+			metadata.Signed.Expires.After(u.opts.Now.Add(expiration))
+	}
+	return false
+}
+
+// sign signs the next version of the role metadata, signed returns the metadata for the common attributes
+func (u *updater) sign(role string, signed func(Common) any) error {
+	version, err := nextVersion(u.current.Metadata[metadataFile(role)])
+	if err != nil {
+		return err
+	}
+	data, err := signMetadata(signed(newCommon(role, version, u.opts.Now.Add(u.opts.Expirations[role]))), u.signers[role])
+	if err != nil {
+		return fmt.Errorf("could not sign %s metadata: %w", role, err)
+	}
+	u.next.Metadata[metadataFile(role)] = data
+	if u.root.ConsistentSnapshot && role != TimestampRole {
+		u.next.Metadata[versionedFile(version, role)] = data
+		u.prune(role, version-1)
+	}
+	return nil
+}
+
+// prune removes the versioned metadata files of the role older than the version,
+// the previous version stays available to the clients in the middle of an update
+func (u *updater) prune(role string, version int64) {
+	for name := range u.next.Metadata {
+		prefix, file, ok := strings.Cut(name, ".")
+		if !ok || file != metadataFile(role) {
+			continue
+		}
+		if v, err := strconv.ParseInt(prefix, 10, 64); err == nil && v < version {
+			delete(u.next.Metadata, name)
+		}
+	}
+}
+
+// RoleStatus returns the version, the expiry and the signing keys of each top-level role
+func (r *Repository) RoleStatus() ([]v1alpha1.TufRoleStatus, error) {
+	status := make([]v1alpha1.TufRoleStatus, 0, len(Roles))
+	for _, role := range Roles {
+		metadata, err := parseMetadata[Common](r.Metadata[metadataFile(role)])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %w", role, err)
+		}
+		if metadata == nil {
+			continue
+		}
+		keyIDs := make([]string, len(metadata.Signatures))
+		for i, s := range metadata.Signatures {
+			keyIDs[i] = s.KeyID
+		}
+		status = append(status, v1alpha1.TufRoleStatus{
+			Name:    role,
+			Version: metadata.Signed.Version,
+			Expires: metav1.NewTime(metadata.Signed.Expires),
+			KeyIDs:  keyIDs,
+		})
+	}
+	return status, nil
+}
+
+// PendingRootStatus returns the status of the root waiting for signatures, nil when no root is pending
+func (r *Repository) PendingRootStatus() (*v1alpha1.TufPendingRootStatus, error) {
+	pending, err := parseMetadata[Root](r.PendingRoot)
+	if err != nil || pending == nil {
+		return nil, err
+	}
+	role := pending.Signed.Roles[RootRole]
+	status := &v1alpha1.TufPendingRootStatus{
+		Version:      pending.Signed.Version,
+		KeyIDs:       role.KeyIDs,
+		Threshold:    role.Threshold,
+		SignedKeyIDs: make([]string, len(pending.Signatures)),
+	}
+	for i, s := range pending.Signatures {
+		status.SignedKeyIDs[i] = s.KeyID
+	}
+	published, err := parseMetadata[Root](r.Metadata[metadataFile(RootRole)])
+	if err != nil {
+		return nil, err
+	}
+	if published != nil {
+		status.PreviousKeyIDs = published.Signed.Roles[RootRole].KeyIDs
+		status.PreviousThreshold = published.Signed.Roles[RootRole].Threshold
+	}
+	return status, nil
+}
+
+// rootKeys returns the keys of the root role of the pending and the published root
+func rootKeys(roots ...*Metadata[Root]) map[string]Key {
+	keys := map[string]Key{}
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+		for _, id := range root.Signed.Roles[RootRole].KeyIDs {
+			keys[id] = root.Signed.Keys[id]
+		}
+	}
+	return keys
+}
+
+// sameRoot returns true when the roots define the same keys and roles
+func sameRoot(a, b Root) bool {
+	return a.ConsistentSnapshot == b.ConsistentSnapshot && reflect.DeepEqual(a.Keys, b.Keys) && reflect.DeepEqual(a.Roles, b.Roles)
+}
+
+func metadataFile(role string) string {
+	return role + ".json"
+}
+
+// versionedFile returns the name of the metadata file of the role version
+func versionedFile(version int64, role string) string {
+	return fmt.Sprintf("%d.%s", version, metadataFile(role))
+}
+
+// nextVersion returns the version following the version of the metadata file, 1 for a missing file
+func nextVersion(data []byte) (int64, error) {
+	if data == nil {
+		return 1, nil
+	}
+	version, err := versionOf(data)
+	return version + 1, err
+}
+
+func versionOf(data []byte) (int64, error) {
+	metadata, err := parseMetadata[Common](data)
+	if err != nil || metadata == nil {
+		return 0, fmt.Errorf("could not read metadata version: %w", err)
+	}
+	return metadata.Signed.Version, nil
+}
+
+// targetKey returns the ConfigMap key of the target
+func targetKey(name string) string {
+	if len(validation.IsConfigMapKey(name)) == 0 {
+		return targetKeyPrefix + name
+	}
+	return targetPathKeyPrefix + base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// targetName returns the name of the target stored at the ConfigMap key
+func targetName(key string) (string, bool) {
+	if name, ok := strings.CutPrefix(key, targetKeyPrefix); ok {
+		return name, true
+	}
+	if encoded, ok := strings.CutPrefix(key, targetPathKeyPrefix); ok {
+		name, err := base64.RawURLEncoding.DecodeString(encoded)
+		return string(name), err == nil
+	}
+	return "", false
+}
+
+// consistentTargetPath returns the path of the target prefixed with its hash
+func consistentTargetPath(name string, hash string) string {
+	dir, file := path.Split(name)
+	return dir + hash + "." + file
+}
+
+func targetFile(content []byte, usage string) TargetFile {
+	target := TargetFile{Length: int64(len(content)), Hashes: hashes(content)}
+	if usage != "" {
+		target.Custom = &TargetCustom{Sigstore: SigstoreCustom{Usage: usage, Status: "Active"}}
+	}
+	return target
+}
+
+func hashes(content []byte) map[string]string {
+	digest := sha256.Sum256(content)
+	return map[string]string{"sha256": hex.EncodeToString(digest[:])}
+}
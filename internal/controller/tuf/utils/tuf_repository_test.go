@@ -0,0 +1,470 @@
+package utils
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/secure-systems-lab/go-securesystemslib/cjson"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	core "k8s.io/api/core/v1"
+)
+
+func newSigners(g Gomega) RoleSigners {
+	keys, err := GenerateSigningKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+	signers, err := LoadSigners(keys)
+	g.Expect(err).ToNot(HaveOccurred())
+	return signers
+}
+
+func version(g Gomega, repo *Repository, role string) int64 {
+	v, err := versionOf(repo.Metadata[metadataFile(role)])
+	g.Expect(err).ToNot(HaveOccurred())
+	return v
+}
+
+// verifyMetadata checks the signature of the metadata file with the key of the root metadata
+func verifyMetadata(g Gomega, data []byte, key Key) {
+	metadata := &Metadata[json.RawMessage]{}
+	g.Expect(json.Unmarshal(data, metadata)).To(Succeed())
+	var signed any
+	g.Expect(json.Unmarshal(metadata.Signed, &signed)).To(Succeed())
+	payload, err := cjson.EncodeCanonical(signed)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(metadata.Signatures).To(HaveLen(1))
+	g.Expect(verify(key, payload, metadata.Signatures[0].Signature)).To(Succeed())
+}
+
+func TestUpdate(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signers := newSigners(g)
+	targets := map[string][]byte{"rekor.pub": []byte("rekor"), "ctfe.pub": []byte("ctfe")}
+
+	repo, err := Update(ReadRepository(nil), signers, targets, UpdateOptions{Now: now})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Metadata).To(HaveKey("1.root.json"))
+	g.Expect(repo.Targets).To(Equal(targets))
+	for _, role := range Roles {
+		g.Expect(version(g, repo, role)).To(Equal(int64(1)))
+	}
+
+	root, err := parseMetadata[Root](repo.Metadata["root.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(root.Signed.Expires).To(Equal(now.Add(DefaultExpirations[RootRole])))
+	g.Expect(root.Signed.Roles).To(HaveLen(4))
+	for _, role := range Roles {
+		keyID := root.Signed.Roles[role].KeyIDs[0]
+		g.Expect(keyID).To(Equal(signers[role].ID))
+		verifyMetadata(g, repo.Metadata[metadataFile(role)], root.Signed.Keys[keyID])
+	}
+
+	targetsMetadata, err := parseMetadata[Targets](repo.Metadata["targets.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(targetsMetadata.Signed.Targets["rekor.pub"].Length).To(Equal(int64(5)))
+	g.Expect(targetsMetadata.Signed.Targets["rekor.pub"].Custom.Sigstore.Usage).To(Equal("Rekor"))
+
+	timestamp, err := parseMetadata[Timestamp](repo.Metadata["timestamp.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(timestamp.Signed.Meta["snapshot.json"].Hashes).To(Equal(hashes(repo.Metadata["snapshot.json"])))
+
+	t.Run("up-to-date repository is not re-signed", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now.Add(time.Hour)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next).To(Equal(repo))
+	})
+
+	t.Run("changed target", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, map[string][]byte{"rekor.pub": []byte("rotated")}, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.Targets).To(HaveLen(1))
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(1)))
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, SnapshotRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("consistent snapshots", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(root.Signed.ConsistentSnapshot).To(BeTrue())
+		next := repo
+		for _, content := range []string{"v2", "v3"} {
+			next, err = Update(next, signers, map[string][]byte{"rekor.pub": []byte(content)}, UpdateOptions{Now: now})
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+		g.Expect(next.Metadata["3.targets.json"]).To(Equal(next.Metadata["targets.json"]))
+		g.Expect(next.Metadata["3.snapshot.json"]).To(Equal(next.Metadata["snapshot.json"]))
+		g.Expect(next.Metadata).To(HaveKey("2.targets.json"))
+		g.Expect(next.Metadata).ToNot(HaveKey("1.targets.json"))
+		g.Expect(next.Metadata).ToNot(HaveKey("1.snapshot.json"))
+		g.Expect(next.Metadata).ToNot(HaveKey("1.timestamp.json"))
+		g.Expect(next.Metadata).To(HaveKey("1.root.json"))
+	})
+
+	t.Run("versions signed without consistent snapshots", func(t *testing.T) {
+		g := NewWithT(t)
+		legacy := &Repository{Metadata: maps.Clone(repo.Metadata), Targets: repo.Targets}
+		delete(legacy.Metadata, "1.targets.json")
+		delete(legacy.Metadata, "1.snapshot.json")
+		next, err := Update(legacy, signers, targets, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.Metadata).To(HaveKey("2.targets.json"))
+		g.Expect(next.Metadata).To(HaveKey("2.snapshot.json"))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("root without consistent snapshots", func(t *testing.T) {
+		g := NewWithT(t)
+		desired, err := (&updater{signers: signers}).desiredRoot()
+		g.Expect(err).ToNot(HaveOccurred())
+		desired.ConsistentSnapshot = false
+		desired.Common = newCommon(RootRole, 1, now.Add(DefaultExpirations[RootRole]))
+		legacyRoot, err := signMetadata(desired, signers[RootRole])
+		g.Expect(err).ToNot(HaveOccurred())
+		legacy := &Repository{Metadata: map[string][]byte{"root.json": legacyRoot, "1.root.json": legacyRoot}}
+
+		// an upgrade doesn't sign a new root
+		next, err := Update(legacy, signers, targets, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.PendingRoot).To(BeNil())
+		g.Expect(next.Metadata["root.json"]).To(Equal(legacyRoot))
+		g.Expect(next.Metadata).ToNot(HaveKey("1.targets.json"))
+
+		// the next root enables them
+		next, err = Update(next, signers, targets, UpdateOptions{Now: now, Resign: []string{RootRole}})
+		g.Expect(err).ToNot(HaveOccurred())
+		root, err := parseMetadata[Root](next.Metadata["root.json"])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(root.Signed.Version).To(Equal(int64(2)))
+		g.Expect(root.Signed.ConsistentSnapshot).To(BeTrue())
+		g.Expect(next.Metadata).To(HaveKey("2.targets.json"))
+	})
+
+	t.Run("previous targets", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, map[string][]byte{"rekor.pub": []byte("v2")}, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.PreviousTargets).To(Equal(map[string][]byte{"rekor.pub": []byte("rekor"), "ctfe.pub": []byte("ctfe")}))
+
+		next, err = Update(next, signers, map[string][]byte{"rekor.pub": []byte("v2"), "fulcio_v1.crt.pem": []byte("fulcio")}, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		// the removed target is dropped, the previous version of the unchanged target is kept
+		g.Expect(next.PreviousTargets).To(Equal(map[string][]byte{"rekor.pub": []byte("rekor")}))
+
+		next, err = Update(next, signers, map[string][]byte{"rekor.pub": []byte("v3"), "fulcio_v1.crt.pem": []byte("fulcio")}, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.PreviousTargets).To(Equal(map[string][]byte{"rekor.pub": []byte("v2")}))
+	})
+
+	t.Run("changed timestamp key", func(t *testing.T) {
+		g := NewWithT(t)
+		rotated := newSigners(g)
+		rotated[RootRole], rotated[TargetsRole], rotated[SnapshotRole] = signers[RootRole], signers[TargetsRole], signers[SnapshotRole]
+		next, err := Update(repo, rotated, targets, UpdateOptions{Now: now})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.Metadata).To(HaveKey("2.root.json"))
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(1)))
+		g.Expect(version(g, next, SnapshotRole)).To(Equal(int64(1)))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("timestamp refresh", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now.Add(13 * time.Hour)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(version(g, next, SnapshotRole)).To(Equal(int64(1)))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("snapshot refresh", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now.Add(4 * 24 * time.Hour)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(1)))
+		g.Expect(version(g, next, SnapshotRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("shortened expiration", func(t *testing.T) {
+		g := NewWithT(t)
+		expirations := maps.Clone(DefaultExpirations)
+		expirations[TimestampRole] = time.Hour
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now, Expirations: expirations})
+		g.Expect(err).ToNot(HaveOccurred())
+		timestamp, err := parseMetadata[Timestamp](next.Metadata["timestamp.json"])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(timestamp.Signed.Expires).To(Equal(now.Add(time.Hour)))
+	})
+
+	t.Run("requested re-signing", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now.Add(time.Hour), Resign: []string{RootRole, TargetsRole}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.Metadata).To(HaveKey("2.root.json"))
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, SnapshotRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TimestampRole)).To(Equal(int64(2)))
+	})
+
+	t.Run("target usage", func(t *testing.T) {
+		g := NewWithT(t)
+		shards := map[string][]byte{"rekor.pub": []byte("rekor"), "rekor-shard.pub": []byte("shard")}
+		next, err := Update(repo, signers, shards, UpdateOptions{Now: now, Usages: map[string]string{"rekor-shard.pub": "Rekor"}})
+		g.Expect(err).ToNot(HaveOccurred())
+		targetsMetadata, err := parseMetadata[Targets](next.Metadata["targets.json"])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(targetsMetadata.Signed.Targets["rekor.pub"].Custom.Sigstore.Usage).To(Equal("Rekor"))
+		g.Expect(targetsMetadata.Signed.Targets["rekor-shard.pub"].Custom.Sigstore.Usage).To(Equal("Rekor"))
+	})
+
+	t.Run("missing signer", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := Update(repo, RoleSigners{RootRole: signers[RootRole]}, targets, UpdateOptions{Now: now})
+		g.Expect(err).To(MatchError(errMissingSigner))
+	})
+}
+
+func newSigner(g Gomega) *Signer {
+	key, err := GenerateSigningKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	s, err := NewSigner(key)
+	g.Expect(err).ToNot(HaveOccurred())
+	return s
+}
+
+// signPending returns the detached signatures of the pending root payload
+func signPending(g Gomega, repo *Repository, signers ...*Signer) []Signature {
+	signatures := make([]Signature, 0, len(signers))
+	for _, s := range signers {
+		sig, err := s.sign(repo.PendingPayload)
+		g.Expect(err).ToNot(HaveOccurred())
+		signatures = append(signatures, sig)
+	}
+	return signatures
+}
+
+func TestUpdate_offlineRoot(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signers := newSigners(g)
+	delete(signers, RootRole)
+	targets := map[string][]byte{"rekor.pub": []byte("rekor")}
+	root1, root2, root3 := newSigner(g), newSigner(g), newSigner(g)
+	options := &RootOptions{Keys: []Key{root1.Key, root2.Key}, Threshold: 2}
+
+	repo, err := Update(ReadRepository(nil), signers, targets, UpdateOptions{Now: now, Root: options})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Metadata).To(BeEmpty())
+	g.Expect(repo.PendingRoot).ToNot(BeNil())
+	status, err := repo.PendingRootStatus()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.Version).To(Equal(int64(1)))
+	g.Expect(status.KeyIDs).To(ConsistOf(root1.ID, root2.ID))
+	g.Expect(status.SignedKeyIDs).To(BeEmpty())
+
+	// the threshold is not met
+	signatures := signPending(g, repo, root1)
+	repo, err = Update(repo, signers, targets, UpdateOptions{Now: now.Add(time.Minute), Root: options, RootSignatures: signatures})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Metadata).To(BeEmpty())
+	status, err = repo.PendingRootStatus()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.SignedKeyIDs).To(Equal([]string{root1.ID}))
+
+	// invalid signatures are ignored
+	invalid := signPending(g, repo, root3)[0]
+	invalid.KeyID = root2.ID
+	signatures = append(signatures, invalid)
+	repo, err = Update(repo, signers, targets, UpdateOptions{Now: now, Root: options, RootSignatures: signatures})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.Metadata).To(BeEmpty())
+
+	signatures = append(signatures, signPending(g, repo, root2)...)
+	repo, err = Update(repo, signers, targets, UpdateOptions{Now: now, Root: options, RootSignatures: signatures})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.PendingRoot).To(BeNil())
+	g.Expect(repo.Metadata).To(HaveKey("1.root.json"))
+	for _, role := range Roles {
+		g.Expect(version(g, repo, role)).To(Equal(int64(1)))
+	}
+	root, err := parseMetadata[Root](repo.Metadata["root.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(root.Signatures).To(HaveLen(2))
+	g.Expect(root.Signed.Roles[RootRole].Threshold).To(Equal(2))
+
+	t.Run("up-to-date repository is not re-signed", func(t *testing.T) {
+		g := NewWithT(t)
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now.Add(time.Hour), Root: options})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next).To(Equal(repo))
+	})
+
+	t.Run("root key rotation", func(t *testing.T) {
+		g := NewWithT(t)
+		rotated := &RootOptions{Keys: []Key{root2.Key, root3.Key}, Threshold: 1}
+		next, err := Update(repo, signers, targets, UpdateOptions{Now: now, Root: rotated})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(1)))
+		status, err := next.PendingRootStatus()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(status.Version).To(Equal(int64(2)))
+		g.Expect(status.PreviousKeyIDs).To(ConsistOf(root1.ID, root2.ID))
+		g.Expect(status.PreviousThreshold).To(Equal(2))
+
+		// the new root threshold is met, the previous one is not
+		signatures := signPending(g, next, root2, root3)
+		next, err = Update(next, signers, targets, UpdateOptions{Now: now, Root: rotated, RootSignatures: signatures})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(1)))
+
+		signatures = append(signatures, signPending(g, next, root1)...)
+		next, err = Update(next, signers, targets, UpdateOptions{Now: now, Root: rotated, RootSignatures: signatures})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.PendingRoot).To(BeNil())
+		g.Expect(next.Metadata).To(HaveKey("1.root.json"))
+		g.Expect(next.Metadata).To(HaveKey("2.root.json"))
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(1)))
+	})
+
+	t.Run("targets key rotation waits for the root", func(t *testing.T) {
+		g := NewWithT(t)
+		rotated := maps.Clone(signers)
+		rotated[TargetsRole] = newSigner(g)
+		next, err := Update(repo, rotated, map[string][]byte{"rekor.pub": []byte("rotated")}, UpdateOptions{Now: now, Root: options})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(next.PendingRoot).ToNot(BeNil())
+		g.Expect(next.Metadata).To(Equal(repo.Metadata))
+
+		signatures := signPending(g, next, root1, root2)
+		next, err = Update(next, rotated, map[string][]byte{"rekor.pub": []byte("rotated")}, UpdateOptions{Now: now, Root: options, RootSignatures: signatures})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(version(g, next, RootRole)).To(Equal(int64(2)))
+		g.Expect(version(g, next, TargetsRole)).To(Equal(int64(2)))
+	})
+}
+
+func TestUpdate_rootKeyRotation(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	signers := newSigners(g)
+	targets := map[string][]byte{"rekor.pub": []byte("rekor")}
+	repo, err := Update(ReadRepository(nil), signers, targets, UpdateOptions{Now: now})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// the new root is signed by the new root key, the previous root key must sign it too
+	previous := signers[RootRole]
+	rotated := maps.Clone(signers)
+	rotated[RootRole] = newSigner(g)
+	next, err := Update(repo, rotated, targets, UpdateOptions{Now: now})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version(g, next, RootRole)).To(Equal(int64(1)))
+	status, err := next.PendingRootStatus()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status.SignedKeyIDs).To(Equal([]string{rotated[RootRole].ID}))
+
+	next, err = Update(next, rotated, targets, UpdateOptions{Now: now, RootSignatures: signPending(g, next, previous)})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(next.PendingRoot).To(BeNil())
+	g.Expect(version(g, next, RootRole)).To(Equal(int64(2)))
+	root, err := parseMetadata[Root](next.Metadata["2.root.json"])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(root.Signatures).To(HaveLen(2))
+}
+
+func TestRepositoryConfigMap(t *testing.T) {
+	g := NewWithT(t)
+	repo, err := Update(ReadRepository(nil), newSigners(g), map[string][]byte{"fulcio_v1.crt.pem": []byte("cert")}, UpdateOptions{Now: time.Now()})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cm := &core.ConfigMap{}
+	repo.Write(cm)
+	g.Expect(cm.Data).To(HaveLen(7))
+	g.Expect(cm.BinaryData).To(HaveKey("targets_fulcio_v1.crt.pem"))
+	g.Expect(ReadRepository(cm)).To(Equal(repo))
+	g.Expect(Items(cm)).To(Equal([]core.KeyToPath{
+		{Key: "1.root.json", Path: "1.root.json"},
+		{Key: "1.snapshot.json", Path: "1.snapshot.json"},
+		{Key: "1.targets.json", Path: "1.targets.json"},
+		{Key: "root.json", Path: "root.json"},
+		{Key: "snapshot.json", Path: "snapshot.json"},
+		{Key: "targets.json", Path: "targets.json"},
+		{Key: "targets_fulcio_v1.crt.pem", Path: "targets/06298432e8066b29e2223bcc23aa9504b56ae508fabf3435508869b9c3190e22.fulcio_v1.crt.pem"},
+		{Key: "targets_fulcio_v1.crt.pem", Path: "targets/c6f5bdedf32a322066813cbf8cfe42bc51aaf1baa529a3dc2fbc672819c29779f61269694e6486f0830dbc4b0262a93360fb2fbd9fdcb24dad24f50ce48c26dd.fulcio_v1.crt.pem"},
+		{Key: "targets_fulcio_v1.crt.pem", Path: "targets/fulcio_v1.crt.pem"},
+		{Key: "timestamp.json", Path: "timestamp.json"},
+	}))
+
+	// the previous content is served under its hashes only
+	repo.PreviousTargets = map[string][]byte{"fulcio_v1.crt.pem": []byte("previous")}
+	repo.Write(cm)
+	g.Expect(cm.BinaryData).To(HaveKey("previous_targets_fulcio_v1.crt.pem"))
+	g.Expect(ReadRepository(cm)).To(Equal(repo))
+	var previous []string
+	for _, item := range Items(cm) {
+		if item.Key == "previous_targets_fulcio_v1.crt.pem" {
+			previous = append(previous, item.Path)
+		}
+	}
+	sha256Digest := sha256.Sum256([]byte("previous"))
+	g.Expect(previous).To(HaveLen(2))
+	g.Expect(previous).To(ContainElement("targets/" + hex.EncodeToString(sha256Digest[:]) + ".fulcio_v1.crt.pem"))
+}
+
+func TestTargetKey(t *testing.T) {
+	g := NewWithT(t)
+	g.Expect(targetKey("rekor.pub")).To(Equal("targets_rekor.pub"))
+	key := targetKey("registry.npmjs.org/keys.json")
+	g.Expect(key).To(Equal("targetpath_cmVnaXN0cnkubnBtanMub3JnL2tleXMuanNvbg"))
+
+	name, ok := targetName(key)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(name).To(Equal("registry.npmjs.org/keys.json"))
+	g.Expect(consistentTargetPath(name, "abc")).To(Equal("registry.npmjs.org/abc.keys.json"))
+
+	_, ok = targetName("root.json")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestLoadSigners(t *testing.T) {
+	g := NewWithT(t)
+	keys, err := GenerateSigningKeys()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	delete(keys, TimestampRole)
+	_, err = LoadSigners(keys)
+	g.Expect(err).To(MatchError(errMissingSigner))
+
+	keys[TimestampRole] = []byte("invalid")
+	_, err = LoadSigners(keys)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestNewSigner_ed25519(t *testing.T) {
+	g := NewWithT(t)
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	pem, err := cryptoutils.MarshalPrivateKeyToPEM(private)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	s, err := NewSigner(pem)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.Key).To(Equal(Key{Type: "ed25519", Scheme: "ed25519", Value: KeyValue{Public: hex.EncodeToString(public)}}))
+
+	sig, err := s.sign([]byte("payload"))
+	g.Expect(err).ToNot(HaveOccurred())
+	raw, err := hex.DecodeString(sig.Signature)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ed25519.Verify(public, []byte("payload"), raw)).To(BeTrue())
+}
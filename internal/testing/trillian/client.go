@@ -0,0 +1,113 @@
+package trillian
+
+import (
+	"context"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/quota/etcd/quotapb"
+	"github.com/securesign/operator/internal/controller/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FakeClient is an in-memory implementation of common.TrillianClient
+type FakeClient struct {
+	Trees map[int64]*trillian.Tree
+	Size  uint64
+	// URL of the last opened connection
+	URL string
+	// Paths of the last tree update
+	Updated []string
+	Quotas  map[string]*quotapb.Config
+	// Paths of the last quota update
+	QuotaUpdated []string
+}
+
+func NewFakeClient(trees ...*trillian.Tree) *FakeClient {
+	f := &FakeClient{Trees: map[int64]*trillian.Tree{}, Quotas: map[string]*quotapb.Config{}}
+	for _, tree := range trees {
+		f.Trees[tree.TreeId] = tree
+	}
+	return f
+}
+
+// NewClient implements common.NewTrillianClientFunc
+func (f *FakeClient) NewClient(trillianURL string, _ []byte) (common.TrillianClient, error) {
+	f.URL = trillianURL
+	return f, nil
+}
+
+func (f *FakeClient) CreateTree(_ context.Context, tree *trillian.Tree) (*trillian.Tree, error) {
+	tree.TreeId = int64(len(f.Trees) + 1)
+	for f.Trees[tree.TreeId] != nil {
+		tree.TreeId++
+	}
+	f.Trees[tree.TreeId] = tree
+	return tree, nil
+}
+
+func (f *FakeClient) GetTree(_ context.Context, treeID int64) (*trillian.Tree, error) {
+	tree, ok := f.Trees[treeID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "tree not found")
+	}
+	return tree, nil
+}
+
+func (f *FakeClient) ListTrees(context.Context) ([]*trillian.Tree, error) {
+	trees := make([]*trillian.Tree, 0, len(f.Trees))
+	for _, tree := range f.Trees {
+		trees = append(trees, tree)
+	}
+	return trees, nil
+}
+
+func (f *FakeClient) UpdateTree(_ context.Context, tree *trillian.Tree, paths ...string) (*trillian.Tree, error) {
+	if _, ok := f.Trees[tree.TreeId]; !ok {
+		return nil, status.Error(codes.NotFound, "tree not found")
+	}
+	f.Updated = paths
+	f.Trees[tree.TreeId] = tree
+	return tree, nil
+}
+
+func (f *FakeClient) DeleteTree(_ context.Context, treeID int64) error {
+	if _, ok := f.Trees[treeID]; !ok {
+		return status.Error(codes.NotFound, "tree not found")
+	}
+	delete(f.Trees, treeID)
+	return nil
+}
+
+func (f *FakeClient) TreeSize(context.Context, int64) (uint64, error) {
+	return f.Size, nil
+}
+
+func (f *FakeClient) GetQuota(_ context.Context, name string) (*quotapb.Config, error) {
+	config, ok := f.Quotas[name]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "config not found")
+	}
+	return config, nil
+}
+
+func (f *FakeClient) CreateQuota(_ context.Context, config *quotapb.Config) (*quotapb.Config, error) {
+	if _, ok := f.Quotas[config.Name]; ok {
+		return nil, status.Error(codes.AlreadyExists, "config already exists")
+	}
+	f.Quotas[config.Name] = config
+	return config, nil
+}
+
+func (f *FakeClient) UpdateQuota(_ context.Context, config *quotapb.Config, paths ...string) (*quotapb.Config, error) {
+	if _, ok := f.Quotas[config.Name]; !ok {
+		return nil, status.Error(codes.NotFound, "config not found")
+	}
+	f.QuotaUpdated = paths
+	f.Quotas[config.Name] = config
+	return config, nil
+}
+
+func (f *FakeClient) Close() error {
+	return nil
+}
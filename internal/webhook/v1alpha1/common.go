@@ -0,0 +1,136 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// secretRef is a reference to a secret, or to a key of it, checked by the validating webhooks
+type secretRef struct {
+	path *field.Path
+	name string
+	key  string
+}
+
+// keyRef returns the reference of a secret key selector, nil selectors are skipped
+func keyRef(path *field.Path, selector *rhtasv1alpha1.SecretKeySelector) []secretRef {
+	if selector == nil {
+		return nil
+	}
+	return []secretRef{{path: path, name: selector.Name, key: selector.Key}}
+}
+
+// localRef returns the reference of a whole secret, nil references are skipped
+func localRef(path *field.Path, reference *rhtasv1alpha1.LocalObjectReference) []secretRef {
+	if reference == nil {
+		return nil
+	}
+	return []secretRef{{path: path, name: reference.Name}}
+}
+
+// missingSecrets returns an error for every referenced secret or secret key missing in the namespace.
+// The secrets are read when the component is deployed, the controllers can't make progress without them.
+func missingSecrets(ctx context.Context, c client.Client, namespace string, refs ...[]secretRef) (field.ErrorList, error) {
+	var all []secretRef
+	for _, r := range refs {
+		all = append(all, r...)
+	}
+	var errs field.ErrorList
+	for _, ref := range all {
+		secret := &corev1.Secret{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.name}, secret)
+		switch {
+		case apierrors.IsNotFound(err):
+			errs = append(errs, field.NotFound(ref.path, fmt.Sprintf("secret %s/%s", namespace, ref.name)))
+		case err != nil:
+			return nil, err
+		case ref.key != "":
+			if _, ok := secret.Data[ref.key]; !ok {
+				errs = append(errs, field.Invalid(ref.path, ref.key, fmt.Sprintf("key not found in secret %s/%s", namespace, ref.name)))
+			}
+		}
+	}
+	return errs, nil
+}
+
+// missingObject returns a not found error when the referenced object is missing in the namespace. The callers
+// decide whether it rejects the resource or is only a warning.
+func missingObject(ctx context.Context, c client.Client, path *field.Path, namespace, name string, object client.Object) (*field.Error, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	err := c.Get(ctx, key, object)
+	switch {
+	case apierrors.IsNotFound(err):
+		kind, _ := c.GroupVersionKindFor(object)
+		if namespace == "" {
+			return field.NotFound(path, fmt.Sprintf("%s %s", kind.Kind, name)), nil
+		}
+		return field.NotFound(path, fmt.Sprintf("%s %s", kind.Kind, key)), nil
+	case err != nil:
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ignored returns a warning for a field overridden by another one
+func ignored(path, by *field.Path) string {
+	return fmt.Sprintf("%s is ignored when %s is set", path, by)
+}
+
+// validateFunc validates a resource, the field errors reject it
+type validateFunc func(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error)
+
+// validateCreate validates a created resource
+func validateCreate(ctx context.Context, kind string, obj runtime.Object, validate validateFunc) (admission.Warnings, error) {
+	warnings, errs, err := validate(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, invalid(kind, obj.(client.Object).GetName(), errs)
+}
+
+// validateUpdate validates an updated resource. A resource being deleted is not validated, the removal of its
+// finalizers can't depend on its references. Only the errors the old resource didn't have reject the update: the
+// secrets, ConfigMaps and namespaces removed after the resource was admitted don't block its metadata and status
+// updates, only a new or changed reference is checked.
+func validateUpdate(ctx context.Context, kind string, oldObj, newObj runtime.Object, validate validateFunc) (admission.Warnings, error) {
+	updated, ok := newObj.(client.Object)
+	if ok && updated.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	warnings, errs, err := validate(ctx, newObj)
+	if err != nil || len(errs) == 0 {
+		return warnings, err
+	}
+	_, previous, err := validate(ctx, oldObj)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(previous))
+	for _, e := range previous {
+		known[e.Error()] = true
+	}
+	var changed field.ErrorList
+	for _, e := range errs {
+		if !known[e.Error()] {
+			changed = append(changed, e)
+		}
+	}
+	return warnings, invalid(kind, updated.GetName(), changed)
+}
+
+// invalid aggregates the validation errors of the resource, nil is returned when there are none
+func invalid(kind, name string, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: rhtasv1alpha1.GroupVersion.Group, Kind: kind}, name, errs)
+}
@@ -0,0 +1,143 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// oidPattern matches an ASN.1 object identifier in dotted notation
+var oidPattern = regexp.MustCompile(`^[0-2](\.(0|[1-9][0-9]*))+$`)
+
+// SetupCTlogWebhookWithManager registers the defaulting and validating webhooks of CTlog
+func SetupCTlogWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.CTlog{}).
+		WithDefaulter(&CTlogCustomDefaulter{}).
+		WithValidator(&CTlogCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-rhtas-redhat-com-v1alpha1-ctlog,mutating=true,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=ctlogs,verbs=create;update,versions=v1alpha1,name=mctlog.rhtas.redhat.com,admissionReviewVersions=v1
+
+// CTlogCustomDefaulter sets the defaults of CTlog the CRD schema can't express
+type CTlogCustomDefaulter struct{}
+
+func (d *CTlogCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	ctlog, ok := obj.(*rhtasv1alpha1.CTlog)
+	if !ok {
+		return fmt.Errorf("expected a CTlog object but got %T", obj)
+	}
+	rhtasv1alpha1.DefaultCTlogSpec(&ctlog.Spec)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-ctlog,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=ctlogs,verbs=create;update,versions=v1alpha1,name=vctlog.rhtas.redhat.com,admissionReviewVersions=v1
+
+// CTlogCustomValidator validates the cross-field and cross-object rules of CTlog
+type CTlogCustomValidator struct {
+	Client client.Client
+}
+
+func (v *CTlogCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "CTlog", obj, v.validate)
+}
+
+func (v *CTlogCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "CTlog", oldObj, newObj, v.validate)
+}
+
+func (v *CTlogCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *CTlogCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	ctlog, ok := obj.(*rhtasv1alpha1.CTlog)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a CTlog object but got %T", obj)
+	}
+	warnings, errs, err := validateCTlogSpec(ctx, v.Client, ctlog.Namespace, &ctlog.Spec, field.NewPath("spec"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, errs, nil
+}
+
+// validateCTlogSpec validates the spec of CTlog deployed in the namespace
+func validateCTlogSpec(ctx context.Context, c client.Client, namespace string, spec *rhtasv1alpha1.CTlogSpec, path *field.Path) (admission.Warnings, field.ErrorList, error) {
+	var errs field.ErrorList
+	if spec.Admission != nil {
+		for i, oid := range spec.Admission.RejectExtensions {
+			if !oidPattern.MatchString(oid) {
+				errs = append(errs, field.Invalid(path.Child("admission", "rejectExtensions").Index(i), oid, "must be an object identifier in dotted notation"))
+			}
+		}
+	}
+
+	var warnings admission.Warnings
+	if spec.ServerConfigRef != nil {
+		// the server config replaces the configuration generated from these fields
+		serverConfig := path.Child("serverConfigRef")
+		for _, f := range []struct {
+			name string
+			set  bool
+		}{
+			{"treeID", spec.TreeID != nil},
+			{"treeRef", spec.TreeRef != nil},
+			{"privateKeyRef", spec.PrivateKeyRef != nil},
+			{"privateKeyPasswordRef", spec.PrivateKeyPasswordRef != nil},
+			{"publicKeyRef", spec.PublicKeyRef != nil},
+			{"rootCertificates", len(spec.RootCertificates) > 0},
+			{"admission", spec.Admission != nil},
+		} {
+			if f.set {
+				warnings = append(warnings, ignored(path.Child(f.name), serverConfig))
+			}
+		}
+		if spec.Trillian.Address != "" {
+			warnings = append(warnings, ignored(path.Child("trillian", "address"), serverConfig))
+		}
+	}
+
+	missing, err := missingSecrets(ctx, c, namespace,
+		localRef(path.Child("serverConfigRef"), spec.ServerConfigRef),
+		keyRef(path.Child("privateKeyRef"), spec.PrivateKeyRef),
+		keyRef(path.Child("privateKeyPasswordRef"), spec.PrivateKeyPasswordRef),
+		keyRef(path.Child("publicKeyRef"), spec.PublicKeyRef),
+		keyRef(path.Child("trillian", "caCertRef"), spec.Trillian.CACertRef))
+	if err != nil {
+		return nil, nil, err
+	}
+	errs = append(errs, missing...)
+
+	rootNamespace := namespace
+	if spec.RootCertificatesNamespace != "" {
+		rootNamespace = spec.RootCertificatesNamespace
+	}
+	roots := make([]secretRef, 0, len(spec.RootCertificates))
+	for i := range spec.RootCertificates {
+		roots = append(roots, keyRef(path.Child("rootCertificates").Index(i), &spec.RootCertificates[i])...)
+	}
+	if missing, err = missingSecrets(ctx, c, rootNamespace, roots); err != nil {
+		return nil, nil, err
+	}
+	errs = append(errs, missing...)
+
+	if spec.TreeRef != nil {
+		// the tree may be created after the log, the controller waits for it
+		tree, err := missingObject(ctx, c, path.Child("treeRef"), namespace, spec.TreeRef.Name, &rhtasv1alpha1.TrillianTree{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if tree != nil {
+			warnings = append(warnings, tree.Error())
+		}
+	}
+	return warnings, errs, nil
+}
@@ -0,0 +1,55 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("CTlog webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	It("defaults the extended key usages of the admission policy", func() {
+		ctlog := &rhtasv1alpha1.CTlog{
+			ObjectMeta: metav1.ObjectMeta{Name: "ctlog", Namespace: namespace},
+			Spec:       rhtasv1alpha1.CTlogSpec{Admission: &rhtasv1alpha1.CTlogAdmission{RejectExpired: true}},
+		}
+		Expect(k8sClient.Create(ctx, ctlog)).To(Succeed())
+		Expect(ctlog.Spec.Admission.ExtKeyUsages).To(Equal([]rhtasv1alpha1.ExtKeyUsage{"CodeSigning"}))
+	})
+
+	It("rejects an invalid extension OID", func() {
+		ctlog := &rhtasv1alpha1.CTlog{
+			ObjectMeta: metav1.ObjectMeta{Name: "ctlog", Namespace: namespace},
+			Spec:       rhtasv1alpha1.CTlogSpec{Admission: &rhtasv1alpha1.CTlogAdmission{RejectExtensions: []string{"1.3.6.1.4.1.57264.1.1", "oid"}}},
+		}
+		err := k8sClient.Create(ctx, ctlog)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.admission.rejectExtensions[1]")))
+	})
+
+	It("warns about the fields overridden by the server config", func() {
+		createSecret(namespace, "config", "config")
+		ctlog := &rhtasv1alpha1.CTlog{
+			ObjectMeta: metav1.ObjectMeta{Name: "ctlog", Namespace: namespace},
+			Spec: rhtasv1alpha1.CTlogSpec{
+				ServerConfigRef: &rhtasv1alpha1.LocalObjectReference{Name: "config"},
+				TreeID:          ptr.To(int64(1)),
+			},
+		}
+		Eventually(func() []string {
+			Expect(k8sClient.Create(ctx, ctlog)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, ctlog)).To(Succeed())
+			ctlog.ResourceVersion = ""
+			return warnings.Reset()
+		}).Should(ConsistOf("spec.treeID is ignored when spec.serverConfigRef is set"))
+	})
+})
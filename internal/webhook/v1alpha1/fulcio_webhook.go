@@ -0,0 +1,94 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupFulcioWebhookWithManager registers the validating webhook of Fulcio
+func SetupFulcioWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.Fulcio{}).
+		WithValidator(&FulcioCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-fulcio,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=fulcios,verbs=create;update,versions=v1alpha1,name=vfulcio.rhtas.redhat.com,admissionReviewVersions=v1
+
+// FulcioCustomValidator validates the cross-field and cross-object rules of Fulcio
+type FulcioCustomValidator struct {
+	Client client.Client
+}
+
+func (v *FulcioCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "Fulcio", obj, v.validate)
+}
+
+func (v *FulcioCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "Fulcio", oldObj, newObj, v.validate)
+}
+
+func (v *FulcioCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *FulcioCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	fulcio, ok := obj.(*rhtasv1alpha1.Fulcio)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a Fulcio object but got %T", obj)
+	}
+	warnings, errs, err := validateFulcioSpec(ctx, v.Client, fulcio.Namespace, &fulcio.Spec, field.NewPath("spec"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, errs, nil
+}
+
+// validateFulcioSpec validates the spec of Fulcio deployed in the namespace
+func validateFulcioSpec(ctx context.Context, c client.Client, namespace string, spec *rhtasv1alpha1.FulcioSpec, path *field.Path) (admission.Warnings, field.ErrorList, error) {
+	var errs field.ErrorList
+	// the issuers are keyed by the issuer in the server config
+	for _, issuers := range []struct {
+		name    string
+		issuers []rhtasv1alpha1.OIDCIssuer
+	}{
+		{"OIDCIssuers", spec.Config.OIDCIssuers},
+		{"MetaIssuers", spec.Config.MetaIssuers},
+	} {
+		seen := make(map[string]bool, len(issuers.issuers))
+		for i, issuer := range issuers.issuers {
+			if seen[issuer.Issuer] {
+				errs = append(errs, field.Duplicate(path.Child("config", issuers.name).Index(i).Child("Issuer"), issuer.Issuer))
+			}
+			seen[issuer.Issuer] = true
+		}
+	}
+
+	certificate := path.Child("certificate")
+	missing, err := missingSecrets(ctx, c, namespace,
+		keyRef(certificate.Child("privateKeyRef"), spec.Certificate.PrivateKeyRef),
+		keyRef(certificate.Child("privateKeyPasswordRef"), spec.Certificate.PrivateKeyPasswordRef),
+		keyRef(certificate.Child("caRef"), spec.Certificate.CARef))
+	if err != nil {
+		return nil, nil, err
+	}
+	errs = append(errs, missing...)
+
+	if spec.TrustedCA != nil {
+		missing, err := missingObject(ctx, c, path.Child("trustedCA"), namespace, spec.TrustedCA.Name, &corev1.ConfigMap{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if missing != nil {
+			errs = append(errs, missing)
+		}
+	}
+	return nil, errs, nil
+}
@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Fulcio webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	generateFulcio := func(issuers ...rhtasv1alpha1.OIDCIssuer) *rhtasv1alpha1.Fulcio {
+		return &rhtasv1alpha1.Fulcio{
+			ObjectMeta: metav1.ObjectMeta{Name: "fulcio", Namespace: namespace},
+			Spec: rhtasv1alpha1.FulcioSpec{
+				Config:      rhtasv1alpha1.FulcioConfig{OIDCIssuers: issuers},
+				Certificate: rhtasv1alpha1.FulcioCert{CommonName: "hostname", OrganizationName: "organization"},
+			},
+		}
+	}
+
+	It("leaves the issuer URL unset", func() {
+		fulcio := generateFulcio(rhtasv1alpha1.OIDCIssuer{Issuer: "https://issuer", ClientID: "client", Type: "email"})
+		Expect(k8sClient.Create(ctx, fulcio)).To(Succeed())
+		Expect(fulcio.Spec.Config.OIDCIssuers[0].IssuerURL).To(BeEmpty())
+	})
+
+	It("rejects duplicate issuers", func() {
+		fulcio := generateFulcio(
+			rhtasv1alpha1.OIDCIssuer{Issuer: "https://issuer", ClientID: "client", Type: "email"},
+			rhtasv1alpha1.OIDCIssuer{Issuer: "https://issuer", ClientID: "other", Type: "email"},
+		)
+		err := k8sClient.Create(ctx, fulcio)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.config.OIDCIssuers[1].Issuer: Duplicate value")))
+	})
+
+	It("rejects missing certificate secrets and trusted CA", func() {
+		fulcio := generateFulcio(rhtasv1alpha1.OIDCIssuer{Issuer: "https://issuer", ClientID: "client", Type: "email"})
+		fulcio.Spec.Certificate.PrivateKeyRef = &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "ca"}, Key: "key"}
+		fulcio.Spec.TrustedCA = &rhtasv1alpha1.LocalObjectReference{Name: "trusted"}
+		err := k8sClient.Create(ctx, fulcio)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`spec.certificate.privateKeyRef: Not found: "secret ` + namespace + `/ca"`)))
+		Expect(err).To(MatchError(ContainSubstring(`spec.trustedCA: Not found: "ConfigMap ` + namespace + `/trusted"`)))
+	})
+})
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupRekorWebhookWithManager registers the defaulting and validating webhooks of Rekor
+func SetupRekorWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.Rekor{}).
+		WithDefaulter(&RekorCustomDefaulter{}).
+		WithValidator(&RekorCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-rhtas-redhat-com-v1alpha1-rekor,mutating=true,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=rekors,verbs=create;update,versions=v1alpha1,name=mrekor.rhtas.redhat.com,admissionReviewVersions=v1
+
+// RekorCustomDefaulter sets the defaults of Rekor the CRD schema can't express
+type RekorCustomDefaulter struct{}
+
+func (d *RekorCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rekor, ok := obj.(*rhtasv1alpha1.Rekor)
+	if !ok {
+		return fmt.Errorf("expected a Rekor object but got %T", obj)
+	}
+	rhtasv1alpha1.DefaultRekorSpec(&rekor.Spec)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-rekor,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=rekors,verbs=create;update,versions=v1alpha1,name=vrekor.rhtas.redhat.com,admissionReviewVersions=v1
+
+// RekorCustomValidator validates the cross-field and cross-object rules of Rekor
+type RekorCustomValidator struct {
+	Client client.Client
+}
+
+func (v *RekorCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "Rekor", obj, v.validate)
+}
+
+func (v *RekorCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "Rekor", oldObj, newObj, v.validate)
+}
+
+func (v *RekorCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *RekorCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	rekor, ok := obj.(*rhtasv1alpha1.Rekor)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a Rekor object but got %T", obj)
+	}
+	warnings, errs, err := validateRekorSpec(ctx, v.Client, rekor.Namespace, &rekor.Spec, rekor.Status.TreeID, field.NewPath("spec"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, errs, nil
+}
+
+// validateRekorSpec validates the spec of Rekor deployed in the namespace. The active tree is the tree the log
+// currently writes to, it can't be one of the inactive shards.
+func validateRekorSpec(ctx context.Context, c client.Client, namespace string, spec *rhtasv1alpha1.RekorSpec, activeTree *int64, path *field.Path) (admission.Warnings, field.ErrorList, error) {
+	var errs field.ErrorList
+	if spec.TreeID != nil {
+		activeTree = spec.TreeID
+	}
+	if activeTree != nil {
+		for i, shard := range spec.Sharding {
+			if shard.TreeID == *activeTree {
+				errs = append(errs, field.Invalid(path.Child("sharding").Index(i).Child("treeID"), shard.TreeID, "the active tree can't be an inactive shard"))
+			}
+		}
+	}
+
+	var warnings admission.Warnings
+	signer := path.Child("signer")
+	if spec.Signer.KMS != "secret" && spec.Signer.KMS != "" {
+		if spec.Signer.KeyRef != nil {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored when %s is not secret", signer.Child("keyRef"), signer.Child("kms")))
+		}
+		if spec.Signer.PasswordRef != nil {
+			warnings = append(warnings, fmt.Sprintf("%s is ignored when %s is not secret", signer.Child("passwordRef"), signer.Child("kms")))
+		}
+	}
+
+	missing, err := missingSecrets(ctx, c, namespace,
+		keyRef(signer.Child("keyRef"), spec.Signer.KeyRef),
+		keyRef(signer.Child("passwordRef"), spec.Signer.PasswordRef),
+		keyRef(path.Child("trillian", "caCertRef"), spec.Trillian.CACertRef))
+	if err != nil {
+		return nil, nil, err
+	}
+	errs = append(errs, missing...)
+
+	if spec.TreeRef != nil {
+		// the tree may be created after the log, the controller waits for it
+		tree, err := missingObject(ctx, c, path.Child("treeRef"), namespace, spec.TreeRef.Name, &rhtasv1alpha1.TrillianTree{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if tree != nil {
+			warnings = append(warnings, tree.Error())
+		}
+	}
+	return warnings, errs, nil
+}
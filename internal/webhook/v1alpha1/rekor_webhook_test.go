@@ -0,0 +1,67 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Rekor webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	It("defaults the signer", func() {
+		rekor := &rhtasv1alpha1.Rekor{ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: namespace}}
+		Expect(k8sClient.Create(ctx, rekor)).To(Succeed())
+		Expect(rekor.Spec.Signer.KMS).To(Equal("secret"))
+	})
+
+	It("rejects the active tree as an inactive shard", func() {
+		rekor := &rhtasv1alpha1.Rekor{
+			ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: namespace},
+			Spec: rhtasv1alpha1.RekorSpec{
+				TreeID:   ptr.To(int64(1)),
+				Sharding: []rhtasv1alpha1.RekorLogRange{{TreeID: 1}},
+			},
+		}
+		err := k8sClient.Create(ctx, rekor)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("the active tree can't be an inactive shard")))
+	})
+
+	It("rejects missing secrets", func() {
+		rekor := &rhtasv1alpha1.Rekor{
+			ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: namespace},
+			Spec: rhtasv1alpha1.RekorSpec{
+				Signer: rhtasv1alpha1.RekorSigner{
+					KeyRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "signer"}, Key: "private"},
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, rekor)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`spec.signer.keyRef: Not found: "secret ` + namespace + `/signer"`)))
+
+		createSecret(namespace, "signer", "public")
+		// the webhook reads the secret from the cache of the manager
+		Eventually(func() error {
+			return k8sClient.Create(ctx, rekor)
+		}).Should(MatchError(ContainSubstring("key not found in secret " + namespace + "/signer")))
+	})
+
+	It("warns about a missing tree", func() {
+		rekor := &rhtasv1alpha1.Rekor{
+			ObjectMeta: metav1.ObjectMeta{Name: "rekor", Namespace: namespace},
+			Spec:       rhtasv1alpha1.RekorSpec{TreeRef: &rhtasv1alpha1.LocalObjectReference{Name: "tree"}},
+		}
+		Expect(k8sClient.Create(ctx, rekor)).To(Succeed())
+		Expect(warnings.Reset()).To(ConsistOf(`spec.treeRef: Not found: "TrillianTree ` + namespace + `/tree"`))
+	})
+})
@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupSecuresignWebhookWithManager registers the defaulting and validating webhooks of Securesign
+func SetupSecuresignWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.Securesign{}).
+		WithDefaulter(&SecuresignCustomDefaulter{}).
+		WithValidator(&SecuresignCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-rhtas-redhat-com-v1alpha1-securesign,mutating=true,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=securesigns,verbs=create;update,versions=v1alpha1,name=msecuresign.rhtas.redhat.com,admissionReviewVersions=v1
+
+// SecuresignCustomDefaulter sets the defaults of the component specs of Securesign, they match the defaults
+// of the component resources created from them
+type SecuresignCustomDefaulter struct{}
+
+func (d *SecuresignCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	securesign, ok := obj.(*rhtasv1alpha1.Securesign)
+	if !ok {
+		return fmt.Errorf("expected a Securesign object but got %T", obj)
+	}
+	rhtasv1alpha1.DefaultTrillianSpec(&securesign.Spec.Trillian)
+	rhtasv1alpha1.DefaultRekorSpec(&securesign.Spec.Rekor)
+	rhtasv1alpha1.DefaultCTlogSpec(&securesign.Spec.Ctlog)
+	rhtasv1alpha1.DefaultTufSpec(&securesign.Spec.Tuf)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-securesign,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=securesigns,verbs=create;update,versions=v1alpha1,name=vsecuresign.rhtas.redhat.com,admissionReviewVersions=v1
+
+// SecuresignCustomValidator validates the specs of the components deployed by Securesign
+type SecuresignCustomValidator struct {
+	Client client.Client
+}
+
+func (v *SecuresignCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "Securesign", obj, v.validate)
+}
+
+func (v *SecuresignCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "Securesign", oldObj, newObj, v.validate)
+}
+
+func (v *SecuresignCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *SecuresignCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	securesign, ok := obj.(*rhtasv1alpha1.Securesign)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a Securesign object but got %T", obj)
+	}
+	spec := &securesign.Spec
+	path := field.NewPath("spec")
+	// the specs of the components not deployed by the Securesign resource are not used
+	validators := []struct {
+		name      string
+		component rhtasv1alpha1.SecuresignComponent
+		validate  func(namespace string) (admission.Warnings, field.ErrorList, error)
+	}{
+		{"trillian", spec.Components.Trillian, func(namespace string) (admission.Warnings, field.ErrorList, error) {
+			return validateTrillianSpec(ctx, v.Client, namespace, &spec.Trillian, path.Child("trillian"))
+		}},
+		{"fulcio", spec.Components.Fulcio, func(namespace string) (admission.Warnings, field.ErrorList, error) {
+			return validateFulcioSpec(ctx, v.Client, namespace, &spec.Fulcio, path.Child("fulcio"))
+		}},
+		{"rekor", spec.Components.Rekor, func(namespace string) (admission.Warnings, field.ErrorList, error) {
+			return validateRekorSpec(ctx, v.Client, namespace, &spec.Rekor, securesign.Status.RekorStatus.TreeID, path.Child("rekor"))
+		}},
+		{"ctlog", spec.Components.Ctlog, func(namespace string) (admission.Warnings, field.ErrorList, error) {
+			return validateCTlogSpec(ctx, v.Client, namespace, &spec.Ctlog, path.Child("ctlog"))
+		}},
+		{"tuf", spec.Components.Tuf, func(namespace string) (admission.Warnings, field.ErrorList, error) {
+			return validateTufSpec(ctx, v.Client, namespace, &spec.Tuf, path.Child("tuf"))
+		}},
+	}
+
+	var (
+		warnings admission.Warnings
+		errs     field.ErrorList
+	)
+	for _, c := range validators {
+		if !c.component.IsManaged() {
+			continue
+		}
+		namespace := securesign.Namespace
+		if c.component.Namespace != "" {
+			namespace = c.component.Namespace
+			missing, err := missingObject(ctx, v.Client, path.Child("components", c.name, "namespace"), "", namespace, &corev1.Namespace{})
+			if err != nil {
+				return nil, nil, err
+			}
+			if missing != nil {
+				// the component can't be created, its references can't be checked either
+				errs = append(errs, missing)
+				continue
+			}
+		}
+		w, e, err := c.validate(namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, w...)
+		errs = append(errs, e...)
+	}
+	return warnings, errs, nil
+}
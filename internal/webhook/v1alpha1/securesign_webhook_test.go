@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Securesign webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	generateSecuresign := func() *rhtasv1alpha1.Securesign {
+		return &rhtasv1alpha1.Securesign{
+			ObjectMeta: metav1.ObjectMeta{Name: "securesign", Namespace: namespace},
+			Spec: rhtasv1alpha1.SecuresignSpec{
+				Fulcio: rhtasv1alpha1.FulcioSpec{
+					Config: rhtasv1alpha1.FulcioConfig{OIDCIssuers: []rhtasv1alpha1.OIDCIssuer{
+						{Issuer: "https://issuer", ClientID: "client", Type: "email"},
+					}},
+					Certificate: rhtasv1alpha1.FulcioCert{CommonName: "hostname", OrganizationName: "organization"},
+				},
+			},
+		}
+	}
+
+	It("defaults the component specs", func() {
+		securesign := generateSecuresign()
+		Expect(k8sClient.Create(ctx, securesign)).To(Succeed())
+		Expect(securesign.Spec.Fulcio.Config.OIDCIssuers[0].IssuerURL).To(BeEmpty())
+		Expect(securesign.Spec.Rekor.Signer.KMS).To(Equal("secret"))
+		Expect(securesign.Spec.Tuf.Keys).To(HaveEach(HaveField("Usage", Not(BeEmpty()))))
+	})
+
+	It("validates the specs of the managed components only", func() {
+		securesign := generateSecuresign()
+		securesign.Spec.Rekor.TreeID = ptr.To(int64(1))
+		securesign.Spec.Rekor.Sharding = []rhtasv1alpha1.RekorLogRange{{TreeID: 1}}
+		err := k8sClient.Create(ctx, securesign)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.rekor.sharding[0].treeID")))
+
+		securesign.Spec.Components.Rekor.External = true
+		Expect(k8sClient.Create(ctx, securesign)).To(Succeed())
+	})
+
+	It("rejects missing namespaces and secrets", func() {
+		securesign := generateSecuresign()
+		securesign.Spec.Components.Ctlog.Namespace = "missing"
+		securesign.Spec.Rekor.Signer.KeyRef = &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "signer"}, Key: "private"}
+		err := k8sClient.Create(ctx, securesign)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`spec.components.ctlog.namespace: Not found: "Namespace missing"`)))
+		Expect(err).To(MatchError(ContainSubstring(`spec.rekor.signer.keyRef: Not found: "secret ` + namespace + `/signer"`)))
+	})
+
+	It("doesn't block the updates after a referenced secret is removed", func() {
+		createSecret(namespace, "signer", "private")
+		securesign := generateSecuresign()
+		securesign.Finalizers = []string{"rhtas.redhat.com/test"}
+		securesign.Spec.Rekor.Signer.KeyRef = &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "signer"}, Key: "private"}
+		// the webhook reads the secret from the cache of the manager
+		Eventually(func() error {
+			return k8sClient.Create(ctx, securesign)
+		}).Should(Succeed())
+
+		Expect(k8sClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "signer"}})).To(Succeed())
+		// wait for the cache to observe the removal
+		Eventually(func() error {
+			probe := securesign.DeepCopy()
+			probe.ObjectMeta = metav1.ObjectMeta{Name: "probe", Namespace: namespace}
+			return k8sClient.Create(ctx, probe)
+		}).Should(MatchError(ContainSubstring(`spec.rekor.signer.keyRef: Not found: "secret ` + namespace + `/signer"`)))
+
+		// a changed reference is checked
+		updated := &rhtasv1alpha1.Securesign{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(securesign), updated)).To(Succeed())
+		updated.Spec.Rekor.Signer.KeyRef.Name = "other"
+		Expect(k8sClient.Update(ctx, updated)).To(MatchError(ContainSubstring(`spec.rekor.signer.keyRef: Not found: "secret ` + namespace + `/other"`)))
+
+		// the unchanged reference is not checked
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(securesign), securesign)).To(Succeed())
+		securesign.Labels = map[string]string{"updated": "true"}
+		Expect(k8sClient.Update(ctx, securesign)).To(Succeed())
+
+		Expect(k8sClient.Delete(ctx, securesign)).To(Succeed())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(securesign), securesign)).To(Succeed())
+		Expect(securesign.DeletionTimestamp).ToNot(BeNil())
+		securesign.Finalizers = nil
+		Expect(k8sClient.Update(ctx, securesign)).To(Succeed())
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(securesign), securesign))).To(BeTrue())
+	})
+})
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupTrillianWebhookWithManager registers the defaulting and validating webhooks of Trillian
+func SetupTrillianWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.Trillian{}).
+		WithDefaulter(&TrillianCustomDefaulter{}).
+		WithValidator(&TrillianCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-rhtas-redhat-com-v1alpha1-trillian,mutating=true,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=trillians,verbs=create;update,versions=v1alpha1,name=mtrillian.rhtas.redhat.com,admissionReviewVersions=v1
+
+// TrillianCustomDefaulter sets the defaults of Trillian the CRD schema can't express
+type TrillianCustomDefaulter struct{}
+
+func (d *TrillianCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	trillian, ok := obj.(*rhtasv1alpha1.Trillian)
+	if !ok {
+		return fmt.Errorf("expected a Trillian object but got %T", obj)
+	}
+	rhtasv1alpha1.DefaultTrillianSpec(&trillian.Spec)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-trillian,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=trillians,verbs=create;update,versions=v1alpha1,name=vtrillian.rhtas.redhat.com,admissionReviewVersions=v1
+
+// TrillianCustomValidator validates the cross-field and cross-object rules of Trillian
+type TrillianCustomValidator struct {
+	Client client.Client
+}
+
+func (v *TrillianCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "Trillian", obj, v.validate)
+}
+
+func (v *TrillianCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "Trillian", oldObj, newObj, v.validate)
+}
+
+func (v *TrillianCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *TrillianCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	trillian, ok := obj.(*rhtasv1alpha1.Trillian)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a Trillian object but got %T", obj)
+	}
+	warnings, errs, err := validateTrillianSpec(ctx, v.Client, trillian.Namespace, &trillian.Spec, field.NewPath("spec"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, errs, nil
+}
+
+// validateTrillianSpec validates the spec of Trillian deployed in the namespace
+func validateTrillianSpec(ctx context.Context, c client.Client, namespace string, spec *rhtasv1alpha1.TrillianSpec, path *field.Path) (admission.Warnings, field.ErrorList, error) {
+	var errs field.ErrorList
+	etcdServers := path.Child("logSigner", "election", "etcdServers")
+	for i, server := range spec.LogSigner.Election.EtcdServers {
+		if u, err := url.Parse(server); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, field.Invalid(etcdServers.Index(i), server, "must be an http or https URL"))
+		}
+	}
+
+	refs := [][]secretRef{
+		keyRef(path.Child("tls", "certRef"), spec.TLS.CertRef),
+		keyRef(path.Child("tls", "privateKeyRef"), spec.TLS.PrivateKeyRef),
+		keyRef(path.Child("tls", "caCertRef"), spec.TLS.CACertRef),
+		localRef(path.Child("database", "databaseSecretRef"), spec.Db.DatabaseSecretRef),
+	}
+	if backup := spec.Db.Backup; backup != nil && backup.S3 != nil {
+		refs = append(refs, localRef(path.Child("database", "backup", "s3", "credentialsSecretRef"), &backup.S3.CredentialsSecretRef))
+	}
+	missing, err := missingSecrets(ctx, c, namespace, refs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, append(errs, missing...), nil
+}
@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Trillian webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	generateTrillian := func() *rhtasv1alpha1.Trillian {
+		return &rhtasv1alpha1.Trillian{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: namespace},
+			Spec: rhtasv1alpha1.TrillianSpec{TLS: rhtasv1alpha1.TLS{
+				CertRef:       &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "tls"}, Key: "cert"},
+				PrivateKeyRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "tls"}, Key: "key"},
+			}},
+		}
+	}
+
+	It("enables TLS when a certificate is provided", func() {
+		createSecret(namespace, "tls", "cert", "key")
+		trillian := generateTrillian()
+		// the webhook reads the secret from the cache of the manager
+		Eventually(func() error {
+			return k8sClient.Create(ctx, trillian)
+		}).Should(Succeed())
+		Expect(trillian.Spec.TLS.Enabled).To(BeTrue())
+	})
+
+	It("rejects missing TLS secrets", func() {
+		err := k8sClient.Create(ctx, generateTrillian())
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`spec.tls.certRef: Not found: "secret ` + namespace + `/tls"`)))
+		Expect(err).To(MatchError(ContainSubstring(`spec.tls.privateKeyRef: Not found: "secret ` + namespace + `/tls"`)))
+	})
+
+	It("rejects etcd servers that are not URLs", func() {
+		trillian := &rhtasv1alpha1.Trillian{
+			ObjectMeta: metav1.ObjectMeta{Name: "trillian", Namespace: namespace},
+			Spec: rhtasv1alpha1.TrillianSpec{LogSigner: rhtasv1alpha1.TrillianLogSigner{
+				Election: rhtasv1alpha1.TrillianElection{EtcdServers: []string{"http://etcd:2379", "etcd:2379"}},
+			}},
+		}
+		err := k8sClient.Create(ctx, trillian)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.logSigner.election.etcdServers[1]")))
+	})
+})
@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// SetupTufWebhookWithManager registers the defaulting and validating webhooks of Tuf
+func SetupTufWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rhtasv1alpha1.Tuf{}).
+		WithDefaulter(&TufCustomDefaulter{}).
+		WithValidator(&TufCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-rhtas-redhat-com-v1alpha1-tuf,mutating=true,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=tufs,verbs=create;update,versions=v1alpha1,name=mtuf.rhtas.redhat.com,admissionReviewVersions=v1
+
+// TufCustomDefaulter sets the defaults of Tuf the CRD schema can't express
+type TufCustomDefaulter struct{}
+
+func (d *TufCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	tuf, ok := obj.(*rhtasv1alpha1.Tuf)
+	if !ok {
+		return fmt.Errorf("expected a Tuf object but got %T", obj)
+	}
+	rhtasv1alpha1.DefaultTufSpec(&tuf.Spec)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-rhtas-redhat-com-v1alpha1-tuf,mutating=false,failurePolicy=fail,sideEffects=None,groups=rhtas.redhat.com,resources=tufs,verbs=create;update,versions=v1alpha1,name=vtuf.rhtas.redhat.com,admissionReviewVersions=v1
+
+// TufCustomValidator validates the cross-field and cross-object rules of Tuf
+type TufCustomValidator struct {
+	Client client.Client
+}
+
+func (v *TufCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return validateCreate(ctx, "Tuf", obj, v.validate)
+}
+
+func (v *TufCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return validateUpdate(ctx, "Tuf", oldObj, newObj, v.validate)
+}
+
+func (v *TufCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *TufCustomValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, field.ErrorList, error) {
+	tuf, ok := obj.(*rhtasv1alpha1.Tuf)
+	if !ok {
+		return nil, nil, fmt.Errorf("expected a Tuf object but got %T", obj)
+	}
+	warnings, errs, err := validateTufSpec(ctx, v.Client, tuf.Namespace, &tuf.Spec, field.NewPath("spec"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, errs, nil
+}
+
+// validateTufSpec validates the spec of Tuf deployed in the namespace
+func validateTufSpec(ctx context.Context, c client.Client, namespace string, spec *rhtasv1alpha1.TufSpec, path *field.Path) (admission.Warnings, field.ErrorList, error) {
+	var (
+		errs     field.ErrorList
+		warnings admission.Warnings
+	)
+	keys := path.Child("keys")
+	names := make(map[string]bool, len(spec.Keys))
+	for i, key := range spec.Keys {
+		if names[key.Name] {
+			errs = append(errs, field.Duplicate(keys.Index(i).Child("name"), key.Name))
+		}
+		names[key.Name] = true
+
+		if key.Selector == nil {
+			continue
+		}
+		if key.SecretRef != nil {
+			warnings = append(warnings, ignored(keys.Index(i).Child("selector"), keys.Index(i).Child("secretRef")))
+		} else if _, err := metav1.LabelSelectorAsSelector(key.Selector); err != nil {
+			errs = append(errs, field.Invalid(keys.Index(i).Child("selector"), key.Selector, err.Error()))
+		}
+	}
+
+	if spec.Mirror != nil {
+		mirror := path.Child("mirror")
+		missing, err := missingSecrets(ctx, c, namespace, keyRef(mirror.Child("root"), &spec.Mirror.Root))
+		if err != nil {
+			return nil, nil, err
+		}
+		// the keys and the repository are served by the mirrored repository
+		return warnings, append(errs, missing...), nil
+	}
+
+	for i, key := range spec.Keys {
+		keyNamespace := namespace
+		if key.Namespace != "" {
+			keyNamespace = key.Namespace
+		}
+		missing, err := missingSecrets(ctx, c, keyNamespace, keyRef(keys.Index(i).Child("secretRef"), key.SecretRef))
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, missing...)
+	}
+
+	if repository := spec.Repository; repository != nil {
+		refs := [][]secretRef{localRef(path.Child("repository", "signingKeys"), repository.SigningKeys)}
+		if repository.Root != nil {
+			for i := range repository.Root.Keys {
+				refs = append(refs, keyRef(path.Child("repository", "root", "keys").Index(i), &repository.Root.Keys[i]))
+			}
+		}
+		missing, err := missingSecrets(ctx, c, namespace, refs...)
+		if err != nil {
+			return nil, nil, err
+		}
+		errs = append(errs, missing...)
+	}
+	return warnings, errs, nil
+}
@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rhtasv1alpha1 "github.com/securesign/operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Tuf webhook", func() {
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = createNamespace()
+		warnings.Reset()
+	})
+
+	It("defaults the usage of the well-known targets", func() {
+		tuf := &rhtasv1alpha1.Tuf{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: namespace},
+			Spec: rhtasv1alpha1.TufSpec{Keys: []rhtasv1alpha1.TufKey{
+				{Name: "rekor.pub"},
+				{Name: "ctfe.pub", SecretRef: &rhtasv1alpha1.SecretKeySelector{LocalObjectReference: rhtasv1alpha1.LocalObjectReference{Name: "ctlog"}, Key: "public"}},
+			}},
+		}
+		err := k8sClient.Create(ctx, tuf)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring(`spec.keys[1].secretRef: Not found: "secret ` + namespace + `/ctlog"`)))
+
+		createSecret(namespace, "ctlog", "public")
+		// the webhook reads the secret from the cache of the manager
+		Eventually(func() error {
+			return k8sClient.Create(ctx, tuf)
+		}).Should(Succeed())
+		Expect(tuf.Spec.Keys[0].Usage).To(Equal("Rekor"))
+		Expect(tuf.Spec.Keys[1].Usage).To(Equal("CTFE"))
+	})
+
+	It("rejects duplicate keys", func() {
+		tuf := &rhtasv1alpha1.Tuf{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuf", Namespace: namespace},
+			Spec:       rhtasv1alpha1.TufSpec{Keys: []rhtasv1alpha1.TufKey{{Name: "rekor.pub"}, {Name: "rekor.pub"}}},
+		}
+		err := k8sClient.Create(ctx, tuf)
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.keys[1].name: Duplicate value")))
+	})
+})
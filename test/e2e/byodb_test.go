@@ -9,10 +9,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/securesign/operator/api/v1alpha1"
+	"github.com/securesign/operator/internal/controller/common/utils"
 	"github.com/securesign/operator/internal/controller/common/utils/kubernetes"
 	"github.com/securesign/operator/test/e2e/support"
 	"github.com/securesign/operator/test/e2e/support/tas"
 	clients "github.com/securesign/operator/test/e2e/support/tas/cli"
+	apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -107,6 +109,17 @@ var _ = Describe("Securesign install with byodb", Ordered, func() {
 			tas.VerifyTuf(ctx, cli, namespace.Name, securesign.Name)
 		})
 
+		It("Trillian connects to the DB over TLS", func() {
+			for _, name := range []string{"trillian-logserver", "trillian-logsigner"} {
+				deployment := &apps.Deployment{}
+				Expect(cli.Get(ctx, runtimeCli.ObjectKey{Namespace: namespace.Name, Name: securesign.Name + "-" + name}, deployment)).To(Succeed())
+				Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElements(
+					"--mysql_uri=$(MYSQL_USER):$(MYSQL_PASSWORD)@tcp($(MYSQL_HOSTNAME):$(MYSQL_PORT))/$(MYSQL_DATABASE)",
+					"--mysql_tls_ca=/var/run/secrets/tas/db-tls/ca.crt",
+				))
+			}
+		})
+
 		It("No other DB is created", func() {
 			list := &v1.PodList{}
 			Expect(cli.List(ctx, list, runtimeCli.InNamespace(namespace.Name), runtimeCli.MatchingLabels{kubernetes.NameLabel: "trillian-db"})).To(Succeed())
@@ -154,15 +167,27 @@ var _ = Describe("Securesign install with byodb", Ordered, func() {
 })
 
 func createDB(ctx context.Context, cli runtimeCli.Client, ns string, secretRef string) error {
-	err := cli.Create(ctx, &v1.Secret{
+	caCert, caKey, err := utils.CreateCACertificate("my-trillian-mysql-ca", time.Hour)
+	if err != nil {
+		return err
+	}
+	cert, key, err := utils.CreateServerCertificate(caCert, caKey, []string{"my-trillian-mysql", "my-trillian-mysql." + ns + ".svc"}, time.Hour)
+	if err != nil {
+		return err
+	}
+
+	err = cli.Create(ctx, &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: secretRef},
 		Data: map[string][]byte{
-			"mysql-database":      []byte("my_trillian"),
-			"mysql-host":          []byte("my-trillian-mysql"),
-			"mysql-password":      []byte("password"),
-			"mysql-port":          []byte("3300"),
-			"mysql-root-password": []byte("password"),
-			"mysql-user":          []byte("mysql"),
+			"mysql-database":        []byte("my_trillian"),
+			"mysql-host":            []byte("my-trillian-mysql"),
+			"mysql-password":        []byte("password"),
+			"mysql-port":            []byte("3300"),
+			"mysql-root-password":   []byte("password"),
+			"mysql-user":            []byte("mysql"),
+			"mysql-tls-ca":          caCert,
+			"mysql-tls-server-cert": cert,
+			"mysql-tls-server-key":  key,
 		},
 	})
 	if err != nil {
@@ -183,11 +208,29 @@ func createDB(ctx context.Context, cli runtimeCli.Client, ns string, secretRef s
 						EmptyDir: &v1.EmptyDirVolumeSource{},
 					},
 				},
+				{
+					Name: "tls",
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{
+							SecretName: secretRef,
+							Items: []v1.KeyToPath{
+								{Key: "mysql-tls-server-cert", Path: "tls.crt"},
+								{Key: "mysql-tls-server-key", Path: "tls.key"},
+							},
+						},
+					},
+				},
 			},
 			Containers: []v1.Container{
 				{
 					Name:  "mysql",
 					Image: "registry.redhat.io/rhtas-tech-preview/trillian-database-rhel9@sha256:fe4758ff57a9a6943a4655b21af63fb579384dc51838af85d0089c04290b4957",
+					Args: []string{
+						"run-mysqld",
+						"--ssl-cert=/etc/mysql-tls/tls.crt",
+						"--ssl-key=/etc/mysql-tls/tls.key",
+						"--require-secure-transport=ON",
+					},
 					Env: []v1.EnvVar{
 						{
 							Name: "MYSQL_ROOT_PASSWORD",
@@ -249,6 +292,11 @@ func createDB(ctx context.Context, cli runtimeCli.Client, ns string, secretRef s
 							Name:      "storage",
 							MountPath: "/var/lib/mysql",
 						},
+						{
+							Name:      "tls",
+							MountPath: "/etc/mysql-tls",
+							ReadOnly:  true,
+						},
 					},
 				},
 			},
@@ -130,11 +130,11 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 	Describe("Inject Fulcio CA", func() {
 		It("Pods are restarted after update", func() {
 			By("Storing current deployment observed generations")
-			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			Expect(tufGeneration).Should(BeNumerically(">", 0))
-			ctlogGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: ctlog.DeploymentName})
+			ctlogGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + ctlog.DeploymentName})
 			Expect(ctlogGeneration).Should(BeNumerically(">", 0))
-			fulcioGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: fulcio.DeploymentName})
+			fulcioGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + fulcio.DeploymentName})
 			Expect(fulcioGeneration).Should(BeNumerically(">", 0))
 
 			Expect(cli.Get(ctx, runtimeCli.ObjectKeyFromObject(securesign), securesign)).To(Succeed())
@@ -168,15 +168,15 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 			Expect(cli.Create(ctx, initFulcioSecret(namespace.Name, "my-fulcio-secret"))).Should(Succeed())
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			}).Should(BeNumerically(">", tufGeneration))
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: ctlog.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + ctlog.DeploymentName})
 			}).Should(BeNumerically(">", ctlogGeneration))
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: fulcio.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + fulcio.DeploymentName})
 			}).Should(BeNumerically(">", fulcioGeneration))
 
 			tas.VerifyTuf(ctx, cli, namespace.Name, securesign.Name)
@@ -200,7 +200,7 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 	Describe("Fulcio Config update", func() {
 		It("Pods are restarted after update", func() {
 			By("Storing current deployment observed generations")
-			fulcioGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: fulcio.DeploymentName})
+			fulcioGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + fulcio.DeploymentName})
 			Expect(fulcioGeneration).Should(BeNumerically(">", 0))
 
 			Expect(cli.Get(ctx, runtimeCli.ObjectKeyFromObject(securesign), securesign)).To(Succeed())
@@ -221,7 +221,7 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 			Expect(cli.Update(ctx, securesign)).To(Succeed())
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: fulcio.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + fulcio.DeploymentName})
 			}).Should(BeNumerically(">", fulcioGeneration))
 
 			tas.VerifyFulcio(ctx, cli, namespace.Name, securesign.Name)
@@ -249,7 +249,7 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 	Describe("Inject Rekor signer", func() {
 		It("Pods are restarted after update", func() {
 			By("Storing current deployment observed generations")
-			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			Expect(tufGeneration).Should(BeNumerically(">", 0))
 			rekorGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: rekor.ServerComponentName})
 			Expect(rekorGeneration).Should(BeNumerically(">", 0))
@@ -274,7 +274,7 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 			Expect(cli.Create(ctx, initRekorSecret(namespace.Name, "my-rekor-secret"))).To(Succeed())
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			}).Should(BeNumerically(">", tufGeneration))
 
 			Eventually(func() int64 {
@@ -300,9 +300,9 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 	Describe("Inject CTL secret", func() {
 		It("Pods are restarted after update", func() {
 			By("Storing current deployment observed generations")
-			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+			tufGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			Expect(tufGeneration).Should(BeNumerically(">", 0))
-			ctlogGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: ctlog.DeploymentName})
+			ctlogGeneration := getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + ctlog.DeploymentName})
 			Expect(ctlogGeneration).Should(BeNumerically(">", 0))
 
 			Expect(cli.Get(ctx, runtimeCli.ObjectKeyFromObject(securesign), securesign)).To(Succeed())
@@ -327,11 +327,11 @@ var _ = Describe("Securesign hot update", Ordered, func() {
 			Expect(cli.Create(ctx, initCTSecret(namespace.Name, "my-ctlog-secret"))).Should(Succeed())
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: tuf.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + tuf.DeploymentName})
 			}).Should(BeNumerically(">", tufGeneration))
 
 			Eventually(func() int64 {
-				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: ctlog.DeploymentName})
+				return getDeploymentGeneration(types.NamespacedName{Namespace: namespace.Name, Name: securesign.Name + "-" + ctlog.DeploymentName})
 			}).Should(BeNumerically(">", ctlogGeneration))
 
 			tas.VerifyTuf(ctx, cli, namespace.Name, securesign.Name)